@@ -0,0 +1,327 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"flyby/internal/concourse"
+	"flyby/internal/config"
+)
+
+// command is a non-interactive subcommand for use in scripts
+type command struct {
+	usage       string
+	description string
+	run         func(flags *commandFlags, args []string) error
+}
+
+// commands are the subcommands run instead of the TUI, by name
+var commands = map[string]command{
+	"pipelines": {"pipelines -t TARGET [--include-archived] [--json]", "List pipelines", runPipelines},
+	"jobs":      {"jobs -t TARGET -p PIPELINE [--json]", "List a pipeline's jobs", runJobs},
+	"builds":    {"builds -t TARGET -j PIPELINE/JOB [-c COUNT] [--json]", "List a job's builds", runBuilds},
+	"trigger":   {"trigger -t TARGET -j PIPELINE/JOB [--json]", "Trigger a job", runTrigger},
+	"check":     {"check -t TARGET -r PIPELINE/RESOURCE [--json]", "Check a resource", runCheck},
+}
+
+// commandOrder is the order commands are listed in the help
+var commandOrder = []string{"pipelines", "jobs", "builds", "trigger", "check"}
+
+// commandFlags are the flags every subcommand accepts
+type commandFlags struct {
+	*flag.FlagSet
+	target string
+	flyrc  string
+	json   bool
+	demo   bool
+}
+
+// newCommandFlags creates the flag set of a subcommand
+func newCommandFlags(name string, cmd command) *commandFlags {
+	flags := &commandFlags{FlagSet: flag.NewFlagSet(name, flag.ExitOnError)}
+	flags.StringVar(&flags.target, "t", "", "target from the flyrc, defaults to default_target")
+	flags.StringVar(&flags.target, "target", "", "target from the flyrc, defaults to default_target")
+	flags.StringVar(&flags.flyrc, "flyrc", "", "fly configuration file, defaults to $FLYBY_FLYRC or ~/.flyrc")
+	flags.BoolVar(&flags.json, "json", false, "print JSON instead of a table")
+	flags.BoolVar(&flags.demo, "demo", false, "use generated data instead of fly")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: flyby %s\n\n%s\n\nFlags:\n", cmd.usage, cmd.description)
+		flags.PrintDefaults()
+	}
+	return flags
+}
+
+// client returns a client for the selected target, checking it exists first
+func (f *commandFlags) client() (*concourse.Client, error) {
+	if f.demo {
+		concourse.SetDefaultExecutor(concourse.NewDemoExecutor())
+		if f.target == "" {
+			f.target = "production"
+		}
+		return concourse.NewClient(f.target), nil
+	}
+
+	if !checkFlyAvailable() {
+		return nil, fmt.Errorf("fly CLI not found in PATH")
+	}
+	if err := useFlyrc(f.flyrc); err != nil {
+		return nil, err
+	}
+	settings, err := config.LoadSettings()
+	if err != nil {
+		return nil, err
+	}
+	concourse.SetCommandTimeouts(settings.GetCommandTimeouts())
+	concourse.SetRetries(settings.Retries)
+	if f.target == "" {
+		f.target = settings.DefaultTarget
+	}
+	if f.target == "" {
+		return nil, fmt.Errorf("no target given, use -t TARGET or set default_target")
+	}
+
+	configManager, err := config.NewConfigManager()
+	if err != nil {
+		return nil, err
+	}
+	if _, exists := configManager.GetTarget(f.target); !exists {
+		return nil, fmt.Errorf("unknown target '%s' in %s", f.target, configManager.GetConfigPath())
+	}
+	return concourse.NewClient(f.target), nil
+}
+
+// runCommand runs the named subcommand and returns the process exit code
+func runCommand(name string, args []string) int {
+	cmd := commands[name]
+	if err := cmd.run(newCommandFlags(name, cmd), args); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// runPipelines lists the pipelines of a target
+func runPipelines(flags *commandFlags, args []string) error {
+	var includeArchived bool
+	flags.BoolVar(&includeArchived, "include-archived", false, "list archived pipelines too")
+	flags.Parse(args)
+	client, err := flags.client()
+	if err != nil {
+		return err
+	}
+
+	pipelines, err := client.GetPipelines(includeArchived)
+	if err != nil {
+		return err
+	}
+	if flags.json {
+		return printJSON(pipelines)
+	}
+
+	table := newTable("NAME", "STATUS", "PUBLIC", "UPDATED")
+	for _, pipeline := range pipelines {
+		status := "active"
+		if pipeline.Archived {
+			status = "archived"
+		} else if pipeline.Paused {
+			status = "paused"
+		}
+		table.row(pipeline.Ref(), status, yesNo(pipeline.Public), formatTime(pipeline.GetLastUpdated()))
+	}
+	return table.flush()
+}
+
+// runJobs lists the jobs of a pipeline
+func runJobs(flags *commandFlags, args []string) error {
+	var pipeline string
+	flags.StringVar(&pipeline, "p", "", "pipeline name")
+	flags.StringVar(&pipeline, "pipeline", "", "pipeline name")
+	flags.Parse(args)
+	if pipeline == "" {
+		return fmt.Errorf("no pipeline given, use -p PIPELINE")
+	}
+	client, err := flags.client()
+	if err != nil {
+		return err
+	}
+
+	jobs, err := client.GetJobs(pipeline)
+	if err != nil {
+		return err
+	}
+	if flags.json {
+		return printJSON(jobs)
+	}
+
+	table := newTable("NAME", "LATEST", "STATUS", "RUNNING")
+	for _, job := range jobs {
+		latest, status := "n/a", "n/a"
+		if job.FinishedBuild.Name != "" {
+			latest, status = "#"+job.FinishedBuild.Name, job.FinishedBuild.Status
+		}
+		running := ""
+		if job.NextBuild.Name != "" {
+			running = fmt.Sprintf("#%s (%s)", job.NextBuild.Name, job.NextBuild.Status)
+		}
+		table.row(job.Name, latest, status, running)
+	}
+	return table.flush()
+}
+
+// runBuilds lists the builds of a job
+func runBuilds(flags *commandFlags, args []string) error {
+	var job string
+	var count int
+	flags.StringVar(&job, "j", "", "job as PIPELINE/JOB")
+	flags.StringVar(&job, "job", "", "job as PIPELINE/JOB")
+	flags.IntVar(&count, "c", 50, "number of builds")
+	flags.IntVar(&count, "count", 50, "number of builds")
+	flags.Parse(args)
+	pipeline, jobName, err := splitPair(job, "-j", "PIPELINE/JOB")
+	if err != nil {
+		return err
+	}
+	client, err := flags.client()
+	if err != nil {
+		return err
+	}
+
+	builds, err := client.GetBuilds(pipeline, jobName, count)
+	if err != nil {
+		return err
+	}
+	if flags.json {
+		return printJSON(builds)
+	}
+
+	table := newTable("BUILD", "STATUS", "STARTED", "DURATION")
+	for _, build := range builds {
+		duration := ""
+		if start, end := build.GetStartTime(), build.GetEndTime(); !start.IsZero() && !end.IsZero() {
+			duration = end.Sub(start).String()
+		}
+		table.row("#"+build.Name, build.Status, formatTime(build.GetStartTime()), duration)
+	}
+	return table.flush()
+}
+
+// runTrigger triggers a job
+func runTrigger(flags *commandFlags, args []string) error {
+	var job string
+	flags.StringVar(&job, "j", "", "job as PIPELINE/JOB")
+	flags.StringVar(&job, "job", "", "job as PIPELINE/JOB")
+	flags.Parse(args)
+	pipeline, jobName, err := splitPair(job, "-j", "PIPELINE/JOB")
+	if err != nil {
+		return err
+	}
+	client, err := flags.client()
+	if err != nil {
+		return err
+	}
+
+	success, output, err := client.TriggerJobWithOutput(pipeline, jobName)
+	return printResult(flags.json, success, output, err)
+}
+
+// runCheck checks a resource for new versions
+func runCheck(flags *commandFlags, args []string) error {
+	var resource string
+	flags.StringVar(&resource, "r", "", "resource as PIPELINE/RESOURCE")
+	flags.StringVar(&resource, "resource", "", "resource as PIPELINE/RESOURCE")
+	flags.Parse(args)
+	pipeline, resourceName, err := splitPair(resource, "-r", "PIPELINE/RESOURCE")
+	if err != nil {
+		return err
+	}
+	client, err := flags.client()
+	if err != nil {
+		return err
+	}
+
+	success, output, err := client.CheckResourceWithOutput(pipeline, resourceName)
+	return printResult(flags.json, success, output, err)
+}
+
+// printResult prints the outcome of a fly action and turns a failure into an error
+func printResult(asJSON, success bool, output string, err error) error {
+	if err != nil {
+		return err
+	}
+	if asJSON {
+		if err := printJSON(struct {
+			Success bool   `json:"success"`
+			Output  string `json:"output"`
+		}{success, output}); err != nil {
+			return err
+		}
+	} else if !success && output != "" {
+		fmt.Fprintln(os.Stderr, output)
+	} else if output != "" {
+		fmt.Println(output)
+	}
+	if !success {
+		return fmt.Errorf("fly reported a failure")
+	}
+	return nil
+}
+
+// splitPair splits a "pipeline/name" flag value at its last "/", since an
+// instanced pipeline's ref like "app/branch:main" has one of its own
+func splitPair(value, flagName, format string) (string, string, error) {
+	i := strings.LastIndex(value, "/")
+	if i <= 0 || i == len(value)-1 {
+		return "", "", fmt.Errorf("%s must be given as %s", flagName, format)
+	}
+	return value[:i], value[i+1:], nil
+}
+
+// printJSON prints v as indented JSON
+func printJSON(v interface{}) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// table prints aligned columns
+type table struct {
+	writer *tabwriter.Writer
+}
+
+// newTable starts a table with a header row
+func newTable(columns ...string) *table {
+	t := &table{writer: tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)}
+	t.row(columns...)
+	return t
+}
+
+// row adds a row to the table
+func (t *table) row(columns ...string) {
+	fmt.Fprintln(t.writer, strings.Join(columns, "\t"))
+}
+
+// flush prints the table
+func (t *table) flush() error {
+	return t.writer.Flush()
+}
+
+// formatTime formats a timestamp for table output
+func formatTime(value time.Time) string {
+	if value.IsZero() {
+		return "n/a"
+	}
+	return value.Format("2006-01-02 15:04:05")
+}
+
+// yesNo formats a boolean for table output
+func yesNo(value bool) string {
+	if value {
+		return "yes"
+	}
+	return "no"
+}
@@ -4,16 +4,37 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"strings"
 
+	"flyby/internal/concourse"
+	"flyby/internal/config"
 	"flyby/internal/tui"
 )
 
 const version = "0.1.0"
 
 func main() {
-	// Check if user wants version info
+	// Scriptable subcommands run without the TUI
 	if len(os.Args) > 1 {
-		switch os.Args[1] {
+		if _, ok := commands[os.Args[1]]; ok {
+			os.Exit(runCommand(os.Args[1], os.Args[2:]))
+		}
+	}
+
+	plain := false
+	demo := false
+	target := ""
+	flyrc := ""
+	var path []string
+	args := os.Args[1:]
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		// Options taking a value also accept --option=value
+		if name, value, ok := strings.Cut(arg, "="); ok && (name == "--target" || name == "--flyrc") {
+			arg = name
+			args = append(args[:i+1], append([]string{value}, args[i+1:]...)...)
+		}
+		switch arg {
 		case "--version", "-v":
 			fmt.Printf("FlyBy v%s\n", version)
 			fmt.Println("A Terminal UI for Concourse CI")
@@ -21,22 +42,82 @@ func main() {
 		case "--help", "-h":
 			printHelp()
 			os.Exit(0)
+		case "--plain":
+			plain = true
+		case "--demo":
+			demo = true
+		case "--target", "-t":
+			if i+1 >= len(args) {
+				fmt.Printf("Option %s requires a target name\n", arg)
+				os.Exit(1)
+			}
+			i++
+			target = args[i]
+		case "--flyrc":
+			if i+1 >= len(args) {
+				fmt.Printf("Option %s requires a file path\n", arg)
+				os.Exit(1)
+			}
+			i++
+			flyrc = args[i]
 		default:
-			fmt.Printf("Unknown option: %s\n", os.Args[1])
+			if !strings.HasPrefix(arg, "-") && path == nil {
+				path = strings.Split(arg, "/")
+				continue
+			}
+			fmt.Printf("Unknown option: %s\n", arg)
 			fmt.Println("Use --help for usage information")
 			os.Exit(1)
 		}
 	}
 
-	// Check if fly CLI is available
-	if !checkFlyAvailable() {
+	// A target/pipeline/job path opens that pipeline's jobs or job's builds
+	var pipeline, job string
+	if path != nil {
+		if target != "" {
+			fmt.Println("Use either --target or a target/pipeline/job path, not both")
+			os.Exit(1)
+		}
+		if !validPath(path) {
+			fmt.Printf("Invalid path: %s\n", strings.Join(path, "/"))
+			fmt.Println("Expected TARGET, TARGET/PIPELINE or TARGET/PIPELINE/JOB")
+			os.Exit(1)
+		}
+		path = append(path, "", "")
+		target, pipeline, job = path[0], path[1], path[2]
+	}
+
+	// Check if fly CLI is available, demo mode doesn't need it
+	if !demo && !checkFlyAvailable() {
 		fmt.Println("Error: fly CLI not found in PATH")
 		fmt.Println("Please install the Concourse fly CLI and ensure it's in your PATH")
 		fmt.Println("Download from: https://concourse-ci.org/download.html")
 		os.Exit(1)
 	}
 
-	app := tui.NewApp()
+	if err := useFlyrc(flyrc); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	settings, err := config.LoadSettings()
+	if err != nil {
+		fmt.Printf("Error loading FlyBy config: %v\n", err)
+		os.Exit(1)
+	}
+	if plain {
+		settings.Plain = true
+	}
+	settings.Demo = demo
+	settings.StartTarget = target
+	settings.StartPipeline = pipeline
+	settings.StartJob = job
+	// See https://no-color.org
+	if os.Getenv("NO_COLOR") != "" {
+		settings.NoColor = true
+	}
+
+	app := tui.NewApp(settings)
 	if err := app.Run(); err != nil {
 		fmt.Printf("Error running FlyBy: %v\n", err)
 		os.Exit(1)
@@ -47,8 +128,19 @@ func printHelp() {
 	fmt.Printf("FlyBy v%s - Terminal UI for Concourse CI\n\n", version)
 	fmt.Println("Usage:")
 	fmt.Println("  flyby              Start the Terminal UI")
+	fmt.Println("  flyby -t TARGET    Open the pipelines of a target from ~/.flyrc")
+	fmt.Println("  flyby TARGET/PIPELINE[/JOB]")
+	fmt.Println("                     Open a pipeline's jobs or a job's builds")
 	fmt.Println("  flyby --version    Show version information")
 	fmt.Println("  flyby --help       Show this help message")
+	fmt.Println("  flyby --plain      Disable colors and use ASCII-only glyphs")
+	fmt.Println("  flyby --demo       Try FlyBy with generated data, no Concourse needed")
+	fmt.Println("  flyby --flyrc PATH Use another fly configuration file than ~/.flyrc")
+	fmt.Println("")
+	fmt.Println("Commands (for scripts, add --help for flags):")
+	for _, name := range commandOrder {
+		fmt.Printf("  flyby %-54s %s\n", commands[name].usage, commands[name].description)
+	}
 	fmt.Println("")
 	fmt.Println("Features:")
 	fmt.Println("  • Manage Concourse targets and teams")
@@ -60,6 +152,12 @@ func printHelp() {
 	fmt.Println("  • fly CLI installed and available in PATH")
 	fmt.Println("  • Configured Concourse targets in ~/.flyrc")
 	fmt.Println("")
+	fmt.Println("Configuration:")
+	fmt.Println("  • Optional FlyBy settings in $XDG_CONFIG_HOME/flyby/config.yaml or ~/.config/flyby/config.yaml")
+	fmt.Println("  • FLYBY_CONFIG overrides the FlyBy settings file")
+	fmt.Println("  • FLYBY_FLYRC overrides the fly configuration file")
+	fmt.Println("  • NO_COLOR disables colors")
+	fmt.Println("")
 	fmt.Println("Navigation:")
 	fmt.Println("  • Use arrow keys or j/k to navigate")
 	fmt.Println("  • Press Enter to select items")
@@ -67,6 +165,34 @@ func printHelp() {
 	fmt.Println("  • Press q to quit")
 }
 
+// useFlyrc points FlyBy and fly at the fly configuration given by --flyrc,
+// $FLYBY_FLYRC or the default ~/.flyrc
+func useFlyrc(path string) error {
+	if path != "" {
+		config.SetFlyrcPath(path)
+	}
+	flyrc, err := config.FlyrcPath()
+	if err != nil {
+		return err
+	}
+	concourse.SetDefaultExecutor(concourse.ExecExecutor{Flyrc: flyrc})
+	return nil
+}
+
+// validPath reports whether a split target/pipeline/job path has between one
+// and three non-empty segments
+func validPath(path []string) bool {
+	if len(path) > 3 {
+		return false
+	}
+	for _, segment := range path {
+		if segment == "" {
+			return false
+		}
+	}
+	return true
+}
+
 func checkFlyAvailable() bool {
 	_, err := exec.LookPath("fly")
 	return err == nil
@@ -0,0 +1,79 @@
+package concourse
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// cacheTTL is how long the output of a command that only fetches data is
+// reused instead of running fly again
+const cacheTTL = 10 * time.Second
+
+// cacheEntry is the output of a fly command and when it ran
+type cacheEntry struct {
+	output []byte
+	stored time.Time
+}
+
+var (
+	cacheMu sync.Mutex
+	cache   = make(map[string]cacheEntry) // by cacheKey
+)
+
+// cacheable reports whether the output of a fly command can be reused: it
+// only fetches data, and isn't the login check, which must ask the target
+func cacheable(args []string) bool {
+	return len(args) > 0 && readCommands[args[0]] && args[0] != "status"
+}
+
+// cacheKey identifies a command run against a target
+func cacheKey(target string, args []string) string {
+	return target + "\x00" + strings.Join(args, "\x00")
+}
+
+// cached returns the output of a command that ran against target within
+// cacheTTL
+func cached(target string, args []string) ([]byte, bool) {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+	entry, ok := cache[cacheKey(target, args)]
+	if !ok || time.Since(entry.stored) >= cacheTTL {
+		return nil, false
+	}
+	return entry.output, true
+}
+
+// storeCached keeps the output of a command for reuse, dropping what went
+// stale meanwhile
+func storeCached(target string, args []string, output []byte) {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+	for key, entry := range cache {
+		if time.Since(entry.stored) >= cacheTTL {
+			delete(cache, key)
+		}
+	}
+	cache[cacheKey(target, args)] = cacheEntry{output: output, stored: time.Now()}
+}
+
+// invalidateCache forgets the output of every command run against target,
+// as a command that changes something may have made them outdated
+func invalidateCache(target string) {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+	prefix := target + "\x00"
+	for key := range cache {
+		if strings.HasPrefix(key, prefix) {
+			delete(cache, key)
+		}
+	}
+}
+
+// ClearCache forgets the output of every command, so the next ones run fly
+// again whatever the target
+func ClearCache() {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+	cache = make(map[string]cacheEntry)
+}
@@ -1,11 +1,15 @@
 package concourse
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"os"
-	"os/exec"
+	"io"
+	"path"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -18,6 +22,7 @@ type Pipeline struct {
 	Archived bool   `json:"archived"`
 	TeamName string `json:"team_name"`
 	LastUpdatedUnix int64 `json:"last_updated"`
+	InstanceVars InstanceVars `json:"instance_vars,omitempty"`
 }
 
 // GetLastUpdated returns the last updated time as a proper time.Time
@@ -28,13 +33,32 @@ func (p Pipeline) GetLastUpdated() time.Time {
 	return time.Unix(p.LastUpdatedUnix, 0)
 }
 
+// PipelineGroup is a named set of a pipeline's jobs, shown as a tab by the
+// web UI. Jobs may be glob patterns.
+type PipelineGroup struct {
+	Name string   `json:"name"`
+	Jobs []string `json:"jobs"`
+}
+
+// Contains reports whether the job belongs to the group
+func (g PipelineGroup) Contains(job string) bool {
+	for _, pattern := range g.Jobs {
+		if matched, err := path.Match(pattern, job); pattern == job || (err == nil && matched) {
+			return true
+		}
+	}
+	return false
+}
+
 // Job represents a pipeline job
 type Job struct {
 	ID           int    `json:"id"`
 	Name         string `json:"name"`
 	PipelineName string `json:"pipeline_name"`
+	PipelineInstanceVars InstanceVars `json:"pipeline_instance_vars,omitempty"`
 	PipelineID   int    `json:"pipeline_id"`
 	TeamName     string `json:"team_name"`
+	Paused       bool   `json:"paused,omitempty"`
 	NextBuild    Build  `json:"next_build,omitempty"`
 	FinishedBuild Build `json:"finished_build,omitempty"`
 }
@@ -51,6 +75,7 @@ type Build struct {
 	EndTimeUnix   int64 `json:"end_time,omitempty"`
 	PipelineID    int   `json:"pipeline_id"`
 	PipelineName  string `json:"pipeline_name"`
+	PipelineInstanceVars InstanceVars `json:"pipeline_instance_vars,omitempty"`
 }
 
 // GetStartTime returns the start time as a proper time.Time
@@ -69,10 +94,58 @@ func (b Build) GetEndTime() time.Time {
 	return time.Unix(b.EndTimeUnix, 0)
 }
 
+// BuildPreparation reports what a build that hasn't started yet waits on.
+// Each check is "blocking", "not_blocking" or "unknown".
+type BuildPreparation struct {
+	BuildID             int               `json:"build_id"`
+	PausedPipeline      string            `json:"paused_pipeline"`
+	PausedJob           string            `json:"paused_job"`
+	MaxRunningBuilds    string            `json:"max_running_builds"`
+	Inputs              map[string]string `json:"inputs"`
+	InputsSatisfied     string            `json:"inputs_satisfied"`
+	MissingInputReasons map[string]string `json:"missing_input_reasons"`
+}
+
+// Blockers describes why the build can't start yet, none once it is only
+// waiting to be scheduled
+func (p BuildPreparation) Blockers() []string {
+	var blockers []string
+	if p.PausedPipeline == "blocking" {
+		blockers = append(blockers, "pipeline is paused")
+	}
+	if p.PausedJob == "blocking" {
+		blockers = append(blockers, "job is paused")
+	}
+	if p.MaxRunningBuilds == "blocking" {
+		blockers = append(blockers, "max in flight reached, waiting for running builds to finish")
+	}
+	if p.InputsSatisfied == "blocking" {
+		var inputs []string
+		for input, status := range p.Inputs {
+			if status == "blocking" {
+				inputs = append(inputs, input)
+			}
+		}
+		sort.Strings(inputs)
+		for _, input := range inputs {
+			if reason := p.MissingInputReasons[input]; reason != "" {
+				blockers = append(blockers, fmt.Sprintf("waiting for input %s: %s", input, reason))
+			} else {
+				blockers = append(blockers, "waiting for input "+input)
+			}
+		}
+		if len(inputs) == 0 {
+			blockers = append(blockers, "waiting for inputs")
+		}
+	}
+	return blockers
+}
+
 // Resource represents a pipeline resource
 type Resource struct {
 	Name         string                 `json:"name"`
 	PipelineName string                 `json:"pipeline_name"`
+	PipelineInstanceVars InstanceVars   `json:"pipeline_instance_vars,omitempty"`
 	TeamName     string                 `json:"team_name"`
 	Type         string                 `json:"type"`
 	LastCheckedUnix int64               `json:"last_checked,omitempty"`
@@ -100,14 +173,84 @@ type Team struct {
 	Name string `json:"name"`
 }
 
+// ActiveUser represents a user who logged in to a Concourse recently
+type ActiveUser struct {
+	ID            int    `json:"id"`
+	Username      string `json:"username"`
+	Connector     string `json:"connector"`
+	LastLoginUnix int64  `json:"last_login"`
+}
+
+// GetLastLogin returns the last login time as a proper time.Time
+func (u ActiveUser) GetLastLogin() time.Time {
+	if u.LastLoginUnix == 0 {
+		return time.Time{}
+	}
+	return time.Unix(u.LastLoginUnix, 0)
+}
+
+// UserInfo describes the user fly is logged in as
+type UserInfo struct {
+	Sub           string              `json:"sub"`
+	Name          string              `json:"name"`
+	UserID        string              `json:"user_id"`
+	UserName      string              `json:"user_name"`
+	Email         string              `json:"email"`
+	IsAdmin       bool                `json:"is_admin"`
+	IsSystem      bool                `json:"is_system"`
+	Teams         map[string][]string `json:"teams"` // team -> roles
+	Connector     string              `json:"connector"`
+	DisplayUserID string              `json:"display_user_id"`
+}
+
+// DisplayName returns the most readable name the user has
+func (u UserInfo) DisplayName() string {
+	for _, name := range []string{u.UserName, u.DisplayUserID, u.Name, u.UserID} {
+		if name != "" {
+			return name
+		}
+	}
+	return "unknown user"
+}
+
+// ErrOutOfSync is returned when the fly version doesn't match the target's,
+// which running Sync fixes
+var ErrOutOfSync = errors.New("fly is out of sync with the target")
+
+// ErrCanceled is returned for commands killed by CancelReads
+var ErrCanceled = errors.New("fly command canceled")
+
+// readCommands are the fly commands that only fetch data. They aren't kept
+// for RetryPending since views reload them anyway.
+var readCommands = map[string]bool{
+	"pipelines": true, "jobs": true, "resources": true, "builds": true, "teams": true, "status": true, "curl": true, "userinfo": true, "active-users": true, "get-pipeline": true,
+}
+
 // Client wraps fly CLI operations
 type Client struct {
-	target string
+	target   string
+	executor FlyExecutor
+	uncached bool // run every command, refreshing the cache rather than reading it
+	
+	pendingMu sync.Mutex
+	pending   []string // the last command refused because fly was out of sync
 }
 
 // NewClient creates a new Concourse client for a specific target
 func NewClient(target string) *Client {
-	return &Client{target: target}
+	return NewClientWithExecutor(target, defaultExecutor)
+}
+
+// NewClientWithExecutor creates a client that runs fly through executor
+func NewClientWithExecutor(target string, executor FlyExecutor) *Client {
+	return &Client{target: target, executor: executor}
+}
+
+// Uncached returns a client for the same target that always runs fly, for
+// polling what changes by itself like running builds. What it fetches still
+// refreshes the cache.
+func (c *Client) Uncached() *Client {
+	return &Client{target: c.target, executor: c.executor, uncached: true}
 }
 
 // GetTarget returns the target name
@@ -115,24 +258,123 @@ func (c *Client) GetTarget() string {
 	return c.target
 }
 
-// execFly executes a fly command and returns the output
-func (c *Client) execFly(args ...string) ([]byte, error) {
+// targetArgs prefixes args with the client's target
+func (c *Client) targetArgs(args []string) []string {
 	if c.target != "" {
 		args = append([]string{"-t", c.target}, args...)
 	}
+	return args
+}
+
+// execFly executes a fly command and returns the output. Commands that only
+// fetch data reuse the output of the same command for a few seconds, and are
+// retried when enabled if the target seems unreachable. Other commands make
+// the target's outputs outdated.
+func (c *Client) execFly(args ...string) ([]byte, error) {
+	if !cacheable(args) {
+		defer invalidateCache(c.target)
+	} else if output, ok := cached(c.target, args); ok && !c.uncached {
+		return output, nil
+	}
 	
-	cmd := exec.Command("fly", args...)
-	output, err := cmd.Output()
-	if err != nil {
-		if exitError, ok := err.(*exec.ExitError); ok {
-			return nil, fmt.Errorf("fly command failed: %s", string(exitError.Stderr))
+	output, err := c.runFly(args)
+	for attempt := 0; err != nil && readCommands[args[0]] && attempt < retryCount() && IsTransientError(err); attempt++ {
+		if err := waitToRetry(args, attempt); err != nil {
+			return nil, err
 		}
-		return nil, fmt.Errorf("failed to execute fly command: %w", err)
+		output, err = c.runFly(args)
+	}
+	if err != nil {
+		return nil, err
 	}
 	
+	if cacheable(args) {
+		storeCached(c.target, args, output)
+	}
 	return output, nil
 }
 
+// runFly runs a fly command once and returns the output
+func (c *Client) runFly(args []string) ([]byte, error) {
+	ctx, cancel := commandContext(args)
+	defer cancel()
+	stdout, stderr, exitCode, err := c.executor.Run(ctx, c.targetArgs(args))
+	if err := commandInterrupted(ctx, args); err != nil {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute fly command: %w", err)
+	}
+	if exitCode != 0 {
+		if outOfSync(stderr) {
+			return nil, c.outOfSyncError(args, stderr)
+		}
+		return nil, fmt.Errorf("fly command failed: %s", stderr)
+	}
+	return []byte(stdout), nil
+}
+
+// execFlyCombined executes a fly command and returns its trimmed stdout and
+// stderr together. ok is false when fly ran but exited non-zero, err is only
+// set when fly couldn't be run or is out of sync with the target.
+func (c *Client) execFlyCombined(args ...string) (output string, ok bool, err error) {
+	// Only used for commands that change something
+	defer invalidateCache(c.target)
+	ctx, cancel := commandContext(args)
+	defer cancel()
+	stdout, stderr, exitCode, err := c.executor.Run(ctx, c.targetArgs(args))
+	if err := commandInterrupted(ctx, args); err != nil {
+		return "", false, err
+	}
+	output = strings.TrimSpace(strings.TrimSpace(stdout) + "\n" + strings.TrimSpace(stderr))
+	if err == nil && exitCode != 0 && outOfSync(stderr) {
+		err = c.outOfSyncError(args, stderr)
+	}
+	return output, err == nil && exitCode == 0, err
+}
+
+// outOfSyncError remembers a command fly refused to run so it can be retried
+// after syncing, and returns the ErrOutOfSync describing it
+func (c *Client) outOfSyncError(args []string, stderr string) error {
+	if len(args) > 0 && !readCommands[args[0]] {
+		c.pendingMu.Lock()
+		c.pending = append([]string(nil), args...)
+		c.pendingMu.Unlock()
+	}
+	return fmt.Errorf("%w: %s", ErrOutOfSync, strings.TrimSpace(stderr))
+}
+
+// PendingCommand returns the command line of the last command fly refused to
+// run because it was out of sync, or "" when there is none
+func (c *Client) PendingCommand() string {
+	c.pendingMu.Lock()
+	defer c.pendingMu.Unlock()
+	if c.pending == nil {
+		return ""
+	}
+	return CommandRecord{Args: append([]string{"fly"}, c.targetArgs(c.pending)...)}.Command()
+}
+
+// RetryPending runs the command that failed because fly was out of sync
+// again, typically right after Sync, and forgets it. ok and err are as for
+// the *WithOutput methods; with nothing pending it returns "", true, nil.
+func (c *Client) RetryPending() (output string, ok bool, err error) {
+	c.pendingMu.Lock()
+	args := c.pending
+	c.pending = nil
+	c.pendingMu.Unlock()
+	
+	if args == nil {
+		return "", true, nil
+	}
+	return c.execFlyCombined(args...)
+}
+
+// outOfSync reports whether fly refused to run because of a version mismatch
+func outOfSync(stderr string) bool {
+	return strings.Contains(strings.ToLower(stderr), "out of sync with the target")
+}
+
 // Login authenticates with the target
 func (c *Client) Login(teamName, username, password string) error {
 	args := []string{"login"}
@@ -150,24 +392,78 @@ func (c *Client) Login(teamName, username, password string) error {
 	return err
 }
 
-// LoginInteractive performs interactive login (opens browser)
-func (c *Client) LoginInteractive(apiURL, teamName string) error {
+// TLSOptions are fly login's options for targets behind self-signed or
+// mutual TLS
+type TLSOptions struct {
+	Insecure   bool   // skip verification of the server certificate
+	CACert     string // path to the CA certificate to trust
+	ClientCert string // path to the client certificate for mutual TLS
+	ClientKey  string // path to the client certificate's private key
+	
+	// CACertPEM is a CA certificate's contents, as fly saves it in the
+	// flyrc. Only FetchInfo uses it, fly login needs CACert.
+	CACertPEM string
+}
+
+// Args returns the fly login flags for the options
+func (o TLSOptions) Args() []string {
+	var args []string
+	if o.Insecure {
+		args = append(args, "-k")
+	}
+	if o.CACert != "" {
+		args = append(args, "--ca-cert", o.CACert)
+	}
+	if o.ClientCert != "" {
+		args = append(args, "--client-cert", o.ClientCert)
+	}
+	if o.ClientKey != "" {
+		args = append(args, "--client-key", o.ClientKey)
+	}
+	return args
+}
+
+// LoginArgs returns the fly arguments of an interactive login to apiURL
+func LoginArgs(apiURL, teamName string, tls TLSOptions) []string {
 	args := []string{"login", "-c", apiURL}
 	if teamName != "" {
 		args = append(args, "-n", teamName)
 	}
-	
-	if c.target != "" {
-		args = append([]string{"-t", c.target}, args...)
+	return append(args, tls.Args()...)
+}
+
+// LoginCommand returns the command line of an interactive login to apiURL,
+// for running in another terminal. It uses the same flyrc as FlyBy's own fly
+// commands.
+func (c *Client) LoginCommand(apiURL, teamName string, tls TLSOptions) (string, error) {
+	args := append([]string{"fly"}, c.targetArgs(LoginArgs(apiURL, teamName, tls))...)
+	if executor, ok := c.executor.(ExecExecutor); ok && executor.Flyrc != "" {
+		home, err := flyrcHome(executor.Flyrc)
+		if err != nil {
+			return "", err
+		}
+		if home != "" {
+			args = append([]string{"env", "HOME=" + home}, args...)
+		}
 	}
-	
-	// Execute interactively (this will open browser)
-	cmd := exec.Command("fly", args...)
-	cmd.Stdin = os.Stdin
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	
-	return cmd.Run()
+	return CommandRecord{Args: args}.Command(), nil
+}
+
+// LoginInteractive returns an interactive login (opens browser)
+func (c *Client) LoginInteractive(apiURL, teamName string) *InteractiveCommand {
+	return c.LoginInteractiveTLS(apiURL, teamName, TLSOptions{})
+}
+
+// LoginInteractiveTLS returns an interactive login with TLS options, which fly
+// saves to the target for later commands
+func (c *Client) LoginInteractiveTLS(apiURL, teamName string, tls TLSOptions) *InteractiveCommand {
+	return &InteractiveCommand{executor: c.executor, args: c.targetArgs(LoginArgs(apiURL, teamName, tls))}
+}
+
+// SwitchTeam returns a login of the target to another team, keeping its URL
+// and TLS settings (may open a browser)
+func (c *Client) SwitchTeam(teamName string) *InteractiveCommand {
+	return &InteractiveCommand{executor: c.executor, args: c.targetArgs([]string{"login", "-n", teamName})}
 }
 
 // Status checks if we're logged in to the target
@@ -182,9 +478,33 @@ func (c *Client) Status() (bool, error) {
 	return true, nil
 }
 
-// GetPipelines retrieves all pipelines
-func (c *Client) GetPipelines() ([]Pipeline, error) {
-	output, err := c.execFly("pipelines", "--json")
+// Logout logs out of the target with fly logout, which clears its token in
+// the flyrc but keeps the target
+func (c *Client) Logout() error {
+	_, err := c.execFly("logout")
+	if err != nil {
+		return fmt.Errorf("failed to log out of %s: %w", c.target, err)
+	}
+	return nil
+}
+
+// GetPipelines retrieves all pipelines, archived ones only when asked to
+func (c *Client) GetPipelines(includeArchived bool) ([]Pipeline, error) {
+	return c.getPipelines(includeArchived, "pipelines", "--json")
+}
+
+// GetPipelinesAllTeams retrieves the pipelines of every team the user can
+// see, which for admins is every team of the target
+func (c *Client) GetPipelinesAllTeams(includeArchived bool) ([]Pipeline, error) {
+	return c.getPipelines(includeArchived, "pipelines", "--all-teams", "--json")
+}
+
+// getPipelines runs a fly pipelines command and parses its JSON
+func (c *Client) getPipelines(includeArchived bool, args ...string) ([]Pipeline, error) {
+	if includeArchived {
+		args = append(args, "--include-archived")
+	}
+	output, err := c.execFly(args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get pipelines: %w", err)
 	}
@@ -240,18 +560,11 @@ func (c *Client) TriggerJob(pipeline, job string) error {
 func (c *Client) TriggerJobWithOutput(pipeline, job string) (bool, string, error) {
 	jobName := fmt.Sprintf("%s/%s", pipeline, job)
 	
-	// Use exec.Command directly to capture both success/failure cases
-	cmd := exec.Command("fly", "-t", c.target, "trigger-job", "-j", jobName)
-	output, err := cmd.CombinedOutput()
-	outputStr := strings.TrimSpace(string(output))
-	
-	if err != nil {
-		// Check if it's a command execution error or job trigger failure
-		if _, ok := err.(*exec.ExitError); ok {
-			// fly command ran but returned non-zero exit code (e.g., job not found)
-			return false, outputStr, nil
-		}
-		// Actual command execution error (e.g., fly not found)
+	// Capture output for both success and failure cases
+	outputStr, ok, err := c.execFlyCombined("trigger-job", "-j", jobName)
+	if err != nil || !ok {
+		// err is an actual command execution error (e.g., fly not found),
+		// otherwise fly ran but returned non-zero exit code (e.g., job not found)
 		return false, outputStr, err
 	}
 	
@@ -265,18 +578,11 @@ func (c *Client) RerunBuildWithOutput(pipeline, job string, buildNumber int) (bo
 	jobName := fmt.Sprintf("%s/%s", pipeline, job)
 	buildStr := fmt.Sprintf("%d", buildNumber)
 	
-	// Use exec.Command directly to capture both success/failure cases
-	cmd := exec.Command("fly", "-t", c.target, "rerun-build", "--job", jobName, "--build", buildStr)
-	output, err := cmd.CombinedOutput()
-	outputStr := strings.TrimSpace(string(output))
-	
-	if err != nil {
-		// Check if it's a command execution error or build rerun failure
-		if _, ok := err.(*exec.ExitError); ok {
-			// fly command ran but returned non-zero exit code (e.g., build not found)
-			return false, outputStr, nil
-		}
-		// Actual command execution error (e.g., fly not found)
+	// Capture output for both success and failure cases
+	outputStr, ok, err := c.execFlyCombined("rerun-build", "--job", jobName, "--build", buildStr)
+	if err != nil || !ok {
+		// err is an actual command execution error (e.g., fly not found),
+		// otherwise fly ran but returned non-zero exit code (e.g., build not found)
 		return false, outputStr, err
 	}
 	
@@ -298,18 +604,11 @@ func (c *Client) CheckResource(pipeline, resource string) error {
 func (c *Client) CheckResourceWithOutput(pipeline, resource string) (bool, string, error) {
 	resourceName := fmt.Sprintf("%s/%s", pipeline, resource)
 	
-	// Use exec.Command directly to capture both success/failure cases
-	cmd := exec.Command("fly", "-t", c.target, "check-resource", "-r", resourceName)
-	output, err := cmd.CombinedOutput()
-	outputStr := strings.TrimSpace(string(output))
-	
-	if err != nil {
-		// Check if it's a command execution error or resource check failure
-		if _, ok := err.(*exec.ExitError); ok {
-			// fly command ran but returned non-zero exit code (e.g., resource not found)
-			return false, outputStr, nil
-		}
-		// Actual command execution error (e.g., fly not found)
+	// Capture output for both success and failure cases
+	outputStr, ok, err := c.execFlyCombined("check-resource", "-r", resourceName)
+	if err != nil || !ok {
+		// err is an actual command execution error (e.g., fly not found),
+		// otherwise fly ran but returned non-zero exit code (e.g., resource not found)
 		return false, outputStr, err
 	}
 	
@@ -336,6 +635,24 @@ func (c *Client) PausePipeline(pipeline string) error {
 	return nil
 }
 
+// DestroyPipeline destroys a pipeline and all of its build history
+func (c *Client) DestroyPipeline(pipeline string) error {
+	_, err := c.execFly("destroy-pipeline", "-p", pipeline, "--non-interactive")
+	if err != nil {
+		return fmt.Errorf("failed to destroy pipeline %s: %w", pipeline, err)
+	}
+	return nil
+}
+
+// AbortBuild aborts a running build of a job
+func (c *Client) AbortBuild(pipeline, job, build string) error {
+	_, err := c.execFly("abort-build", "-j", fmt.Sprintf("%s/%s", pipeline, job), "-b", build)
+	if err != nil {
+		return fmt.Errorf("failed to abort build %s/%s #%s: %w", pipeline, job, build, err)
+	}
+	return nil
+}
+
 // GetBuilds retrieves builds for a specific job
 func (c *Client) GetBuilds(pipeline, job string, limit int) ([]Build, error) {
 	args := []string{"builds", "-j", fmt.Sprintf("%s/%s", pipeline, job), "--json"}
@@ -356,6 +673,44 @@ func (c *Client) GetBuilds(pipeline, job string, limit int) ([]Build, error) {
 	return builds, nil
 }
 
+// GetBuildPreparation retrieves what a build that hasn't started yet waits on
+// through fly curl, fly has no command for it
+func (c *Client) GetBuildPreparation(buildID int) (BuildPreparation, error) {
+	output, err := c.execFly("curl", fmt.Sprintf("/api/v1/builds/%d/preparation", buildID))
+	if err != nil {
+		return BuildPreparation{}, fmt.Errorf("failed to get preparation of build %d: %w", buildID, err)
+	}
+	
+	var preparation BuildPreparation
+	if err := json.Unmarshal(output, &preparation); err != nil {
+		return BuildPreparation{}, fmt.Errorf("failed to parse build preparation JSON: %w", err)
+	}
+	
+	return preparation, nil
+}
+
+// WatchBuild writes the log of a build to output while it runs, returning
+// once the build has finished. How the build ended is the last line of the
+// log, err is only set when fly could not be run, or is ErrCanceled once ctx
+// is done or CancelReads is called and fly was killed. With timestamps, fly
+// starts every line with the time its event happened, e.g. "15:04:05  ".
+func (c *Client) WatchBuild(ctx context.Context, pipeline, job, build string, timestamps bool, output io.Writer) error {
+	args := []string{"watch", "-j", fmt.Sprintf("%s/%s", pipeline, job), "-b", build}
+	if timestamps {
+		args = append(args, "--timestamps")
+	}
+	ctx, cancel := readsStreamContext(ctx)
+	defer cancel()
+	_, err := c.executor.Stream(ctx, c.targetArgs(args), output)
+	if ctx.Err() != nil {
+		return ErrCanceled
+	}
+	if err != nil {
+		return fmt.Errorf("failed to watch build %s/%s #%s: %w", pipeline, job, build, err)
+	}
+	return nil
+}
+
 // GetTeams retrieves all teams
 func (c *Client) GetTeams() ([]Team, error) {
 	output, err := c.execFly("teams", "--json")
@@ -371,12 +726,89 @@ func (c *Client) GetTeams() ([]Team, error) {
 	return teams, nil
 }
 
+// SetTeam creates a team or replaces its auth configuration with the one in
+// configFile, a fly set-team config file
+func (c *Client) SetTeam(team, configFile string) error {
+	_, err := c.execFly("set-team", "-n", team, "-c", configFile, "--non-interactive")
+	if err != nil {
+		return fmt.Errorf("failed to set team %s: %w", team, err)
+	}
+	return nil
+}
+
+// DestroyTeam destroys a team along with all of its pipelines and builds
+func (c *Client) DestroyTeam(team string) error {
+	_, err := c.execFly("destroy-team", "-n", team, "--non-interactive")
+	if err != nil {
+		return fmt.Errorf("failed to destroy team %s: %w", team, err)
+	}
+	return nil
+}
+
+// GetInfo retrieves the Concourse version and URLs of the target through
+// fly curl, so fly's saved TLS settings apply
+func (c *Client) GetInfo() (Info, error) {
+	output, err := c.execFly("curl", "/api/v1/info")
+	if err != nil {
+		return Info{}, fmt.Errorf("failed to get cluster info: %w", err)
+	}
+	
+	var info Info
+	if err := json.Unmarshal(output, &info); err != nil {
+		return Info{}, fmt.Errorf("failed to parse cluster info JSON: %w", err)
+	}
+	
+	return info, nil
+}
+
+// Userinfo retrieves the logged-in user along with their teams and roles
+func (c *Client) Userinfo() (UserInfo, error) {
+	output, err := c.execFly("userinfo", "--json")
+	if err != nil {
+		return UserInfo{}, fmt.Errorf("failed to get user info: %w", err)
+	}
+	
+	var user UserInfo
+	if err := json.Unmarshal(output, &user); err != nil {
+		return UserInfo{}, fmt.Errorf("failed to parse user info JSON: %w", err)
+	}
+	
+	return user, nil
+}
+
+// ActiveUsers lists the users who logged in within the last two months, which
+// only admins of the cluster may see
+func (c *Client) ActiveUsers() ([]ActiveUser, error) {
+	output, err := c.execFly("active-users", "--json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active users: %w", err)
+	}
+	
+	var users []ActiveUser
+	if err := json.Unmarshal(output, &users); err != nil {
+		return nil, fmt.Errorf("failed to parse active users JSON: %w", err)
+	}
+	
+	return users, nil
+}
+
 // Sync syncs with the target (equivalent to fly sync)
 func (c *Client) Sync() error {
 	_, err := c.execFly("sync")
 	return err
 }
 
+// IsOutOfSyncError reports whether err means fly needs a sync with the target
+func IsOutOfSyncError(err error) bool {
+	return errors.Is(err, ErrOutOfSync)
+}
+
+// IsCanceled reports whether err means the command was killed by CancelReads,
+// so nothing is known about what it was fetching
+func IsCanceled(err error) bool {
+	return errors.Is(err, ErrCanceled)
+}
+
 func IsAuthError(err error) bool {
 	if err == nil {
 		return false
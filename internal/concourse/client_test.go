@@ -0,0 +1,133 @@
+package concourse
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExecFly(t *testing.T) {
+	notFound := errors.New(`exec: "fly": executable file not found in $PATH`)
+	tests := []struct {
+		name       string
+		response   FakeResponse
+		wantOutput string
+		wantErr    string
+		outOfSync  bool
+	}{
+		{
+			name:       "success",
+			response:   FakeResponse{Stdout: "started main/build #4\n"},
+			wantOutput: "started main/build #4\n",
+		},
+		{
+			name:     "non-zero exit",
+			response: FakeResponse{Stderr: "error: job 'main/nope' not found\n", ExitCode: 1},
+			wantErr:  "fly command failed: error: job 'main/nope' not found",
+		},
+		{
+			name:      "out of sync",
+			response:  FakeResponse{Stderr: "fly version (7.9.0) is out of sync with the target (7.11.2)\n", ExitCode: 1},
+			wantErr:   "fly is out of sync with the target",
+			outOfSync: true,
+		},
+		{
+			name:     "executor error",
+			response: FakeResponse{Err: notFound, ExitCode: -1},
+			wantErr:  "failed to execute fly command",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := NewFakeExecutor().On(tt.response, "trigger-job")
+			client := NewClientWithExecutor("ci", fake)
+
+			output, err := client.execFly("trigger-job", "-j", "main/build")
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("execFly() error = %v", err)
+				}
+				if string(output) != tt.wantOutput {
+					t.Errorf("execFly() = %q, want %q", output, tt.wantOutput)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("execFly() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+
+			if got := IsOutOfSyncError(err); got != tt.outOfSync {
+				t.Errorf("IsOutOfSyncError() = %v, want %v", got, tt.outOfSync)
+			}
+			if got, want := client.PendingCommand() != "", tt.outOfSync; got != want {
+				t.Errorf("PendingCommand() = %q, want one pending: %v", client.PendingCommand(), want)
+			}
+			want := [][]string{{"-t", "ci", "trigger-job", "-j", "main/build"}}
+			if calls := fake.Calls(); !reflect.DeepEqual(calls, want) {
+				t.Errorf("Calls() = %v, want %v", calls, want)
+			}
+		})
+	}
+}
+
+func TestExecFlyCombined(t *testing.T) {
+	notFound := errors.New(`exec: "fly": executable file not found in $PATH`)
+	tests := []struct {
+		name       string
+		response   FakeResponse
+		wantOutput string
+		wantOK     bool
+		wantErr    error
+	}{
+		{
+			name:       "success",
+			response:   FakeResponse{Stdout: "  unpaused 'main'\n", Stderr: "\n"},
+			wantOutput: "unpaused 'main'",
+			wantOK:     true,
+		},
+		{
+			name:       "non-zero exit",
+			response:   FakeResponse{Stdout: "\n", Stderr: "error: pipeline 'main' not found\n", ExitCode: 1},
+			wantOutput: "error: pipeline 'main' not found",
+		},
+		{
+			name:       "out of sync",
+			response:   FakeResponse{Stderr: "fly version (7.9.0) is out of sync with the target (7.11.2)\n", ExitCode: 1},
+			wantOutput: "fly version (7.9.0) is out of sync with the target (7.11.2)",
+			wantErr:    ErrOutOfSync,
+		},
+		{
+			name:     "executor error",
+			response: FakeResponse{Err: notFound, ExitCode: -1},
+			wantErr:  notFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewClientWithExecutor("ci", NewFakeExecutor().On(tt.response, "unpause-pipeline"))
+
+			output, ok, err := client.execFlyCombined("unpause-pipeline", "-p", "main")
+			if output != tt.wantOutput {
+				t.Errorf("execFlyCombined() output = %q, want %q", output, tt.wantOutput)
+			}
+			if ok != tt.wantOK {
+				t.Errorf("execFlyCombined() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("execFlyCombined() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFakeExecutorWithoutResponse(t *testing.T) {
+	fake := NewFakeExecutor().On(FakeResponse{Stdout: "[]"}, "pipelines", "--json")
+	client := NewClientWithExecutor("ci", fake)
+
+	if _, err := client.execFly("pipelines", "--json"); err != nil {
+		t.Fatalf("execFly() for a registered command: %v", err)
+	}
+	_, err := client.execFly("jobs", "-p", "main", "--json")
+	if err == nil || !strings.Contains(err.Error(), "no fake response for 'fly jobs -p main --json'") {
+		t.Errorf("execFly() for an unregistered command: error = %v", err)
+	}
+}
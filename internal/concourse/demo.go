@@ -0,0 +1,1001 @@
+package concourse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// demoUsers are the users who logged in to every demo target lately
+var demoUsers = []string{"demo", "alice", "bob", "carol", "dave", "erin", "frank", "grace", "heidi"}
+
+// demoBranches are the branches the demo's instanced pipeline has instances for
+var demoBranches = []string{"main", "feature/search", "fix-login"}
+
+// demoLatency is how long every demo command takes, so loading states show up
+const demoLatency = 300 * time.Millisecond
+
+// demoPipelines are the pipelines a demo target can have, with their jobs
+var demoPipelines = []struct {
+	name string
+	jobs []string
+}{
+	{"web-app", []string{"unit-tests", "build-image", "integration-tests", "deploy-staging", "smoke-tests", "deploy-production"}},
+	{"api-gateway", []string{"unit-tests", "build-image", "deploy-staging", "deploy-production"}},
+	{"payments-service", []string{"lint", "unit-tests", "contract-tests", "build-image", "deploy-staging", "deploy-production"}},
+	{"infrastructure", []string{"terraform-plan", "terraform-apply", "rotate-credentials"}},
+	{"docs-site", []string{"build", "publish"}},
+	{"mobile-app", []string{"unit-tests", "build-android", "build-ios", "publish-beta"}},
+	{"data-pipeline", []string{"extract", "transform", "load", "report"}},
+	{"release", []string{"bump-version", "tag", "publish-release"}},
+}
+
+// demoPausedJobs lists, per pipeline, the jobs that are paused
+var demoPausedJobs = map[string][]string{
+	"infrastructure": {"rotate-credentials"},
+	"mobile-app":     {"publish-beta"},
+}
+
+// demoPassed lists, per pipeline, the jobs each job's inputs must pass first.
+// Jobs missing here take their inputs straight from the resources.
+var demoPassed = map[string]map[string][]string{
+	"web-app": {
+		"build-image":       {"unit-tests"},
+		"integration-tests": {"build-image"},
+		"deploy-staging":    {"integration-tests"},
+		"smoke-tests":       {"deploy-staging"},
+		"deploy-production": {"smoke-tests", "build-image"},
+	},
+	"api-gateway": {
+		"build-image":       {"unit-tests"},
+		"deploy-staging":    {"build-image"},
+		"deploy-production": {"deploy-staging"},
+	},
+	"payments-service": {
+		"build-image":       {"lint", "unit-tests", "contract-tests"},
+		"deploy-staging":    {"build-image"},
+		"deploy-production": {"deploy-staging"},
+	},
+	"infrastructure": {
+		"terraform-apply": {"terraform-plan"},
+	},
+	"docs-site": {
+		"publish": {"build"},
+	},
+	"mobile-app": {
+		"build-android": {"unit-tests"},
+		"build-ios":     {"unit-tests"},
+		"publish-beta":  {"build-android", "build-ios"},
+	},
+	"data-pipeline": {
+		"transform": {"extract"},
+		"load":      {"transform"},
+		"report":    {"load", "extract"},
+	},
+	"release": {
+		"tag":             {"bump-version"},
+		"publish-release": {"tag"},
+	},
+}
+
+// demoBuild is a generated build along with how it is going to end
+type demoBuild struct {
+	Build
+	outcome  string
+	duration time.Duration
+}
+
+// demoTarget holds the generated data of one target
+type demoTarget struct {
+	team      string
+	teams     []Team
+	pipelines []Pipeline
+	jobs      map[string][]string     // pipeline -> job names
+	resources map[string][]Resource   // pipeline -> resources
+	builds    map[string][]*demoBuild // "pipeline/job" -> builds, newest first
+}
+
+// DemoExecutor answers fly commands with generated pipelines, jobs, resources
+// and builds, so FlyBy can be tried without a Concourse installation.
+// Triggered builds run for a few seconds and then finish.
+type DemoExecutor struct {
+	mu          sync.Mutex
+	targets     map[string]*demoTarget
+	nextBuildID int
+}
+
+// NewDemoExecutor creates a demo executor. Every target gets its own data,
+// generated the same way each run.
+func NewDemoExecutor() *DemoExecutor {
+	return &DemoExecutor{
+		targets:     make(map[string]*demoTarget),
+		nextBuildID: 1000,
+	}
+}
+
+// Run answers a fly command from the demo data and records it in the history.
+// A command canceled during the latency answers nothing.
+func (d *DemoExecutor) Run(ctx context.Context, args []string) (string, string, int, error) {
+	started := time.Now()
+	select {
+	case <-time.After(demoLatency):
+	case <-ctx.Done():
+		recordArgs(append([]string{"fly"}, args...), started, -1)
+		return "", "", -1, nil
+	}
+
+	target := ""
+	if len(args) >= 2 && args[0] == "-t" {
+		target = args[1]
+	}
+
+	d.mu.Lock()
+	stdout, err := d.run(target, withoutTarget(args), started)
+	d.mu.Unlock()
+
+	stderr, exitCode := "", 0
+	if err != nil {
+		stderr, exitCode = fmt.Sprintf("error: %v\n", err), 1
+	}
+	recordArgs(append([]string{"fly"}, args...), started, exitCode)
+	return stdout, stderr, exitCode, nil
+}
+
+// RunInteractive pretends to log in
+func (d *DemoExecutor) RunInteractive(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	_, _, _, err := d.Run(context.Background(), args)
+	return err
+}
+
+// Stream answers a fly command like Run. Watching a running build writes
+// its log as the build progresses and returns once it has finished, or once
+// ctx is done.
+func (d *DemoExecutor) Stream(ctx context.Context, args []string, w io.Writer) (int, error) {
+	if len(withoutTarget(args)) == 0 || withoutTarget(args)[0] != "watch" {
+		stdout, stderr, exitCode, err := d.Run(ctx, args)
+		io.WriteString(w, stdout+stderr)
+		return exitCode, err
+	}
+
+	started := time.Now()
+	time.Sleep(demoLatency)
+	target := ""
+	if len(args) >= 2 && args[0] == "-t" {
+		target = args[1]
+	}
+	exitCode, err := d.watch(ctx, target, withoutTarget(args), w)
+	if ctx.Err() != nil {
+		// Killed like fly would be, without a last line
+		recordArgs(append([]string{"fly"}, args...), started, -1)
+		return -1, nil
+	}
+	if err != nil {
+		fmt.Fprintf(w, "error: %v\n", err)
+		exitCode = 1
+	}
+	recordArgs(append([]string{"fly"}, args...), started, exitCode)
+	return exitCode, nil
+}
+
+// watch writes the log of a build, waiting for the lines of a running build
+// to be due and for it to finish, or for ctx to be done
+func (d *DemoExecutor) watch(ctx context.Context, targetName string, args []string, w io.Writer) (int, error) {
+	d.mu.Lock()
+	target := d.target(targetName)
+	builds, err := target.jobBuilds(flagValue(args, "-j", "--job"))
+	var build *demoBuild
+	if err == nil {
+		name := flagValue(args, "-b", "--build")
+		for _, candidate := range builds {
+			if candidate.Name == name || (name == "" && build == nil) {
+				build = candidate
+			}
+		}
+		if build == nil {
+			err = fmt.Errorf("build '%s' not found", name)
+		}
+	}
+	d.mu.Unlock()
+	if err != nil {
+		return 1, err
+	}
+
+	lines := demoLog(build)
+	stamp := func(at time.Time) string {
+		if !hasFlag(args, "--timestamps") {
+			return ""
+		}
+		return at.Format("15:04:05") + "  "
+	}
+	written := 0
+	for {
+		d.mu.Lock()
+		now := time.Now()
+		d.advance(target, now)
+		status := build.Status
+		started := build.GetStartTime()
+		ended := now
+		if build.EndTimeUnix != 0 {
+			ended = build.GetEndTime()
+		}
+		d.mu.Unlock()
+
+		over := status != "pending" && status != "started"
+		for written < len(lines) && (over || lines[written].at <= now.Sub(started)) {
+			fmt.Fprintln(w, stamp(started.Add(lines[written].at))+lines[written].text)
+			written++
+		}
+		if over {
+			switch status {
+			case "succeeded":
+				fmt.Fprintln(w, stamp(ended)+"succeeded")
+				return 0, nil
+			case "failed":
+				fmt.Fprintln(w, stamp(ended)+"failed")
+				return 1, nil
+			case "errored":
+				fmt.Fprintln(w, stamp(ended)+"errored")
+				return 2, nil
+			default:
+				fmt.Fprintln(w, stamp(ended)+"interrupted")
+				return 3, nil
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return -1, ctx.Err()
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+}
+
+// demoLogLine is a line of a generated build log, due at an offset from the
+// start of the build
+type demoLogLine struct {
+	at   time.Duration
+	text string
+}
+
+// demoLog generates the log of a build, the same way for every watch. The
+// lines are spread over the build's duration and end like the build did.
+func demoLog(build *demoBuild) []demoLogLine {
+	rng := rand.New(rand.NewSource(int64(build.ID)))
+	duration := build.duration
+	if build.EndTimeUnix != 0 {
+		duration = build.GetEndTime().Sub(build.GetStartTime())
+	}
+	status := build.Status
+	if status == "pending" || status == "started" {
+		status = build.outcome
+	}
+
+	texts := []string{
+		"initializing",
+		fmt.Sprintf("selected worker: demo-worker-%d", 1+rng.Intn(4)),
+		"initialization complete",
+		fmt.Sprintf("fetching source@%07x", rng.Uint32()&0xfffffff),
+		"INFO: found existing resource cache",
+		"",
+		fmt.Sprintf("running source/ci/%s.sh", build.JobName),
+	}
+	for i, steps := 0, 10+rng.Intn(30); i < steps; i++ {
+		switch rng.Intn(4) {
+		case 0:
+			texts = append(texts, fmt.Sprintf("Step %d/%d : RUN make %s", i+1, steps, build.JobName))
+		case 1:
+			texts = append(texts, fmt.Sprintf("\x1b[32mok\x1b[0m   github.com/example/%s/pkg%d  %.3fs", build.PipelineName, i, rng.Float64()*5))
+		case 2:
+			texts = append(texts, fmt.Sprintf("downloading dependency %d of %d", i+1, steps))
+		default:
+			texts = append(texts, fmt.Sprintf("[%s] processed %d items", build.JobName, rng.Intn(1000)))
+		}
+	}
+	switch status {
+	case "failed":
+		texts = append(texts,
+			fmt.Sprintf("\x1b[1;31m--- FAIL: TestService/%s (%.2fs)", build.JobName, rng.Float64()*3),
+			"    expected 200, got 503",
+			"FAIL\x1b[0m",
+			"exit status 1")
+	case "errored":
+		texts = append(texts, "worker demo-worker-2 disappeared while running the task")
+	case "aborted":
+		texts = append(texts, "interrupted by a user")
+	}
+
+	lines := make([]demoLogLine, len(texts))
+	for i, text := range texts {
+		lines[i] = demoLogLine{at: duration * time.Duration(i) / time.Duration(len(texts)), text: text}
+	}
+	return lines
+}
+
+// demoVersion and demoWorkerVersion are the versions every demo target reports
+const (
+	demoVersion       = "7.11.2"
+	demoWorkerVersion = "2.5"
+)
+
+// Info pretends every URL is a Concourse
+func (d *DemoExecutor) Info(apiURL string) (Info, error) {
+	time.Sleep(demoLatency)
+	return Info{Version: demoVersion, WorkerVersion: demoWorkerVersion, ExternalURL: apiURL}, nil
+}
+
+// run executes a command against the target's data
+func (d *DemoExecutor) run(targetName string, args []string, now time.Time) (string, error) {
+	if len(args) == 0 {
+		return "", fmt.Errorf("no command given")
+	}
+	target := d.target(targetName)
+	d.advance(target, now)
+
+	switch args[0] {
+	case "status":
+		return "logged in successfully\n", nil
+	case "login":
+		return "target saved\n", nil
+	case "logout":
+		return "logged out\n", nil
+	case "sync":
+		return "version already matches; skipping\n", nil
+	case "curl":
+		var buildID int
+		if len(args) >= 2 {
+			if _, err := fmt.Sscanf(args[1], "/api/v1/builds/%d/preparation", &buildID); err == nil {
+				return toJSON(target.preparation(buildID))
+			}
+		}
+		if len(args) < 2 || args[1] != "/api/v1/info" {
+			return "", fmt.Errorf("the demo only answers /api/v1/info and build preparations")
+		}
+		return toJSON(Info{Version: demoVersion, WorkerVersion: demoWorkerVersion, ExternalURL: fmt.Sprintf("https://%s.ci.example.com", targetName), ClusterName: targetName})
+	case "userinfo":
+		return toJSON(UserInfo{
+			UserName:  "demo",
+			UserID:    "demo",
+			Teams:     map[string][]string{target.team: {"owner"}, "shared": {"viewer"}},
+			Connector: "local",
+		})
+	case "teams":
+		return toJSON(target.teams)
+	case "active-users":
+		var users []ActiveUser
+		for i, name := range demoUsers {
+			users = append(users, ActiveUser{
+				ID:            i + 1,
+				Username:      name,
+				Connector:     []string{"local", "github", "oidc"}[i%3],
+				LastLoginUnix: now.Add(-time.Duration(i*i*7) * time.Hour).Unix(),
+			})
+		}
+		return toJSON(users)
+	case "set-team":
+		name := flagValue(args, "-n", "--team-name")
+		if name == "" {
+			return "", fmt.Errorf("the required flag `-n, --team-name' was not specified")
+		}
+		if _, err := os.Stat(flagValue(args, "-c", "--config")); err != nil {
+			return "", fmt.Errorf("failed to read config: %w", err)
+		}
+		for _, team := range target.teams {
+			if team.Name == name {
+				return "team updated\n", nil
+			}
+		}
+		target.teams = append(target.teams, Team{ID: target.teams[len(target.teams)-1].ID + 1, Name: name})
+		return "team created\n", nil
+	case "destroy-team":
+		name := flagValue(args, "-n", "--team-name")
+		if name == target.team {
+			return "", fmt.Errorf("cannot destroy the %s team", name)
+		}
+		for i := range target.teams {
+			if target.teams[i].Name == name {
+				target.teams = append(target.teams[:i], target.teams[i+1:]...)
+				return fmt.Sprintf("`%s` deleted\n", name), nil
+			}
+		}
+		return "", fmt.Errorf("team '%s' does not exist", name)
+	case "pipelines":
+		var pipelines []Pipeline
+		for _, pipeline := range target.pipelines {
+			if !pipeline.Archived || hasFlag(args, "--include-archived") {
+				pipelines = append(pipelines, pipeline)
+			}
+		}
+		if hasFlag(args, "-a", "--all-teams") {
+			pipelines = append(pipelines, target.otherTeamPipelines()...)
+		}
+		return toJSON(pipelines)
+	case "jobs":
+		pipeline, err := target.pipeline(flagValue(args, "-p", "--pipeline"))
+		if err != nil {
+			return "", err
+		}
+		return toJSON(target.jobList(pipeline))
+	case "get-pipeline":
+		pipeline, err := target.pipeline(flagValue(args, "-p", "--pipeline"))
+		if err != nil {
+			return "", err
+		}
+		config := demoConfig(*pipeline, target.jobs[pipeline.Ref()])
+		if !hasFlag(args, "--json") {
+			return toYAML(config)
+		}
+		return toJSON(config)
+	case "validate-pipeline":
+		if err := validateDemoConfig(flagValue(args, "-c", "--config")); err != nil {
+			return "", err
+		}
+		return "looks good\n", nil
+	case "set-pipeline":
+		if err := validateDemoConfig(flagValue(args, "-c", "--config")); err != nil {
+			return "", err
+		}
+		// The demo's configs are generated from its jobs, so the new config
+		// only shows in the pipeline's update time
+		name, vars := flagValue(args, "-p", "--pipeline"), flagValues(args, "-i", "--instance-var")
+		for i := range target.pipelines {
+			if refName, refVars := splitPipelineRef(target.pipelines[i].Ref()); refName == name && strings.Join(refVars, ",") == strings.Join(vars, ",") {
+				target.pipelines[i].LastUpdatedUnix = now.Unix()
+				return "configuration updated\n", nil
+			}
+		}
+		return "", fmt.Errorf("pipeline '%s' not found", name)
+	case "resources":
+		pipeline, err := target.pipeline(flagValue(args, "-p", "--pipeline"))
+		if err != nil {
+			return "", err
+		}
+		return toJSON(target.resources[pipeline.Ref()])
+	case "builds":
+		builds, err := target.jobBuilds(flagValue(args, "-j", "--job"))
+		if err != nil {
+			return "", err
+		}
+		count, _ := strconv.Atoi(flagValue(args, "-c", "--count"))
+		if count <= 0 || count > len(builds) {
+			count = len(builds)
+		}
+		list := make([]Build, count)
+		for i := range list {
+			list[i] = builds[i].Build
+		}
+		return toJSON(list)
+	case "trigger-job":
+		job := flagValue(args, "-j", "--job")
+		builds, err := target.jobBuilds(job)
+		if err != nil {
+			return "", err
+		}
+		number := 1
+		if len(builds) > 0 {
+			number, _ = strconv.Atoi(strings.SplitN(builds[0].Name, ".", 2)[0])
+			number++
+		}
+		build := d.startBuild(target, job, strconv.Itoa(number), now)
+		return fmt.Sprintf("started %s #%s\n", job, build.Name), nil
+	case "rerun-build":
+		job := flagValue(args, "-j", "--job")
+		builds, err := target.jobBuilds(job)
+		if err != nil {
+			return "", err
+		}
+		name := flagValue(args, "-b", "--build")
+		reruns := 0
+		for _, build := range builds {
+			if build.Name == name || strings.HasPrefix(build.Name, name+".") {
+				reruns++
+			}
+		}
+		if reruns == 0 {
+			return "", fmt.Errorf("build not found")
+		}
+		build := d.startBuild(target, job, fmt.Sprintf("%s.%d", name, reruns), now)
+		return fmt.Sprintf("started %s #%s\n", job, build.Name), nil
+	case "abort-build":
+		builds, err := target.jobBuilds(flagValue(args, "-j", "--job"))
+		if err != nil {
+			return "", err
+		}
+		name := flagValue(args, "-b", "--build")
+		for _, build := range builds {
+			if build.Name == name {
+				if build.Status == "pending" || build.Status == "started" {
+					build.finish("aborted", now)
+				}
+				return "build successfully aborted\n", nil
+			}
+		}
+		return "", fmt.Errorf("build not found")
+	case "check-resource":
+		pipelineRef, resourceName, ok := splitJobRef(flagValue(args, "-r", "--resource"))
+		if !ok {
+			return "", fmt.Errorf("resource must be given as PIPELINE/RESOURCE")
+		}
+		parts := []string{pipelineRef, resourceName}
+		for i, resource := range target.resources[parts[0]] {
+			if resource.Name == parts[1] {
+				target.resources[parts[0]][i].LastCheckedUnix = now.Unix()
+				d.nextBuildID++
+				return fmt.Sprintf("checking %s/%s in build %d\ninitializing check: %s\nselected worker: demo-worker-1\nsucceeded\n",
+					parts[0], parts[1], d.nextBuildID, parts[1]), nil
+			}
+		}
+		return "", fmt.Errorf("resource '%s' not found", parts[1])
+	case "pause-pipeline", "unpause-pipeline":
+		pipeline, err := target.pipeline(flagValue(args, "-p", "--pipeline"))
+		if err != nil {
+			return "", err
+		}
+		pipeline.Paused = args[0] == "pause-pipeline"
+		pipeline.LastUpdatedUnix = now.Unix()
+		return fmt.Sprintf("%sd '%s'\n", strings.TrimSuffix(args[0], "-pipeline"), pipeline.Name), nil
+	case "destroy-pipeline":
+		pipeline, err := target.pipeline(flagValue(args, "-p", "--pipeline"))
+		if err != nil {
+			return "", err
+		}
+		name := pipeline.Name
+		for i := range target.pipelines {
+			if target.pipelines[i].Name == name {
+				target.pipelines = append(target.pipelines[:i], target.pipelines[i+1:]...)
+				break
+			}
+		}
+		return fmt.Sprintf("`%s` deleted\n", name), nil
+	}
+	return "", fmt.Errorf("unknown command '%s' in demo mode", args[0])
+}
+
+// target returns the data of a target, generating it on first use
+func (d *DemoExecutor) target(name string) *demoTarget {
+	if target, ok := d.targets[name]; ok {
+		return target
+	}
+
+	hash := fnv.New64a()
+	hash.Write([]byte(name))
+	rng := rand.New(rand.NewSource(int64(hash.Sum64())))
+	now := time.Now()
+
+	target := &demoTarget{
+		team:      "main",
+		teams:     []Team{{ID: 1, Name: "main"}, {ID: 2, Name: "shared"}},
+		jobs:      make(map[string][]string),
+		resources: make(map[string][]Resource),
+		builds:    make(map[string][]*demoBuild),
+	}
+	count := 5 + rng.Intn(len(demoPipelines)-4)
+	for i, index := range rng.Perm(len(demoPipelines))[:count] {
+		definition := demoPipelines[index]
+		pipeline := Pipeline{
+			ID:              i + 1,
+			Name:            definition.name,
+			Paused:          rng.Intn(6) == 0,
+			Public:          rng.Intn(3) == 0,
+			TeamName:        target.team,
+			LastUpdatedUnix: now.Add(-time.Duration(rng.Intn(14*24)) * time.Hour).Unix(),
+		}
+		d.addPipeline(target, rng, pipeline, definition.jobs, now)
+	}
+	// An instanced pipeline, one instance per branch
+	for i, branch := range demoBranches {
+		pipeline := Pipeline{
+			ID:              50 + i,
+			Name:            "pr-checks",
+			TeamName:        target.team,
+			LastUpdatedUnix: now.Add(-time.Duration(rng.Intn(48)) * time.Hour).Unix(),
+			InstanceVars:    InstanceVars{"branch": branch},
+		}
+		d.addPipeline(target, rng, pipeline, []string{"lint", "unit-tests"}, now)
+	}
+	// An archived pipeline, which fly only lists when asked to
+	archived := Pipeline{
+		ID:              60,
+		Name:            "legacy-monolith",
+		Paused:          true,
+		Archived:        true,
+		TeamName:        target.team,
+		LastUpdatedUnix: now.Add(-time.Duration(90+rng.Intn(90)) * 24 * time.Hour).Unix(),
+	}
+	d.addPipeline(target, rng, archived, []string{"build", "deploy"}, now)
+	d.targets[name] = target
+	return target
+}
+
+// addPipeline adds a pipeline to a target along with its jobs, resources and
+// a history of builds
+func (d *DemoExecutor) addPipeline(target *demoTarget, rng *rand.Rand, pipeline Pipeline, jobs []string, now time.Time) {
+	ref := pipeline.Ref()
+	target.pipelines = append(target.pipelines, pipeline)
+	target.jobs[ref] = jobs
+	target.resources[ref] = demoResources(rng, pipeline, now)
+
+	for _, job := range jobs {
+		key := ref + "/" + job
+		finished := now.Add(-time.Duration(rng.Intn(120)) * time.Minute)
+		for number := 10 + rng.Intn(40); number > 0; number-- {
+			duration := time.Duration(30+rng.Intn(900)) * time.Second
+			d.nextBuildID++
+			build := &demoBuild{
+				Build: Build{
+					ID:                   d.nextBuildID,
+					TeamName:             target.team,
+					Name:                 strconv.Itoa(number),
+					Status:               demoOutcome(rng),
+					JobName:              job,
+					StartTimeUnix:        finished.Add(-duration).Unix(),
+					EndTimeUnix:          finished.Unix(),
+					PipelineID:           pipeline.ID,
+					PipelineName:         pipeline.Name,
+					PipelineInstanceVars: pipeline.InstanceVars,
+				},
+			}
+			target.builds[key] = append(target.builds[key], build)
+			finished = finished.Add(-duration - time.Duration(rng.Intn(48*60))*time.Minute)
+		}
+		// Keep a few builds running so there's something to watch
+		if !pipeline.Paused && rng.Intn(6) == 0 {
+			latest, _ := strconv.Atoi(target.builds[key][0].Name)
+			d.startBuild(target, key, strconv.Itoa(latest+1), now)
+		}
+	}
+}
+
+// startBuild adds a pending build to a "pipeline/job" that finishes by itself
+func (d *DemoExecutor) startBuild(target *demoTarget, job, name string, now time.Time) *demoBuild {
+	pipelineRef, jobName, _ := splitJobRef(job)
+	pipeline, _ := target.pipeline(pipelineRef)
+
+	d.nextBuildID++
+	rng := rand.New(rand.NewSource(int64(d.nextBuildID)))
+	build := &demoBuild{
+		Build: Build{
+			ID:                   d.nextBuildID,
+			TeamName:             target.team,
+			Name:                 name,
+			Status:               "pending",
+			JobName:              jobName,
+			StartTimeUnix:        now.Unix(),
+			PipelineID:           pipeline.ID,
+			PipelineName:         pipeline.Name,
+			PipelineInstanceVars: pipeline.InstanceVars,
+		},
+		outcome:  demoOutcome(rng),
+		duration: time.Duration(10+rng.Intn(30)) * time.Second,
+	}
+	target.builds[job] = append([]*demoBuild{build}, target.builds[job]...)
+	return build
+}
+
+// advance moves triggered builds along: pending builds start after a couple
+// of seconds and started builds finish once their duration has passed
+func (d *DemoExecutor) advance(target *demoTarget, now time.Time) {
+	for _, builds := range target.builds {
+		for _, build := range builds {
+			switch build.Status {
+			case "pending":
+				if now.Sub(build.GetStartTime()) >= 2*time.Second {
+					build.Status = "started"
+				}
+			case "started":
+				if now.Sub(build.GetStartTime()) >= build.duration {
+					build.finish(build.outcome, now)
+				}
+			}
+		}
+	}
+}
+
+// finish ends a build with status
+func (b *demoBuild) finish(status string, now time.Time) {
+	b.Status = status
+	b.EndTimeUnix = now.Unix()
+}
+
+// preparation reports what a build waits on: its pipeline or job being
+// paused, or nothing once it is only waiting for a worker
+func (t *demoTarget) preparation(buildID int) BuildPreparation {
+	preparation := BuildPreparation{
+		BuildID:             buildID,
+		PausedPipeline:      "not_blocking",
+		PausedJob:           "not_blocking",
+		MaxRunningBuilds:    "not_blocking",
+		Inputs:              map[string]string{},
+		InputsSatisfied:     "not_blocking",
+		MissingInputReasons: map[string]string{},
+	}
+	for job, builds := range t.builds {
+		for _, build := range builds {
+			if build.ID != buildID {
+				continue
+			}
+			pipelineRef, name, _ := splitJobRef(job)
+			pipeline, err := t.pipeline(pipelineRef)
+			if err == nil && pipeline.Paused && build.Status == "pending" {
+				preparation.PausedPipeline = "blocking"
+			}
+			if err == nil && demoJobPaused(pipeline.Name, name) && build.Status == "pending" {
+				preparation.PausedJob = "blocking"
+			}
+			preparation.Inputs["source"] = "not_blocking"
+			preparation.Inputs["version"] = "not_blocking"
+			if strings.HasPrefix(name, "deploy-") {
+				preparation.Inputs["image"] = "not_blocking"
+			}
+		}
+	}
+	return preparation
+}
+
+// pipeline returns a pipeline of the target by name, with instance vars for
+// instanced pipelines
+func (t *demoTarget) pipeline(name string) (*Pipeline, error) {
+	for i := range t.pipelines {
+		if t.pipelines[i].Ref() == name {
+			return &t.pipelines[i], nil
+		}
+	}
+	return nil, fmt.Errorf("pipeline '%s' not found", name)
+}
+
+// jobBuilds returns the builds of a "pipeline/job", newest first
+func (t *demoTarget) jobBuilds(job string) ([]*demoBuild, error) {
+	pipeline, name, ok := splitJobRef(job)
+	if !ok {
+		return nil, fmt.Errorf("job must be given as PIPELINE/JOB")
+	}
+	if _, err := t.pipeline(pipeline); err != nil {
+		return nil, err
+	}
+	builds, ok := t.builds[job]
+	if !ok {
+		return nil, fmt.Errorf("job '%s' not found", name)
+	}
+	return builds, nil
+}
+
+// jobList returns the jobs of a pipeline with their latest builds
+func (t *demoTarget) jobList(pipeline *Pipeline) []Job {
+	var jobs []Job
+	for i, name := range t.jobs[pipeline.Ref()] {
+		job := Job{
+			ID:                   pipeline.ID*100 + i,
+			Name:                 name,
+			PipelineName:         pipeline.Name,
+			PipelineInstanceVars: pipeline.InstanceVars,
+			PipelineID:           pipeline.ID,
+			TeamName:             t.team,
+			Paused:               demoJobPaused(pipeline.Name, name),
+		}
+		for _, build := range t.builds[pipeline.Ref()+"/"+name] {
+			if build.Status == "pending" || build.Status == "started" {
+				if job.NextBuild.ID == 0 {
+					job.NextBuild = build.Build
+				}
+			} else if job.FinishedBuild.ID == 0 {
+				job.FinishedBuild = build.Build
+			}
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs
+}
+
+// demoJobPaused returns true for the jobs demoPausedJobs lists
+func demoJobPaused(pipeline, job string) bool {
+	for _, paused := range demoPausedJobs[pipeline] {
+		if paused == job {
+			return true
+		}
+	}
+	return false
+}
+
+// demoResources generates the resources of a pipeline
+func demoResources(rng *rand.Rand, pipeline Pipeline, now time.Time) []Resource {
+	checked := func() int64 {
+		return now.Add(-time.Duration(rng.Intn(600)) * time.Second).Unix()
+	}
+	commit := fmt.Sprintf("%016x%016x%08x", rng.Uint64(), rng.Uint64(), rng.Uint32())
+	return []Resource{
+		{
+			Name: "source", PipelineName: pipeline.Name, PipelineInstanceVars: pipeline.InstanceVars, TeamName: pipeline.TeamName, Type: "git",
+			LastCheckedUnix: checked(),
+			Version:         map[string]interface{}{"ref": commit},
+			Metadata: []Metadata{
+				{Name: "commit", Value: commit},
+				{Name: "author", Value: "Jane Doe"},
+				{Name: "message", Value: "Bump dependencies"},
+			},
+		},
+		{
+			Name: "image", PipelineName: pipeline.Name, PipelineInstanceVars: pipeline.InstanceVars, TeamName: pipeline.TeamName, Type: "registry-image",
+			LastCheckedUnix: checked(),
+			Version:         map[string]interface{}{"digest": fmt.Sprintf("sha256:%016x%016x%016x%016x", rng.Uint64(), rng.Uint64(), rng.Uint64(), rng.Uint64())},
+		},
+		{
+			Name: "version", PipelineName: pipeline.Name, PipelineInstanceVars: pipeline.InstanceVars, TeamName: pipeline.TeamName, Type: "semver",
+			LastCheckedUnix: checked(),
+			Version:         map[string]interface{}{"number": fmt.Sprintf("1.%d.%d", rng.Intn(20), rng.Intn(10))},
+		},
+		{
+			Name: "nightly", PipelineName: pipeline.Name, PipelineInstanceVars: pipeline.InstanceVars, TeamName: pipeline.TeamName, Type: "time",
+			LastCheckedUnix: checked(),
+			Version:         map[string]interface{}{"time": now.Truncate(24 * time.Hour).Format(time.RFC3339)},
+		},
+	}
+}
+
+// demoOutcome picks how a build ends, mostly successfully
+func demoOutcome(rng *rand.Rand) string {
+	switch n := rng.Intn(20); {
+	case n < 14:
+		return "succeeded"
+	case n < 17:
+		return "failed"
+	case n < 19:
+		return "errored"
+	default:
+		return "aborted"
+	}
+}
+
+// demoConfig generates the config of a pipeline: its groups, and jobs that
+// get the source after the jobs demoPassed lists for them. Image builds put
+// the image that deploys get, and version bumps put the version.
+func demoConfig(pipeline Pipeline, jobs []string) map[string]interface{} {
+	var jobConfigs []map[string]interface{}
+	for _, job := range jobs {
+		get := map[string]interface{}{"get": "source", "trigger": true}
+		if passed := demoPassed[pipeline.Name][job]; len(passed) > 0 {
+			get["passed"] = passed
+		}
+		gets := []interface{}{get, map[string]interface{}{"get": "version"}}
+		if strings.HasPrefix(job, "deploy-") {
+			gets = append(gets, map[string]interface{}{"get": "image"})
+		}
+		plan := []interface{}{
+			map[string]interface{}{"in_parallel": gets},
+			map[string]interface{}{"task": job, "file": "source/ci/" + job + ".yml"},
+		}
+		switch job {
+		case "build-image":
+			plan = append(plan, map[string]interface{}{"put": "image"})
+		case "bump-version":
+			plan = append(plan, map[string]interface{}{"put": "version"})
+		}
+		jobConfigs = append(jobConfigs, map[string]interface{}{"name": job, "plan": plan})
+	}
+	return map[string]interface{}{"groups": demoGroups(jobs), "jobs": jobConfigs}
+}
+
+// demoGroups sorts a pipeline's jobs into test, build and ship groups, like
+// big pipelines often are. Small pipelines get no groups.
+func demoGroups(jobs []string) []PipelineGroup {
+	if len(jobs) < 4 {
+		return nil
+	}
+	groups := []PipelineGroup{{Name: "test"}, {Name: "build"}, {Name: "ship"}}
+	for _, job := range jobs {
+		switch {
+		case strings.Contains(job, "test") || job == "lint":
+			groups[0].Jobs = append(groups[0].Jobs, job)
+		case strings.HasPrefix(job, "deploy-"):
+			// Listed once as a pattern, as pipelines may
+			if !groups[2].Contains(job) {
+				groups[2].Jobs = append(groups[2].Jobs, "deploy-*")
+			}
+		case strings.HasPrefix(job, "publish"):
+			groups[2].Jobs = append(groups[2].Jobs, job)
+		default:
+			groups[1].Jobs = append(groups[1].Jobs, job)
+		}
+	}
+	var used []PipelineGroup
+	for _, group := range groups {
+		if len(group.Jobs) > 0 {
+			used = append(used, group)
+		}
+	}
+	return used
+}
+
+// flagValue returns the value following the first of names in args
+func flagValue(args []string, names ...string) string {
+	for i, arg := range args {
+		for _, name := range names {
+			if arg == name && i+1 < len(args) {
+				return args[i+1]
+			}
+		}
+	}
+	return ""
+}
+
+// flagValues returns the values of a flag given any number of times
+func flagValues(args []string, names ...string) []string {
+	var values []string
+	for i, arg := range args {
+		for _, name := range names {
+			if arg == name && i+1 < len(args) {
+				values = append(values, args[i+1])
+			}
+		}
+	}
+	return values
+}
+
+// validateDemoConfig checks that the file at path holds a pipeline config
+// with jobs, roughly like fly validate-pipeline
+func validateDemoConfig(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var config struct {
+		Jobs []interface{} `yaml:"jobs"`
+	}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return fmt.Errorf("malformed config: %v", err)
+	}
+	if len(config.Jobs) == 0 {
+		return fmt.Errorf("invalid jobs: pipeline must contain at least one job")
+	}
+	return nil
+}
+
+// hasFlag reports whether any of the boolean flag names is among args
+func hasFlag(args []string, names ...string) bool {
+	for _, arg := range args {
+		for _, name := range names {
+			if arg == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// otherTeamPipelines are the pipelines of a demo team the target isn't logged
+// in to, made of the pipelines the target itself doesn't have
+func (t *demoTarget) otherTeamPipelines() []Pipeline {
+	var pipelines []Pipeline
+	for i, definition := range demoPipelines {
+		if _, ok := t.jobs[definition.name]; ok {
+			continue
+		}
+		pipelines = append(pipelines, Pipeline{ID: 100 + i, Name: definition.name, TeamName: "shared"})
+	}
+	return pipelines
+}
+
+// toJSON renders v the way fly prints --json output
+func toJSON(v interface{}) (string, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data) + "\n", nil
+}
+
+// toYAML renders v the way fly prints YAML
+func toYAML(v interface{}) (string, error) {
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,252 @@
+package concourse
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"flyby/internal/config"
+)
+
+// FlyExecutor runs fly commands on behalf of a Client
+type FlyExecutor interface {
+	// Run executes fly with args and captures its output, killing fly once
+	// ctx is done. err is only set when fly could not be run at all; a
+	// failing command is reported through a non-zero exit code.
+	Run(ctx context.Context, args []string) (stdout, stderr string, exitCode int, err error)
+	// Stream executes fly with args like Run, but writes its combined output
+	// to w while fly runs, e.g. to follow a build's log
+	Stream(ctx context.Context, args []string, w io.Writer) (exitCode int, err error)
+	// RunInteractive executes fly attached to the terminal's streams, e.g.
+	// for a browser login
+	RunInteractive(args []string, stdin io.Reader, stdout, stderr io.Writer) error
+}
+
+// ExecExecutor runs the fly binary found in PATH, recording every command
+// in the history
+type ExecExecutor struct {
+	// Flyrc is the fly configuration file to use instead of ~/.flyrc
+	Flyrc string
+}
+
+// command creates the fly command for args, killed once ctx is done
+func (e ExecExecutor) command(ctx context.Context, args []string) (*exec.Cmd, error) {
+	cmd := exec.CommandContext(ctx, "fly", args...)
+	if e.Flyrc != "" {
+		// fly always reads $HOME/.flyrc, so point HOME at a directory holding it
+		home, err := flyrcHome(e.Flyrc)
+		if err != nil {
+			return nil, err
+		}
+		if home != "" {
+			cmd.Env = append(os.Environ(), "HOME="+home)
+		}
+	}
+	return cmd, nil
+}
+
+// Run executes fly with args and captures its output
+func (e ExecExecutor) Run(ctx context.Context, args []string) (string, string, int, error) {
+	cmd, err := e.command(ctx, args)
+	if err != nil {
+		return "", "", -1, err
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	started := time.Now()
+	err = cmd.Run()
+	exitCode := recordCommand(cmd, started)
+	if _, ok := err.(*exec.ExitError); ok {
+		err = nil
+	}
+	return stdout.String(), stderr.String(), exitCode, err
+}
+
+// Stream executes fly with args, writing its combined output to w
+func (e ExecExecutor) Stream(ctx context.Context, args []string, w io.Writer) (int, error) {
+	cmd, err := e.command(ctx, args)
+	if err != nil {
+		return -1, err
+	}
+	cmd.Stdout = w
+	cmd.Stderr = w
+
+	started := time.Now()
+	err = cmd.Run()
+	exitCode := recordCommand(cmd, started)
+	if _, ok := err.(*exec.ExitError); ok {
+		err = nil
+	}
+	return exitCode, err
+}
+
+// RunInteractive executes fly attached to the terminal's streams
+func (e ExecExecutor) RunInteractive(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	cmd, err := e.command(context.Background(), args)
+	if err != nil {
+		return err
+	}
+	cmd.Stdin = stdin
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	started := time.Now()
+	err = cmd.Run()
+	recordCommand(cmd, started)
+	return err
+}
+
+// InteractiveCommand is a fly command that needs the terminal to itself, like
+// a browser login printing the URL to open and waiting for a pasted token.
+// It fits tea.ExecCommand, so the TUI can suspend itself while fly runs
+// rather than both drawing on the screen at once.
+type InteractiveCommand struct {
+	executor FlyExecutor
+	args     []string
+	stdin    io.Reader
+	stdout   io.Writer
+	stderr   io.Writer
+}
+
+// SetStdin sets the terminal input fly reads
+func (c *InteractiveCommand) SetStdin(r io.Reader) { c.stdin = r }
+
+// SetStdout sets where fly writes its output
+func (c *InteractiveCommand) SetStdout(w io.Writer) { c.stdout = w }
+
+// SetStderr sets where fly writes its errors
+func (c *InteractiveCommand) SetStderr(w io.Writer) { c.stderr = w }
+
+// Run executes fly, on the process's own streams unless others were set
+func (c *InteractiveCommand) Run() error {
+	stdin, stdout, stderr := c.stdin, c.stdout, c.stderr
+	if stdin == nil {
+		stdin = os.Stdin
+	}
+	if stdout == nil {
+		stdout = os.Stdout
+	}
+	if stderr == nil {
+		stderr = os.Stderr
+	}
+	return c.executor.RunInteractive(c.args, stdin, stdout, stderr)
+}
+
+var (
+	readsMu     sync.Mutex
+	readsCtx    context.Context
+	cancelReads context.CancelFunc
+)
+
+func init() {
+	readsCtx, cancelReads = context.WithCancel(context.Background())
+}
+
+// readContext returns the context commands that only fetch data run in
+func readContext() context.Context {
+	readsMu.Lock()
+	defer readsMu.Unlock()
+	return readsCtx
+}
+
+// CancelReads kills the fly commands fetching data that are still running,
+// e.g. once the view waiting for them is left, builds being watched included.
+// They fail with ErrCanceled. Commands started afterwards aren't affected,
+// and commands that change something always run to the end.
+func CancelReads() {
+	readsMu.Lock()
+	defer readsMu.Unlock()
+	cancelReads()
+	readsCtx, cancelReads = context.WithCancel(context.Background())
+}
+
+// readsStreamContext returns a context done once ctx is, or once CancelReads
+// is called, for streamed commands that only fetch data like fly watch
+func readsStreamContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	stop := context.AfterFunc(readContext(), cancel)
+	return ctx, func() {
+		stop()
+		cancel()
+	}
+}
+
+var (
+	flyrcHomesMu sync.Mutex
+	flyrcHomes   = make(map[string]string)
+)
+
+// flyrcHome returns a directory whose .flyrc is the file at path, or "" when
+// path already is the user's ~/.flyrc. Files named otherwise are linked from
+// a directory in FlyBy's cache directory, the same one for the same file on
+// every run, so nothing is left behind to clean up.
+func flyrcHome(path string) (string, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	if homeDir, err := os.UserHomeDir(); err == nil && path == filepath.Join(homeDir, ".flyrc") {
+		return "", nil
+	}
+	if filepath.Base(path) == ".flyrc" {
+		return filepath.Dir(path), nil
+	}
+
+	flyrcHomesMu.Lock()
+	defer flyrcHomesMu.Unlock()
+	if home, ok := flyrcHomes[path]; ok {
+		return home, nil
+	}
+	cacheDir, err := config.CacheDir()
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(path))
+	home := filepath.Join(cacheDir, "flyrc-homes", fmt.Sprintf("%s-%x", filepath.Base(path), sum[:6]))
+	if err := os.MkdirAll(home, 0700); err != nil {
+		return "", fmt.Errorf("failed to create a home for %s: %w", path, err)
+	}
+	link := filepath.Join(home, ".flyrc")
+	if target, err := os.Readlink(link); err != nil || target != path {
+		os.Remove(link)
+		if err := os.Symlink(path, link); err != nil {
+			return "", fmt.Errorf("failed to link %s: %w", path, err)
+		}
+	}
+	flyrcHomes[path] = home
+	return home, nil
+}
+
+// Environ returns the environment for running fly outside FlyBy, e.g. in the
+// user's own commands, so fly reads the same flyrc as FlyBy's commands do
+func Environ() ([]string, error) {
+	environ := os.Environ()
+	if executor, ok := defaultExecutor.(ExecExecutor); ok && executor.Flyrc != "" {
+		home, err := flyrcHome(executor.Flyrc)
+		if err != nil {
+			return nil, err
+		}
+		if home != "" {
+			environ = append(environ, "HOME="+home)
+		}
+	}
+	return environ, nil
+}
+
+// defaultExecutor is used by clients created with NewClient
+var defaultExecutor FlyExecutor = ExecExecutor{}
+
+// SetDefaultExecutor changes the executor used by clients created with
+// NewClient afterwards
+func SetDefaultExecutor(executor FlyExecutor) {
+	defaultExecutor = executor
+}
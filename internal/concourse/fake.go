@@ -0,0 +1,92 @@
+package concourse
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// FakeResponse is the canned result of a fly command run by a FakeExecutor
+type FakeResponse struct {
+	Stdout   string
+	Stderr   string
+	ExitCode int
+	Err      error
+}
+
+// FakeExecutor answers fly commands with canned responses instead of running
+// the fly binary, so flows can be exercised without a Concourse server.
+// Responses are matched on the arguments after "-t <target>".
+type FakeExecutor struct {
+	mu        sync.Mutex
+	responses map[string]FakeResponse
+	calls     [][]string
+}
+
+// NewFakeExecutor creates a fake executor without any responses
+func NewFakeExecutor() *FakeExecutor {
+	return &FakeExecutor{responses: make(map[string]FakeResponse)}
+}
+
+// On sets the response for a command. args may be the full argument list,
+// e.g. "pipelines", "--json", or just the subcommand to answer every
+// invocation of it.
+func (f *FakeExecutor) On(response FakeResponse, args ...string) *FakeExecutor {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.responses[strings.Join(args, " ")] = response
+	return f
+}
+
+// Run returns the response registered for args. Commands without a response
+// fail like an unknown fly subcommand.
+func (f *FakeExecutor) Run(_ context.Context, args []string) (string, string, int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, append([]string(nil), args...))
+
+	args = withoutTarget(args)
+	if response, ok := f.responses[strings.Join(args, " ")]; ok {
+		return response.Stdout, response.Stderr, response.ExitCode, response.Err
+	}
+	if len(args) > 0 {
+		if response, ok := f.responses[args[0]]; ok {
+			return response.Stdout, response.Stderr, response.ExitCode, response.Err
+		}
+	}
+	return "", fmt.Sprintf("error: no fake response for 'fly %s'\n", strings.Join(args, " ")), 1, nil
+}
+
+// Stream writes the response registered for args to w
+func (f *FakeExecutor) Stream(ctx context.Context, args []string, w io.Writer) (int, error) {
+	stdout, stderr, exitCode, err := f.Run(ctx, args)
+	io.WriteString(w, stdout+stderr)
+	return exitCode, err
+}
+
+// RunInteractive records the command and reports the registered error, if any
+func (f *FakeExecutor) RunInteractive(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	_, _, exitCode, err := f.Run(context.Background(), args)
+	if err == nil && exitCode != 0 {
+		err = fmt.Errorf("exit status %d", exitCode)
+	}
+	return err
+}
+
+// Calls returns the argument lists of every command run so far, including
+// the target flags
+func (f *FakeExecutor) Calls() [][]string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([][]string(nil), f.calls...)
+}
+
+// withoutTarget strips a leading "-t <target>" from args
+func withoutTarget(args []string) []string {
+	if len(args) >= 2 && args[0] == "-t" {
+		return args[2:]
+	}
+	return args
+}
@@ -0,0 +1,131 @@
+package concourse
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxHistory is the number of commands kept in memory
+const maxHistory = 500
+
+// CommandRecord describes a fly command FlyBy executed
+type CommandRecord struct {
+	Args     []string      `json:"args"`
+	Target   string        `json:"target,omitempty"`
+	Started  time.Time     `json:"started"`
+	Duration time.Duration `json:"duration"`
+	ExitCode int           `json:"exit_code"`
+}
+
+// Command returns the command line as it would be typed in a shell
+func (r CommandRecord) Command() string {
+	quoted := make([]string, len(r.Args))
+	for i, arg := range r.Args {
+		if arg == "" || strings.ContainsAny(arg, " \t\"'$\\") {
+			arg = fmt.Sprintf("%q", arg)
+		}
+		quoted[i] = arg
+	}
+	return strings.Join(quoted, " ")
+}
+
+// Succeeded reports whether the command exited with status 0
+func (r CommandRecord) Succeeded() bool {
+	return r.ExitCode == 0
+}
+
+var (
+	historyMu   sync.Mutex
+	history     []CommandRecord
+	historyFile *os.File
+)
+
+// SetHistoryLog appends every executed command to the file at path as JSON lines
+func SetHistoryLog(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create history log directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open history log %s: %w", path, err)
+	}
+
+	historyMu.Lock()
+	defer historyMu.Unlock()
+	if historyFile != nil {
+		historyFile.Close()
+	}
+	historyFile = file
+	return nil
+}
+
+// History returns the executed commands, oldest first
+func History() []CommandRecord {
+	historyMu.Lock()
+	defer historyMu.Unlock()
+	return append([]CommandRecord(nil), history...)
+}
+
+// recordCommand adds a finished fly command to the history and returns its
+// exit code, -1 when it couldn't be started
+func recordCommand(cmd *exec.Cmd, started time.Time) int {
+	exitCode := -1
+	if cmd.ProcessState != nil {
+		exitCode = cmd.ProcessState.ExitCode()
+	}
+	recordArgs(cmd.Args, started, exitCode)
+	return exitCode
+}
+
+// redactPasswords copies a command line, masking the value of a login's
+// password flag so it never reaches the history or its log file
+func redactPasswords(args []string) []string {
+	redacted := append([]string(nil), args...)
+	login := false
+	for i, arg := range redacted {
+		switch {
+		case arg == "login":
+			login = true
+		case login && (arg == "-p" || arg == "--password") && i+1 < len(redacted):
+			redacted[i+1] = "********"
+		case login && strings.HasPrefix(arg, "--password="):
+			redacted[i] = "--password=********"
+		}
+	}
+	return redacted
+}
+
+// recordArgs adds a finished command line, starting with "fly", to the history
+func recordArgs(args []string, started time.Time, exitCode int) {
+	record := CommandRecord{
+		Args:     redactPasswords(args),
+		Started:  started,
+		Duration: time.Since(started),
+		ExitCode: exitCode,
+	}
+	for i, arg := range args {
+		if arg == "-t" && i+1 < len(args) {
+			record.Target = args[i+1]
+			break
+		}
+	}
+
+	historyMu.Lock()
+	defer historyMu.Unlock()
+	history = append(history, record)
+	if len(history) > maxHistory {
+		history = history[len(history)-maxHistory:]
+	}
+	if historyFile != nil {
+		// The log is best effort, a full disk shouldn't break fly commands
+		if data, err := json.Marshal(record); err == nil {
+			historyFile.Write(append(data, '\n'))
+		}
+	}
+}
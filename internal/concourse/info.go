@@ -0,0 +1,108 @@
+package concourse
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// infoTimeout bounds how long FetchInfo waits for a Concourse to answer
+const infoTimeout = 10 * time.Second
+
+// Info is what a Concourse API reports about itself at /api/v1/info
+type Info struct {
+	Version       string `json:"version"`
+	WorkerVersion string `json:"worker_version"`
+	ExternalURL   string `json:"external_url,omitempty"`
+	ClusterName   string `json:"cluster_name,omitempty"`
+}
+
+var (
+	// ErrUnreachable is returned when nothing answers at a Concourse URL
+	ErrUnreachable = errors.New("host unreachable")
+	// ErrNotConcourse is returned when a URL answers, but not like a Concourse API
+	ErrNotConcourse = errors.New("not a Concourse API")
+)
+
+// infoFetcher is implemented by executors that answer /api/v1/info without
+// the network, like the demo
+type infoFetcher interface {
+	Info(apiURL string) (Info, error)
+}
+
+// FetchInfo asks the Concourse at apiURL for its version, which tells whether
+// the URL is reachable and really a Concourse before fly logs in to it
+func FetchInfo(apiURL string, tlsOptions TLSOptions) (Info, error) {
+	parsed, err := url.Parse(apiURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return Info{}, fmt.Errorf("invalid URL %q, expected http(s)://host", apiURL)
+	}
+	if fetcher, ok := defaultExecutor.(infoFetcher); ok {
+		return fetcher.Info(apiURL)
+	}
+
+	tlsConfig, err := tlsOptions.config()
+	if err != nil {
+		return Info{}, err
+	}
+	client := &http.Client{
+		Timeout:   infoTimeout,
+		Transport: &http.Transport{Proxy: http.ProxyFromEnvironment, TLSClientConfig: tlsConfig},
+	}
+
+	resp, err := client.Get(strings.TrimRight(apiURL, "/") + "/api/v1/info")
+	if err != nil {
+		var verifyErr *tls.CertificateVerificationError
+		if errors.As(err, &verifyErr) {
+			return Info{}, fmt.Errorf("%s's certificate can't be verified (%v), give its CA cert or skip TLS verification", parsed.Host, verifyErr.Err)
+		}
+		return Info{}, fmt.Errorf("%w: %s: %v", ErrUnreachable, parsed.Host, errors.Unwrap(err))
+	}
+	defer resp.Body.Close()
+
+	var info Info
+	if resp.StatusCode != http.StatusOK {
+		return Info{}, fmt.Errorf("%w: %s answered /api/v1/info with %s", ErrNotConcourse, parsed.Host, resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil || info.Version == "" {
+		return Info{}, fmt.Errorf("%w: %s doesn't report a Concourse version", ErrNotConcourse, parsed.Host)
+	}
+	return info, nil
+}
+
+// config returns the TLS configuration for talking to the API directly
+func (o TLSOptions) config() (*tls.Config, error) {
+	config := &tls.Config{InsecureSkipVerify: o.Insecure}
+	pem := []byte(o.CACertPEM)
+	if o.CACert != "" {
+		var err error
+		if pem, err = os.ReadFile(o.CACert); err != nil {
+			return nil, fmt.Errorf("failed to read CA cert: %w", err)
+		}
+	}
+	if len(pem) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in the CA cert")
+		}
+		config.RootCAs = pool
+	}
+	if o.ClientCert != "" && o.ClientKey != "" {
+		cert, err := tls.LoadX509KeyPair(o.ClientCert, o.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client cert: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
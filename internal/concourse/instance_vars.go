@@ -0,0 +1,127 @@
+package concourse
+
+import (
+	"encoding/json"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// InstanceVars tell apart the instances of an instanced pipeline, which all
+// share the pipeline's name
+type InstanceVars map[string]interface{}
+
+// String formats the vars the way fly takes them, e.g. "branch:main,env:prod".
+// Nested vars are flattened to dotted keys and values fly would misread are
+// quoted.
+func (v InstanceVars) String() string {
+	var pairs []string
+	flattenInstanceVars("", v, func(key string, value interface{}) {
+		pairs = append(pairs, key+":"+formatInstanceVar(value))
+	})
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// Query formats the vars the way the Concourse web UI takes them in its URLs,
+// e.g. "vars.branch=%22main%22", every value encoded as JSON
+func (v InstanceVars) Query() string {
+	values := url.Values{}
+	flattenInstanceVars("", v, func(key string, value interface{}) {
+		encoded, _ := json.Marshal(value)
+		values.Set("vars."+key, string(encoded))
+	})
+	return values.Encode()
+}
+
+// flattenInstanceVars calls leaf with the dotted key of every leaf of vars
+func flattenInstanceVars(prefix string, vars map[string]interface{}, leaf func(key string, value interface{})) {
+	for key, value := range vars {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		if nested, ok := value.(map[string]interface{}); ok {
+			flattenInstanceVars(key, nested, leaf)
+			continue
+		}
+		leaf(key, value)
+	}
+}
+
+// formatInstanceVar formats a single value, which fly parses as YAML
+func formatInstanceVar(value interface{}) string {
+	text, ok := value.(string)
+	if !ok {
+		encoded, _ := json.Marshal(value)
+		return string(encoded)
+	}
+	switch strings.ToLower(text) {
+	case "", "true", "false", "null", "~", "yes", "no":
+		return strconv.Quote(text)
+	}
+	if _, err := strconv.ParseFloat(text, 64); err == nil || strings.ContainsAny(text, ",:/\"'{}[]#& \t") {
+		return strconv.Quote(text)
+	}
+	return text
+}
+
+// PipelineRef returns how fly identifies a pipeline: its name, followed by its
+// instance vars for an instanced pipeline, e.g. "app/branch:main"
+func PipelineRef(name string, vars InstanceVars) string {
+	if len(vars) == 0 {
+		return name
+	}
+	return name + "/" + vars.String()
+}
+
+// Ref returns how fly identifies the pipeline
+func (p Pipeline) Ref() string {
+	return PipelineRef(p.Name, p.InstanceVars)
+}
+
+// PipelineRef returns how fly identifies the job's pipeline
+func (j Job) PipelineRef() string {
+	return PipelineRef(j.PipelineName, j.PipelineInstanceVars)
+}
+
+// PipelineRef returns how fly identifies the resource's pipeline
+func (r Resource) PipelineRef() string {
+	return PipelineRef(r.PipelineName, r.PipelineInstanceVars)
+}
+
+// splitJobRef splits a "pipeline/job" identifier, whose pipeline may have
+// instance vars, into the pipeline and the job
+func splitJobRef(ref string) (pipeline, job string, ok bool) {
+	i := strings.LastIndex(ref, "/")
+	if i <= 0 || i == len(ref)-1 {
+		return "", "", false
+	}
+	return ref[:i], ref[i+1:], true
+}
+
+// splitPipelineRef splits a pipeline identifier into the pipeline's name and
+// its instance vars as set-pipeline takes them, e.g. "branch=main". Unlike
+// other commands, set-pipeline only accepts the name as the pipeline.
+func splitPipelineRef(ref string) (name string, vars []string) {
+	name, rest, found := strings.Cut(ref, "/")
+	if !found {
+		return ref, nil
+	}
+	// Values with commas or colons are quoted, see formatInstanceVar
+	start, quoted := 0, false
+	for i := 0; i <= len(rest); i++ {
+		switch {
+		case i == len(rest) || (rest[i] == ',' && !quoted):
+			if key, value, ok := strings.Cut(rest[start:i], ":"); ok {
+				vars = append(vars, key+"="+value)
+			}
+			start = i + 1
+		case rest[i] == '\\' && quoted:
+			i++
+		case rest[i] == '"':
+			quoted = !quoted
+		}
+	}
+	return name, vars
+}
@@ -0,0 +1,282 @@
+package concourse
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// PipelineConfig is the part of a pipeline's config FlyBy reads: its groups,
+// and which jobs each job's inputs have to pass through first
+type PipelineConfig struct {
+	Groups []PipelineGroup
+	Jobs   []JobConfig
+}
+
+// JobConfig is a job of a pipeline's config
+type JobConfig struct {
+	Name    string
+	Inputs  []JobInput
+	Outputs []string // resources the job puts
+}
+
+// JobInput is a resource a job gets, with the jobs its versions must have
+// passed through
+type JobInput struct {
+	Resource string
+	Passed   []string
+	Trigger  bool // new versions trigger the job
+}
+
+// Upstream returns the jobs the job's inputs must have passed, in the order
+// they first appear
+func (j JobConfig) Upstream() []string {
+	var upstream []string
+	seen := make(map[string]bool)
+	for _, input := range j.Inputs {
+		for _, job := range input.Passed {
+			if !seen[job] {
+				seen[job] = true
+				upstream = append(upstream, job)
+			}
+		}
+	}
+	return upstream
+}
+
+// JobLink is a job feeding into another one, or consuming its outputs,
+// through a resource
+type JobLink struct {
+	Job      string
+	Resource string
+	Passed   bool // linked by a passed constraint rather than a put and get
+}
+
+// Job returns the config of a job, if the pipeline has it
+func (c PipelineConfig) Job(name string) (JobConfig, bool) {
+	for _, job := range c.Jobs {
+		if job.Name == name {
+			return job, true
+		}
+	}
+	return JobConfig{}, false
+}
+
+// Upstream returns the jobs feeding into a job: those its inputs must pass,
+// and those putting the resources it gets without passed constraints
+func (c PipelineConfig) Upstream(name string) []JobLink {
+	job, ok := c.Job(name)
+	if !ok {
+		return nil
+	}
+	var links []JobLink
+	for _, input := range job.Inputs {
+		for _, passed := range input.Passed {
+			links = appendLink(links, JobLink{Job: passed, Resource: input.Resource, Passed: true})
+		}
+		if len(input.Passed) > 0 {
+			continue
+		}
+		for _, other := range c.Jobs {
+			if other.Name != name && other.puts(input.Resource) {
+				links = appendLink(links, JobLink{Job: other.Name, Resource: input.Resource})
+			}
+		}
+	}
+	return links
+}
+
+// Downstream returns the jobs consuming a job's outputs: those whose inputs
+// must pass it, and those getting the resources it puts without passed
+// constraints
+func (c PipelineConfig) Downstream(name string) []JobLink {
+	job, ok := c.Job(name)
+	if !ok {
+		return nil
+	}
+	var links []JobLink
+	for _, other := range c.Jobs {
+		if other.Name == name {
+			continue
+		}
+		for _, input := range other.Inputs {
+			switch {
+			case containsString(input.Passed, name):
+				links = appendLink(links, JobLink{Job: other.Name, Resource: input.Resource, Passed: true})
+			case len(input.Passed) == 0 && job.puts(input.Resource):
+				links = appendLink(links, JobLink{Job: other.Name, Resource: input.Resource})
+			}
+		}
+	}
+	return links
+}
+
+// ResourceUsage is how the jobs of a pipeline use a resource
+type ResourceUsage struct {
+	Gets []ResourceGet
+	Puts []string // jobs putting the resource
+}
+
+// ResourceGet is a job getting a resource
+type ResourceGet struct {
+	Job     string
+	Trigger bool
+	Passed  []string
+}
+
+// ResourceUsage returns which jobs get and put a resource
+func (c PipelineConfig) ResourceUsage(resource string) ResourceUsage {
+	var usage ResourceUsage
+	for _, job := range c.Jobs {
+		for _, input := range job.Inputs {
+			if input.Resource == resource {
+				usage.Gets = append(usage.Gets, ResourceGet{Job: job.Name, Trigger: input.Trigger, Passed: input.Passed})
+				break
+			}
+		}
+		if job.puts(resource) {
+			usage.Puts = append(usage.Puts, job.Name)
+		}
+	}
+	return usage
+}
+
+// puts reports whether the job puts the resource
+func (j JobConfig) puts(resource string) bool {
+	return containsString(j.Outputs, resource)
+}
+
+// appendLink appends a link unless the same job is already linked through
+// the same resource
+func appendLink(links []JobLink, link JobLink) []JobLink {
+	for _, existing := range links {
+		if existing.Job == link.Job && existing.Resource == link.Resource {
+			return links
+		}
+	}
+	return append(links, link)
+}
+
+// containsString reports whether values holds value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// GetPipelineConfig retrieves and parses a pipeline's config
+func (c *Client) GetPipelineConfig(pipeline string) (PipelineConfig, error) {
+	output, err := c.execFly("get-pipeline", "-p", pipeline, "--json")
+	if err != nil {
+		return PipelineConfig{}, fmt.Errorf("failed to get config of pipeline %s: %w", pipeline, err)
+	}
+	config, err := parsePipelineConfig(output)
+	if err != nil {
+		return PipelineConfig{}, fmt.Errorf("failed to parse pipeline config JSON: %w", err)
+	}
+	return config, nil
+}
+
+// GetPipelineYAML retrieves a pipeline's config as fly prints it, in YAML
+func (c *Client) GetPipelineYAML(pipeline string) (string, error) {
+	output, err := c.execFly("get-pipeline", "-p", pipeline)
+	if err != nil {
+		return "", fmt.Errorf("failed to get config of pipeline %s: %w", pipeline, err)
+	}
+	return string(output), nil
+}
+
+// parsePipelineConfig parses the JSON config printed by fly get-pipeline
+func parsePipelineConfig(data []byte) (PipelineConfig, error) {
+	var raw struct {
+		Groups []PipelineGroup `json:"groups"`
+		Jobs   []struct {
+			Name string        `json:"name"`
+			Plan []interface{} `json:"plan"`
+		} `json:"jobs"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return PipelineConfig{}, err
+	}
+
+	config := PipelineConfig{Groups: raw.Groups}
+	for _, job := range raw.Jobs {
+		jobConfig := JobConfig{Name: job.Name}
+		collectSteps(job.Plan, &jobConfig)
+		config.Jobs = append(config.Jobs, jobConfig)
+	}
+	return config, nil
+}
+
+// collectSteps finds the get and put steps anywhere in a plan, including
+// those nested in in_parallel, do, try and hooks
+func collectSteps(step interface{}, job *JobConfig) {
+	switch step := step.(type) {
+	case []interface{}:
+		for _, nested := range step {
+			collectSteps(nested, job)
+		}
+	case map[string]interface{}:
+		if name, ok := step["get"].(string); ok {
+			input := JobInput{Resource: stepResource(step, name)}
+			input.Trigger, _ = step["trigger"].(bool)
+			if passed, ok := step["passed"].([]interface{}); ok {
+				for _, job := range passed {
+					if name, ok := job.(string); ok {
+						input.Passed = append(input.Passed, name)
+					}
+				}
+			}
+			job.Inputs = append(job.Inputs, input)
+		}
+		if name, ok := step["put"].(string); ok {
+			if resource := stepResource(step, name); !job.puts(resource) {
+				job.Outputs = append(job.Outputs, resource)
+			}
+		}
+		// Walk nested steps in a stable order, for the steps to keep theirs
+		var keys []string
+		for key := range step {
+			switch key {
+			case "get", "put", "passed", "trigger", "params", "version":
+			default:
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			collectSteps(step[key], job)
+		}
+	}
+}
+
+// stepResource returns the resource a get or put step uses, which is the
+// step's name unless it sets resource
+func stepResource(step map[string]interface{}, name string) string {
+	if resource, ok := step["resource"].(string); ok && resource != "" {
+		return resource
+	}
+	return name
+}
+
+// ValidatePipelineConfig checks the pipeline config in the file at path, and
+// returns whether fly accepted it along with fly's complaints
+func (c *Client) ValidatePipelineConfig(path string) (bool, string, error) {
+	output, ok, err := c.execFlyCombined("validate-pipeline", "-c", path)
+	return ok && err == nil, output, err
+}
+
+// SetPipelineConfig replaces a pipeline's config with the file at path,
+// without fly asking for confirmation
+func (c *Client) SetPipelineConfig(pipeline, path string) (bool, string, error) {
+	name, vars := splitPipelineRef(pipeline)
+	args := []string{"set-pipeline", "-n", "-p", name, "-c", path}
+	for _, pair := range vars {
+		args = append(args, "-i", pair)
+	}
+	output, ok, err := c.execFlyCombined(args...)
+	return ok && err == nil, output, err
+}
@@ -0,0 +1,78 @@
+package concourse
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// retryBackoff is how long the first retry waits, each next one waits twice
+// as long
+const retryBackoff = 500 * time.Millisecond
+
+// transientErrors are bits of fly's errors meaning the target couldn't be
+// reached for now, rather than refusing the command
+var transientErrors = []string{
+	"connection refused",
+	"connection reset",
+	"no such host",
+	"i/o timeout",
+	"tls handshake timeout",
+	"network is unreachable",
+	"temporary failure",
+	"unexpected eof",
+	"502 bad gateway",
+	"503 service unavailable",
+	"504 gateway timeout",
+}
+
+var (
+	retriesMu sync.Mutex
+	retries   int
+)
+
+// SetRetries makes commands that only fetch data run up to n more times when
+// they fail because the target seems unreachable. Zero, the default, never
+// retries.
+func SetRetries(n int) {
+	retriesMu.Lock()
+	defer retriesMu.Unlock()
+	retries = n
+}
+
+// retryCount returns how many times a failed command may be retried
+func retryCount() int {
+	retriesMu.Lock()
+	defer retriesMu.Unlock()
+	return retries
+}
+
+// waitToRetry waits before the given retry of a command, or returns
+// ErrCanceled when CancelReads is called meanwhile
+func waitToRetry(args []string, attempt int) error {
+	ctx := readContext()
+	select {
+	case <-time.After(retryBackoff << attempt):
+		return nil
+	case <-ctx.Done():
+		return commandInterrupted(ctx, args)
+	}
+}
+
+// IsTransientError reports whether err looks like a network problem that may
+// go away by itself. Auth errors never do, they need a new login.
+func IsTransientError(err error) bool {
+	if err == nil || IsAuthError(err) || IsOutOfSyncError(err) || IsCanceled(err) {
+		return false
+	}
+	if IsTimeout(err) {
+		return true
+	}
+	message := strings.ToLower(err.Error())
+	for _, transient := range transientErrors {
+		if strings.Contains(message, transient) {
+			return true
+		}
+	}
+	return false
+}
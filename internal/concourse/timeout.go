@@ -0,0 +1,83 @@
+package concourse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultCommandTimeout is how long a fly command may run before it is
+// killed, unless configured otherwise
+const DefaultCommandTimeout = 30 * time.Second
+
+// ErrTimeout is returned for commands killed for running too long
+var ErrTimeout = errors.New("fly command timed out")
+
+// slowCommands are the fly commands that need longer than the default:
+// fly sync downloads fly itself
+var slowCommands = map[string]time.Duration{
+	"sync": 5 * time.Minute,
+}
+
+var (
+	timeoutsMu      sync.Mutex
+	commandTimeout  = DefaultCommandTimeout
+	commandTimeouts = map[string]time.Duration{} // by fly subcommand
+)
+
+// SetCommandTimeouts changes how long fly commands may run: timeout for
+// every command, except the fly subcommands perCommand sets a timeout for.
+// A timeout of zero lets the commands run as long as they take.
+func SetCommandTimeouts(timeout time.Duration, perCommand map[string]time.Duration) {
+	timeoutsMu.Lock()
+	defer timeoutsMu.Unlock()
+	commandTimeout = timeout
+	commandTimeouts = perCommand
+}
+
+// timeoutFor returns how long the fly subcommand may run
+func timeoutFor(command string) time.Duration {
+	timeoutsMu.Lock()
+	defer timeoutsMu.Unlock()
+	if timeout, ok := commandTimeouts[command]; ok {
+		return timeout
+	}
+	if timeout, ok := slowCommands[command]; ok && timeout > commandTimeout {
+		return timeout
+	}
+	return commandTimeout
+}
+
+// commandContext returns the context a fly command runs in: killed once it
+// runs too long, and once CancelReads is called when it only fetches data
+func commandContext(args []string) (context.Context, context.CancelFunc) {
+	parent := context.Background()
+	if readCommands[args[0]] {
+		parent = readContext()
+	}
+	timeout := timeoutFor(args[0])
+	if timeout <= 0 {
+		return context.WithCancel(parent)
+	}
+	return context.WithTimeout(parent, timeout)
+}
+
+// commandInterrupted returns why the command run in ctx was killed, or nil
+// when it ran to the end
+func commandInterrupted(ctx context.Context, args []string) error {
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return fmt.Errorf("%w after %s: fly %s", ErrTimeout, timeoutFor(args[0]), args[0])
+	case ctx.Err() != nil:
+		return fmt.Errorf("%w: fly %s", ErrCanceled, args[0])
+	}
+	return nil
+}
+
+// IsTimeout reports whether err means the command was killed for running
+// too long, e.g. because the target is unreachable
+func IsTimeout(err error) bool {
+	return errors.Is(err, ErrTimeout)
+}
@@ -0,0 +1,169 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// backupTimeFormat stamps backup file names, sorting them from oldest to newest
+const backupTimeFormat = "20060102-150405.000000"
+
+// flyrcBackups is how many backups of the flyrc are kept, none when 0
+var flyrcBackups = 10
+
+// SetFlyrcBackups sets how many backups of the flyrc FlyBy keeps, disabling
+// them when 0
+func SetFlyrcBackups(count int) {
+	flyrcBackups = count
+}
+
+// FlyrcBackup is a copy of the flyrc made before FlyBy changed it
+type FlyrcBackup struct {
+	Path    string
+	Time    time.Time
+	Targets []string // sorted names of the targets it has
+}
+
+// BackupDir returns the directory of the flyrc backups, within FlyBy's config
+// directory
+func BackupDir() (string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "flyrc-backups"), nil
+}
+
+// backupPrefix returns the name the backups of the flyrc at path start with,
+// e.g. "flyrc-" for ~/.flyrc
+func backupPrefix(path string) string {
+	return strings.TrimPrefix(filepath.Base(path), ".") + "-"
+}
+
+// backupFlyrc copies the flyrc at path to a new backup unless it is missing
+// or already holds next, then removes the oldest backups beyond the ones kept
+func backupFlyrc(path string, next []byte) error {
+	if flyrcBackups <= 0 {
+		return nil
+	}
+	current, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) || bytes.Equal(current, next) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to back up %s: %w", path, err)
+	}
+
+	dir, err := BackupDir()
+	if err != nil {
+		return fmt.Errorf("failed to back up %s: %w", path, err)
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to back up %s: %w", path, err)
+	}
+	name := backupPrefix(path) + time.Now().Format(backupTimeFormat)
+	if err := writeFileAtomic(filepath.Join(dir, name), current, 0600); err != nil {
+		return fmt.Errorf("failed to back up %s: %w", path, err)
+	}
+
+	names, err := backupNames(dir, path)
+	if err != nil {
+		return err
+	}
+	for len(names) > flyrcBackups {
+		os.Remove(filepath.Join(dir, names[0]))
+		names = names[1:]
+	}
+	return nil
+}
+
+// backupNames returns the file names of the backups of the flyrc at path,
+// oldest first
+func backupNames(dir, path string) ([]string, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list backups in %s: %w", dir, err)
+	}
+
+	prefix := backupPrefix(path)
+	var names []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() && strings.HasPrefix(name, prefix) && !strings.HasSuffix(name, ".tmp") {
+			if _, err := time.ParseInLocation(backupTimeFormat, strings.TrimPrefix(name, prefix), time.Local); err == nil {
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Backups returns the backups of the flyrc, newest first. Backups that can't
+// be read are left out.
+func (cm *ConfigManager) Backups() ([]FlyrcBackup, error) {
+	if cm.configPath == "" {
+		return nil, nil // In-memory configuration
+	}
+	dir, err := BackupDir()
+	if err != nil {
+		return nil, err
+	}
+	names, err := backupNames(dir, cm.configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var backups []FlyrcBackup
+	for i := len(names) - 1; i >= 0; i-- {
+		path := filepath.Join(dir, names[i])
+		config, _, err := readFlyrc(path)
+		if err != nil {
+			continue
+		}
+		backup := FlyrcBackup{Path: path}
+		backup.Time, _ = time.ParseInLocation(backupTimeFormat, strings.TrimPrefix(names[i], backupPrefix(cm.configPath)), time.Local)
+		for name := range config.Targets {
+			backup.Targets = append(backup.Targets, name)
+		}
+		sort.Strings(backup.Targets)
+		backups = append(backups, backup)
+	}
+	return backups, nil
+}
+
+// RestoreBackup replaces the flyrc with a backup, backing up the flyrc first
+// so the restore can be undone. Like a change made by another program, the
+// restored targets are picked up by LoadConfig.
+func (cm *ConfigManager) RestoreBackup(backup FlyrcBackup) error {
+	if cm.configPath == "" {
+		return fmt.Errorf("no flyrc to restore to")
+	}
+	data, err := ioutil.ReadFile(backup.Path)
+	if err != nil {
+		return fmt.Errorf("failed to read backup: %w", err)
+	}
+	if _, _, err := readFlyrc(backup.Path); err != nil {
+		return fmt.Errorf("invalid backup %s: %w", backup.Path, err)
+	}
+
+	unlock, err := lockFile(cm.configPath)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := backupFlyrc(cm.configPath, data); err != nil {
+		return err
+	}
+	return writeFileAtomic(cm.configPath, data, 0600)
+}
@@ -2,11 +2,15 @@ package config
 
 import (
 	"bufio"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
 	"gopkg.in/yaml.v2"
 )
@@ -15,11 +19,26 @@ import (
 type Token struct {
 	Type  string `yaml:"type"`
 	Value string `yaml:"value"`
+
+	Extra map[string]interface{} `yaml:",inline"` // fields FlyBy doesn't know, kept when saving
+}
+
+// ParseToken parses a token as the Concourse login page shows it for pasting
+// into fly, e.g. "bearer eyJhbGci...". A bare token is taken for a bearer one.
+func ParseToken(text string) (Token, error) {
+	fields := strings.Fields(text)
+	switch len(fields) {
+	case 1:
+		return Token{Type: "bearer", Value: fields[0]}, nil
+	case 2:
+		return Token{Type: strings.ToLower(fields[0]), Value: fields[1]}, nil
+	}
+	return Token{}, fmt.Errorf("expected a token like \"bearer eyJhbGci...\"")
 }
 
 // Target represents a Concourse target configuration
 type Target struct {
-	Name       string `yaml:"name"`
+	Name       string `yaml:"-"`          // the target's key in the flyrc
 	API        string `yaml:"api"`        // fly CLI uses 'api' not 'url'
 	Team       string `yaml:"team"`
 	Token      *Token `yaml:"token,omitempty"` // Token is a nested object
@@ -27,6 +46,8 @@ type Target struct {
 	CACert     string `yaml:"ca_cert,omitempty"`
 	ClientCert string `yaml:"client_cert,omitempty"`
 	ClientKey  string `yaml:"client_key,omitempty"`
+
+	Extra map[string]interface{} `yaml:",inline"` // fields FlyBy doesn't know, kept when saving
 }
 
 // GetURL returns the API URL for compatibility
@@ -47,25 +68,91 @@ func (t Target) HasToken() bool {
 	return t.Token != nil && t.Token.Value != ""
 }
 
+// TokenExpiry returns when the target's token expires, decoded from the JWT
+// fly stores. ok is false when there is no token or it carries no expiry.
+func (t Target) TokenExpiry() (expiry time.Time, ok bool) {
+	parts := strings.Split(t.GetTokenValue(), ".")
+	if len(parts) != 3 {
+		return time.Time{}, false
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}, false
+	}
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(claims.Exp, 0), true
+}
+
+// TokenExpired returns true if the target's token is known to have expired
+func (t Target) TokenExpired() bool {
+	expiry, ok := t.TokenExpiry()
+	return ok && !expiry.After(time.Now())
+}
+
 // FlyConfig represents the ~/.flyrc configuration
 type FlyConfig struct {
 	Targets map[string]Target `yaml:"targets"`
+
+	Extra map[string]interface{} `yaml:",inline"` // fields FlyBy doesn't know, kept when saving
+
+	header string // the comment lines the file starts with, which yaml drops
 }
 
 // ConfigManager handles fly configuration operations
 type ConfigManager struct {
 	configPath string
 	config     *FlyConfig
+	modTime    time.Time // of the flyrc when last read or written
+	size       int64
+}
+
+// flyrcPath overrides where the fly configuration is read from
+var flyrcPath string
+
+// SetFlyrcPath makes FlyBy use the fly configuration at path, e.g. from --flyrc
+func SetFlyrcPath(path string) {
+	flyrcPath = path
+}
+
+// FlyrcPath returns the fly configuration file in use: the one given to
+// SetFlyrcPath, then $FLYBY_FLYRC, then ~/.flyrc
+func FlyrcPath() (string, error) {
+	for _, path := range []string{flyrcPath, os.Getenv("FLYBY_FLYRC")} {
+		if path != "" {
+			return ExpandHome(path), nil
+		}
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".flyrc"), nil
+}
+
+// ExpandHome expands a leading ~/ in path to the user's home directory
+func ExpandHome(path string) string {
+	if strings.HasPrefix(path, "~/") {
+		if homeDir, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(homeDir, path[2:])
+		}
+	}
+	return path
 }
 
 // NewConfigManager creates a new configuration manager
 func NewConfigManager() (*ConfigManager, error) {
-	homeDir, err := os.UserHomeDir()
+	configPath, err := FlyrcPath()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get home directory: %w", err)
+		return nil, err
 	}
 
-	configPath := filepath.Join(homeDir, ".flyrc")
 	manager := &ConfigManager{
 		configPath: configPath,
 		config:     &FlyConfig{Targets: make(map[string]Target)},
@@ -81,24 +168,120 @@ func NewConfigManager() (*ConfigManager, error) {
 	return manager, nil
 }
 
-// LoadConfig loads the fly configuration from ~/.flyrc
+// NewMemoryConfigManager creates a configuration manager holding targets
+// that are never read from or written to disk, e.g. for demo mode
+func NewMemoryConfigManager(targets map[string]Target) *ConfigManager {
+	config := &FlyConfig{Targets: make(map[string]Target)}
+	for name, target := range targets {
+		target.Name = name
+		config.Targets[name] = target
+	}
+	return &ConfigManager{config: config}
+}
+
+// LoadConfig loads the fly configuration from the flyrc file, replacing
+// what was loaded before
 func (cm *ConfigManager) LoadConfig() error {
-	data, err := ioutil.ReadFile(cm.configPath)
+	if cm.configPath == "" {
+		return nil // In-memory configuration
+	}
+	config, info, err := readFlyrc(cm.configPath)
 	if err != nil {
 		return err
 	}
 
-	return yaml.Unmarshal(data, cm.config)
+	cm.config = config
+	cm.modTime, cm.size = info.ModTime(), info.Size()
+	return nil
+}
+
+// readFlyrc reads and parses the flyrc at path
+func readFlyrc(path string) (*FlyConfig, os.FileInfo, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	config := &FlyConfig{}
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, nil, err
+	}
+	for _, line := range strings.SplitAfter(string(data), "\n") {
+		if !strings.HasPrefix(line, "#") {
+			break
+		}
+		config.header += line
+	}
+	if config.Targets == nil {
+		config.Targets = make(map[string]Target)
+	}
+	for name, target := range config.Targets {
+		target.Name = name
+		config.Targets[name] = target
+	}
+	return config, info, nil
+}
+
+// Changed returns true if the flyrc was modified since it was last read or
+// written, e.g. by fly login in another terminal
+func (cm *ConfigManager) Changed() bool {
+	if cm.configPath == "" {
+		return false
+	}
+	info, err := os.Stat(cm.configPath)
+	if err != nil {
+		return false
+	}
+	return !info.ModTime().Equal(cm.modTime) || info.Size() != cm.size
 }
 
-// SaveConfig saves the configuration to ~/.flyrc
-func (cm *ConfigManager) SaveConfig() error {
-	data, err := yaml.Marshal(cm.config)
+// update applies change to the flyrc as it is on disk rather than to the
+// copy loaded earlier, so targets and tokens fly wrote meanwhile, e.g. from
+// a login in another terminal, survive. It holds the flyrc lock from reading
+// until the changed file is renamed into place.
+func (cm *ConfigManager) update(change func(config *FlyConfig) error) error {
+	if cm.configPath == "" {
+		return change(cm.config) // In-memory configuration
+	}
+
+	unlock, err := lockFile(cm.configPath)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	config, _, err := readFlyrc(cm.configPath)
+	if os.IsNotExist(err) {
+		config = &FlyConfig{Targets: make(map[string]Target)}
+	} else if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := change(config); err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(config)
 	if err != nil {
 		return fmt.Errorf("failed to marshal config: %w", err)
 	}
+	data = append([]byte(config.header), data...)
+	if err := backupFlyrc(cm.configPath, data); err != nil {
+		return err
+	}
+	if err := writeFileAtomic(cm.configPath, data, 0600); err != nil {
+		return err
+	}
 
-	return ioutil.WriteFile(cm.configPath, data, 0600)
+	cm.config = config
+	// Our own write isn't a change to pick up
+	if info, err := os.Stat(cm.configPath); err == nil {
+		cm.modTime, cm.size = info.ModTime(), info.Size()
+	}
+	return nil
 }
 
 // GetTargets returns all configured targets
@@ -106,6 +289,16 @@ func (cm *ConfigManager) GetTargets() map[string]Target {
 	return cm.config.Targets
 }
 
+// GetTargetNames returns the names of all configured targets, sorted
+func (cm *ConfigManager) GetTargetNames() []string {
+	names := make([]string, 0, len(cm.config.Targets))
+	for name := range cm.config.Targets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // GetTarget returns a specific target by name
 func (cm *ConfigManager) GetTarget(name string) (Target, bool) {
 	target, exists := cm.config.Targets[name]
@@ -118,34 +311,84 @@ func (cm *ConfigManager) AddTarget(name, url, team string) error {
 		return fmt.Errorf("name, url, and team are required")
 	}
 
-	cm.config.Targets[name] = Target{
-		Name: name,
-		API:  url,  // Use API field instead of URL
-		Team: team,
-	}
-
-	return cm.SaveConfig()
+	return cm.update(func(config *FlyConfig) error {
+		config.Targets[name] = Target{
+			Name: name,
+			API:  url,  // Use API field instead of URL
+			Team: team,
+		}
+		return nil
+	})
 }
 
 // RemoveTarget removes a target from the configuration
 func (cm *ConfigManager) RemoveTarget(name string) error {
-	if _, exists := cm.config.Targets[name]; !exists {
-		return fmt.Errorf("target '%s' does not exist", name)
-	}
+	return cm.update(func(config *FlyConfig) error {
+		if _, exists := config.Targets[name]; !exists {
+			return fmt.Errorf("target '%s' does not exist", name)
+		}
 
-	delete(cm.config.Targets, name)
-	return cm.SaveConfig()
+		delete(config.Targets, name)
+		return nil
+	})
 }
 
 // UpdateTarget updates an existing target
 func (cm *ConfigManager) UpdateTarget(name string, target Target) error {
-	if _, exists := cm.config.Targets[name]; !exists {
-		return fmt.Errorf("target '%s' does not exist", name)
-	}
+	return cm.update(func(config *FlyConfig) error {
+		if _, exists := config.Targets[name]; !exists {
+			return fmt.Errorf("target '%s' does not exist", name)
+		}
+
+		target.Name = name
+		config.Targets[name] = target
+		return nil
+	})
+}
+
+// EditTarget changes fields of an existing target as it is on disk, keeping
+// the others, like a token fly saved meanwhile
+func (cm *ConfigManager) EditTarget(name string, edit func(target *Target)) error {
+	return cm.RenameAndEditTarget(name, name, edit)
+}
+
+// RenameAndEditTarget renames an existing target and changes its fields in
+// a single write, so a failure leaves the target as it was
+func (cm *ConfigManager) RenameAndEditTarget(oldName, newName string, edit func(target *Target)) error {
+	return cm.update(func(config *FlyConfig) error {
+		target, exists := config.Targets[oldName]
+		if !exists {
+			return fmt.Errorf("target '%s' does not exist", oldName)
+		}
+		if _, taken := config.Targets[newName]; taken && newName != oldName {
+			return fmt.Errorf("target '%s' already exists", newName)
+		}
+
+		edit(&target)
+		delete(config.Targets, oldName)
+		target.Name = newName
+		config.Targets[newName] = target
+		return nil
+	})
+}
+
+// SetToken saves a new token for an existing target
+func (cm *ConfigManager) SetToken(name string, token Token) error {
+	return cm.update(func(config *FlyConfig) error {
+		target, exists := config.Targets[name]
+		if !exists {
+			return fmt.Errorf("target '%s' does not exist", name)
+		}
+
+		target.Token = &token
+		config.Targets[name] = target
+		return nil
+	})
+}
 
-	target.Name = name
-	cm.config.Targets[name] = target
-	return cm.SaveConfig()
+// RenameTarget renames an existing target, keeping its settings and token
+func (cm *ConfigManager) RenameTarget(oldName, newName string) error {
+	return cm.RenameAndEditTarget(oldName, newName, func(target *Target) {})
 }
 
 // GetConfigPath returns the path to the fly config file
@@ -0,0 +1,76 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// ConfigDir returns the directory of FlyBy's config and state files:
+// $XDG_CONFIG_HOME/flyby, %AppData%\flyby on Windows, or ~/.config/flyby,
+// which macOS command line tools favor over ~/Library
+func ConfigDir() (string, error) {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); filepath.IsAbs(dir) {
+		return filepath.Join(dir, "flyby"), nil
+	}
+	if runtime.GOOS == "windows" {
+		dir, err := os.UserConfigDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get config directory: %w", err)
+		}
+		return filepath.Join(dir, "flyby"), nil
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".config", "flyby"), nil
+}
+
+// CacheDir returns the directory of files FlyBy can do without, like logs:
+// $XDG_CACHE_HOME/flyby, or the platform's cache directory, i.e.
+// ~/Library/Caches on macOS, %LocalAppData% on Windows and ~/.cache elsewhere
+func CacheDir() (string, error) {
+	if dir := os.Getenv("XDG_CACHE_HOME"); filepath.IsAbs(dir) {
+		return filepath.Join(dir, "flyby"), nil
+	}
+
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get cache directory: %w", err)
+	}
+	return filepath.Join(dir, "flyby"), nil
+}
+
+// LogDir returns the directory of FlyBy's logs, within its cache directory
+func LogDir() (string, error) {
+	dir, err := CacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "logs"), nil
+}
+
+// ConfigFile returns the path of a file in FlyBy's config directory. A file
+// only found in ~/.config/flyby, where FlyBy kept its files regardless of
+// XDG_CONFIG_HOME and the platform before, is used from there.
+func ConfigFile(name string) (string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, name)
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		return path, nil
+	}
+
+	if homeDir, err := os.UserHomeDir(); err == nil {
+		legacy := filepath.Join(homeDir, ".config", "flyby", name)
+		if _, err := os.Stat(legacy); err == nil {
+			return legacy, nil
+		}
+	}
+	return path, nil
+}
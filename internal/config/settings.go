@@ -0,0 +1,164 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Settings represents FlyBy's own configuration from config.yaml in its
+// config directory
+type Settings struct {
+	DefaultTarget    string              `yaml:"default_target,omitempty"`
+	RefreshInterval  int                 `yaml:"refresh_interval,omitempty"` // seconds between automatic refreshes
+	BuildsCount      int                 `yaml:"builds_count,omitempty"`     // number of builds fetched per job
+	Theme            string              `yaml:"theme,omitempty"`
+	ClipboardCommand string              `yaml:"clipboard_command,omitempty"` // e.g. "xclip -selection clipboard"
+	TerminalCommand  string              `yaml:"terminal_command,omitempty"`  // opens a window running the command after it, e.g. "tmux split-window"
+	Keys             map[string][]string `yaml:"keys,omitempty"`              // action name -> keys, replacing the defaults
+	NoColor          bool                `yaml:"no_color,omitempty"`          // also enabled by the NO_COLOR environment variable
+	Plain            bool                `yaml:"plain,omitempty"`             // no colors and ASCII-only glyphs
+	HistoryLog       string              `yaml:"history_log,omitempty"`       // file every executed fly command is appended to
+	Notifications    bool                `yaml:"notifications,omitempty"`     // desktop notifications when triggered, rerun or watched builds finish
+	Bell             bool                `yaml:"bell,omitempty"`              // ring the terminal bell when watched builds finish or triggered ones fail
+	ShowArchived     bool                `yaml:"show_archived,omitempty"`     // list archived pipelines, A toggles it at runtime
+	CommandTimeout   int                 `yaml:"command_timeout,omitempty"`   // seconds a fly command may run before it is killed
+	CommandTimeouts  map[string]int      `yaml:"command_timeouts,omitempty"`  // fly subcommand -> seconds, overriding command_timeout
+	Retries          int                 `yaml:"retries,omitempty"`           // retries of fly commands fetching data when the target seems unreachable
+	Commands         []CustomCommand     `yaml:"commands,omitempty"`          // the user's own commands, bound to keys
+	Hooks            Hooks               `yaml:"hooks,omitempty"`             // shell commands run after events
+	Groups           map[string][]string `yaml:"groups,omitempty"`            // group name -> target names, e.g. one group per environment
+	RestoreSession   string              `yaml:"restore_session,omitempty"`   // ask, always or never return to where FlyBy last quit
+	FlyrcBackups     int                 `yaml:"flyrc_backups,omitempty"`     // backups of the flyrc kept, made before FlyBy changes it
+	Demo             bool                `yaml:"-"`                           // generated data instead of fly, set by --demo
+	StartTarget      string              `yaml:"-"`                           // set by --target, unlike default_target it must exist
+	StartPipeline    string              `yaml:"-"`                           // set by a target/pipeline[/job] path
+	StartJob         string              `yaml:"-"`
+
+	path string
+}
+
+// CustomCommand is a shell command of the user's bound to a key in some
+// views, e.g. "fly -t {target} trigger-job -j {pipeline}/{job} -w". The
+// placeholders are filled in from the selected item.
+type CustomCommand struct {
+	Name       string   `yaml:"name"`
+	Key        string   `yaml:"key"`
+	Views      []string `yaml:"views"` // targets, pipelines, jobs, builds or resources
+	Command    string   `yaml:"command"`
+	Background bool     `yaml:"background,omitempty"` // run without leaving FlyBy, reporting the outcome in a notification
+}
+
+// Hooks are shell commands run in the background after events, with what
+// happened in FLYBY_* environment variables. Empty hooks are skipped.
+type Hooks struct {
+	JobTriggered        string `yaml:"job_triggered,omitempty"`         // a job was triggered from FlyBy
+	BuildFinished       string `yaml:"build_finished,omitempty"`        // a build of a watched job or pipeline finished
+	ResourceCheckFailed string `yaml:"resource_check_failed,omitempty"` // a resource check from FlyBy failed
+}
+
+// DefaultSettings returns the settings used when no config file exists
+func DefaultSettings() *Settings {
+	return &Settings{
+		RefreshInterval: 30,
+		BuildsCount:     50,
+		CommandTimeout:  30,
+		Theme:           "auto",
+		RestoreSession:  "ask",
+		FlyrcBackups:    10,
+	}
+}
+
+// SettingsPath returns FlyBy's config file: $FLYBY_CONFIG, or config.yaml
+// in its config directory
+func SettingsPath() (string, error) {
+	if path := os.Getenv("FLYBY_CONFIG"); path != "" {
+		return ExpandHome(path), nil
+	}
+	return ConfigFile("config.yaml")
+}
+
+// LoadSettings loads FlyBy's configuration, falling back to defaults for
+// anything the file doesn't set or when the file doesn't exist
+func LoadSettings() (*Settings, error) {
+	path, err := SettingsPath()
+	if err != nil {
+		return nil, err
+	}
+
+	settings := DefaultSettings()
+	settings.path = path
+
+	data, err := ioutil.ReadFile(settings.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return settings, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", settings.path, err)
+	}
+
+	if err := yaml.Unmarshal(data, settings); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", settings.path, err)
+	}
+
+	if settings.RefreshInterval <= 0 {
+		settings.RefreshInterval = DefaultSettings().RefreshInterval
+	}
+	if settings.BuildsCount <= 0 {
+		settings.BuildsCount = DefaultSettings().BuildsCount
+	}
+	if settings.CommandTimeout <= 0 {
+		settings.CommandTimeout = DefaultSettings().CommandTimeout
+	}
+	if settings.FlyrcBackups < 0 {
+		return nil, fmt.Errorf("invalid flyrc_backups %d in %s, expected 0 or more", settings.FlyrcBackups, settings.path)
+	}
+	switch settings.RestoreSession {
+	case "":
+		settings.RestoreSession = DefaultSettings().RestoreSession
+	case "ask", "always", "never":
+	default:
+		return nil, fmt.Errorf("invalid restore_session %q in %s, expected ask, always or never", settings.RestoreSession, settings.path)
+	}
+
+	return settings, nil
+}
+
+// GetPath returns the path to the FlyBy config file
+func (s *Settings) GetPath() string {
+	return s.path
+}
+
+// GetRefreshInterval returns the automatic refresh interval as a duration
+func (s *Settings) GetRefreshInterval() time.Duration {
+	return time.Duration(s.RefreshInterval) * time.Second
+}
+
+// GetCommandTimeouts returns how long fly commands may run, and how long
+// the fly subcommands with their own timeout may
+func (s *Settings) GetCommandTimeouts() (time.Duration, map[string]time.Duration) {
+	perCommand := make(map[string]time.Duration)
+	for command, seconds := range s.CommandTimeouts {
+		if seconds > 0 {
+			perCommand[command] = time.Duration(seconds) * time.Second
+		}
+	}
+	return time.Duration(s.CommandTimeout) * time.Second, perCommand
+}
+
+// GetHistoryLogPath returns the command history log path with a leading ~
+// expanded and a bare file name put in FlyBy's log directory, or "" when no
+// log is configured
+func (s *Settings) GetHistoryLogPath() string {
+	path := ExpandHome(s.HistoryLog)
+	if path != "" && filepath.Base(path) == path {
+		if dir, err := LogDir(); err == nil {
+			return filepath.Join(dir, path)
+		}
+	}
+	return path
+}
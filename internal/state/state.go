@@ -0,0 +1,325 @@
+package state
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"flyby/internal/config"
+
+	"gopkg.in/yaml.v2"
+)
+
+// State represents FlyBy's own persisted UI state
+type State struct {
+	Favorites map[string][]string `yaml:"favorites,omitempty"` // target name -> pipeline names
+	Watchlist []WatchItem         `yaml:"watchlist,omitempty"`
+	Recent    []RecentItem        `yaml:"recent,omitempty"`
+	Pane      *Pane               `yaml:"pane,omitempty"`
+	Group     string              `yaml:"group,omitempty"` // the active target group, "" for all targets
+	Session   *Session            `yaml:"session,omitempty"`
+}
+
+// Session represents where the user was when FlyBy last quit
+type Session struct {
+	View     string `yaml:"view"` // pipelines, jobs, resources or builds
+	Target   string `yaml:"target"`
+	Pipeline string `yaml:"pipeline,omitempty"`
+	Job      string `yaml:"job,omitempty"`
+	Selected string `yaml:"selected,omitempty"` // the pipeline, job, resource or build under the cursor
+}
+
+// DefaultPaneRatio is the share of the width, in percent, the detail pane
+// of the split layout takes unless resized
+const DefaultPaneRatio = 40
+
+// Pane represents how the detail pane of the split layout was last sized
+type Pane struct {
+	Ratio     int  `yaml:"ratio"` // share of the width, in percent
+	Collapsed bool `yaml:"collapsed,omitempty"`
+}
+
+// maxRecentItems is how many recently visited items are remembered
+const maxRecentItems = 10
+
+// RecentItem represents a recently visited pipeline, job, or build
+type RecentItem struct {
+	Target      string `yaml:"target"`
+	Pipeline    string `yaml:"pipeline"`
+	Job         string `yaml:"job,omitempty"`
+	Build       string `yaml:"build,omitempty"`
+	VisitedUnix int64  `yaml:"visited"`
+}
+
+// GetVisited returns the visit time as a proper time.Time
+func (r RecentItem) GetVisited() time.Time {
+	return time.Unix(r.VisitedUnix, 0)
+}
+
+// sameLocation returns true if both items point at the same pipeline, job, or build
+func (r RecentItem) sameLocation(other RecentItem) bool {
+	return r.Target == other.Target && r.Pipeline == other.Pipeline &&
+		r.Job == other.Job && r.Build == other.Build
+}
+
+// WatchItem represents a watched job, or a whole pipeline when Job is empty
+type WatchItem struct {
+	Target   string `yaml:"target"`
+	Pipeline string `yaml:"pipeline"`
+	Job      string `yaml:"job,omitempty"`
+}
+
+// Key returns a unique identifier for the watched item
+func (w WatchItem) Key() string {
+	return w.Target + "/" + w.Pipeline + "/" + w.Job
+}
+
+// Store handles loading and saving FlyBy's state file
+type Store struct {
+	path  string
+	state *State
+}
+
+// NewStore creates a state store backed by state.yaml in FlyBy's config
+// directory
+func NewStore() (*Store, error) {
+	path, err := config.ConfigFile("state.yaml")
+	if err != nil {
+		return nil, err
+	}
+
+	store := &Store{
+		path:  path,
+		state: &State{},
+	}
+
+	if err := store.Load(); err != nil {
+		// A missing state file just means nothing has been saved yet
+		if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to load state: %w", err)
+		}
+	}
+
+	return store, nil
+}
+
+// NewMemoryStore creates a state store that is never saved to disk, e.g. for demo mode
+func NewMemoryStore() *Store {
+	return &Store{state: &State{}}
+}
+
+// Load reads the state file from disk
+func (s *Store) Load() error {
+	data, err := ioutil.ReadFile(s.path)
+	if err != nil {
+		return err
+	}
+
+	return yaml.Unmarshal(data, s.state)
+}
+
+// Save writes the state file to disk, creating its directory if needed
+func (s *Store) Save() error {
+	data, err := yaml.Marshal(s.state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal state: %w", err)
+	}
+	if s.path == "" {
+		return nil // In-memory store
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	return ioutil.WriteFile(s.path, data, 0600)
+}
+
+// GetPath returns the path to the state file
+func (s *Store) GetPath() string {
+	return s.path
+}
+
+// IsFavorite returns true if the pipeline is starred for the target
+func (s *Store) IsFavorite(target, pipeline string) bool {
+	for _, name := range s.state.Favorites[target] {
+		if name == pipeline {
+			return true
+		}
+	}
+	return false
+}
+
+// ToggleFavorite stars or unstars a pipeline and persists the change.
+// It returns whether the pipeline is now a favorite.
+func (s *Store) ToggleFavorite(target, pipeline string) (bool, error) {
+	if s.state.Favorites == nil {
+		s.state.Favorites = make(map[string][]string)
+	}
+
+	favorites := s.state.Favorites[target]
+	for i, name := range favorites {
+		if name == pipeline {
+			s.state.Favorites[target] = append(favorites[:i], favorites[i+1:]...)
+			if len(s.state.Favorites[target]) == 0 {
+				delete(s.state.Favorites, target)
+			}
+			return false, s.Save()
+		}
+	}
+
+	s.state.Favorites[target] = append(favorites, pipeline)
+	return true, s.Save()
+}
+
+// GetWatchlist returns all watched jobs and pipelines
+func (s *Store) GetWatchlist() []WatchItem {
+	return s.state.Watchlist
+}
+
+// IsWatched returns true if the item is on the watchlist
+func (s *Store) IsWatched(item WatchItem) bool {
+	for _, watched := range s.state.Watchlist {
+		if watched == item {
+			return true
+		}
+	}
+	return false
+}
+
+// ToggleWatch adds or removes an item from the watchlist and persists the change.
+// It returns whether the item is now watched.
+func (s *Store) ToggleWatch(item WatchItem) (bool, error) {
+	if s.IsWatched(item) {
+		return false, s.RemoveWatch(item)
+	}
+
+	s.state.Watchlist = append(s.state.Watchlist, item)
+	return true, s.Save()
+}
+
+// RemoveWatch removes an item from the watchlist and persists the change
+func (s *Store) RemoveWatch(item WatchItem) error {
+	for i, watched := range s.state.Watchlist {
+		if watched == item {
+			s.state.Watchlist = append(s.state.Watchlist[:i], s.state.Watchlist[i+1:]...)
+			return s.Save()
+		}
+	}
+	return fmt.Errorf("%s is not on the watchlist", item.Key())
+}
+
+// RenameTarget moves the favorites, watched items, recent items and session
+// of a renamed target to its new name, and persists the change
+func (s *Store) RenameTarget(oldName, newName string) error {
+	if favorites, ok := s.state.Favorites[oldName]; ok {
+		delete(s.state.Favorites, oldName)
+		for _, pipeline := range favorites {
+			if !s.IsFavorite(newName, pipeline) {
+				s.state.Favorites[newName] = append(s.state.Favorites[newName], pipeline)
+			}
+		}
+	}
+
+	watchlist := s.state.Watchlist[:0]
+	for _, item := range s.state.Watchlist {
+		if item.Target == oldName {
+			item.Target = newName
+		}
+		if !containsWatch(watchlist, item) {
+			watchlist = append(watchlist, item)
+		}
+	}
+	s.state.Watchlist = watchlist
+
+	recent := s.state.Recent[:0]
+	for _, item := range s.state.Recent {
+		if item.Target == oldName {
+			item.Target = newName
+		}
+		duplicate := false
+		for _, kept := range recent {
+			duplicate = duplicate || kept.sameLocation(item)
+		}
+		if !duplicate {
+			recent = append(recent, item)
+		}
+	}
+	s.state.Recent = recent
+
+	if s.state.Session != nil && s.state.Session.Target == oldName {
+		s.state.Session.Target = newName
+	}
+	return s.Save()
+}
+
+// containsWatch reports whether items holds item
+func containsWatch(items []WatchItem, item WatchItem) bool {
+	for _, existing := range items {
+		if existing == item {
+			return true
+		}
+	}
+	return false
+}
+
+// GetPane returns how the detail pane was last sized, or its default size
+func (s *Store) GetPane() Pane {
+	if s.state.Pane == nil || s.state.Pane.Ratio <= 0 {
+		return Pane{Ratio: DefaultPaneRatio}
+	}
+	return *s.state.Pane
+}
+
+// SetPane records how the detail pane is sized and persists the change
+func (s *Store) SetPane(pane Pane) error {
+	s.state.Pane = &pane
+	return s.Save()
+}
+
+// GetGroup returns the active target group, or "" when all targets are shown
+func (s *Store) GetGroup() string {
+	return s.state.Group
+}
+
+// SetGroup records the active target group and persists the change
+func (s *Store) SetGroup(group string) error {
+	s.state.Group = group
+	return s.Save()
+}
+
+// GetSession returns where the user was when FlyBy last quit, or nil
+func (s *Store) GetSession() *Session {
+	return s.state.Session
+}
+
+// SetSession records where the user is as FlyBy quits, or forgets it when
+// session is nil, and persists the change
+func (s *Store) SetSession(session *Session) error {
+	s.state.Session = session
+	return s.Save()
+}
+
+// GetRecent returns recently visited items, most recent first
+func (s *Store) GetRecent() []RecentItem {
+	return s.state.Recent
+}
+
+// AddRecent records a visit, moving it to the front of the recent list, and persists the change
+func (s *Store) AddRecent(item RecentItem) error {
+	if item.VisitedUnix == 0 {
+		item.VisitedUnix = time.Now().Unix()
+	}
+
+	recent := []RecentItem{item}
+	for _, existing := range s.state.Recent {
+		if !existing.sameLocation(item) && len(recent) < maxRecentItems {
+			recent = append(recent, existing)
+		}
+	}
+	s.state.Recent = recent
+
+	return s.Save()
+}
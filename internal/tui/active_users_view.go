@@ -0,0 +1,294 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"flyby/internal/concourse"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ActiveUsersViewModel represents the list of users who recently logged in to
+// a target's cluster
+type ActiveUsersViewModel struct {
+	client        *concourse.Client
+	users         []concourse.ActiveUser // most recent login first
+	filteredUsers []concourse.ActiveUser
+	selected      int
+	scrollOffset  int
+	maxVisible    int
+	searchQuery   string
+	searchMode    bool
+	loading       bool
+	err           error
+	clicks        clickTracker
+	loader        loader
+}
+
+// ActiveUsersLoadedMsg represents loaded active users
+type ActiveUsersLoadedMsg struct {
+	Users []concourse.ActiveUser
+	Error error
+}
+
+// NewActiveUsersViewModel creates a new active users view model
+func NewActiveUsersViewModel() ActiveUsersViewModel {
+	return ActiveUsersViewModel{
+		maxVisible: 10,
+		loader:     newLoader(),
+	}
+}
+
+// LoadActiveUsers loads the active users of the client's target
+func (m *ActiveUsersViewModel) LoadActiveUsers(client *concourse.Client) tea.Cmd {
+	m.client = client
+	m.loading = true
+	m.err = nil
+	return func() tea.Msg {
+		users, err := client.ActiveUsers()
+		return ActiveUsersLoadedMsg{Users: users, Error: err}
+	}
+}
+
+// HandleActiveUsersLoaded handles loaded active users
+func (m ActiveUsersViewModel) HandleActiveUsersLoaded(msg ActiveUsersLoadedMsg) ActiveUsersViewModel {
+	m.loading = false
+	m.err = msg.Error
+	m.users = msg.Users
+	sort.SliceStable(m.users, func(i, j int) bool {
+		return m.users[i].LastLoginUnix > m.users[j].LastLoginUnix
+	})
+	m.filterUsers()
+	return m
+}
+
+// filterUsers filters users by username based on the current search query
+func (m *ActiveUsersViewModel) filterUsers() {
+	m.filteredUsers = nil
+	query := strings.ToLower(m.searchQuery)
+	for _, user := range m.users {
+		if strings.Contains(strings.ToLower(user.Username), query) {
+			m.filteredUsers = append(m.filteredUsers, user)
+		}
+	}
+	if m.selected >= len(m.filteredUsers) {
+		m.selected = 0
+		m.scrollOffset = 0
+	}
+}
+
+// Update handles messages for the active users view
+func (m ActiveUsersViewModel) Update(msg tea.KeyMsg) (ActiveUsersViewModel, tea.Cmd) {
+	// Handle search mode
+	if m.searchMode {
+		switch msg.String() {
+		case "enter":
+			m.searchMode = false
+		case "esc":
+			m.searchMode = false
+			m.searchQuery = ""
+			m.filterUsers()
+		case "backspace":
+			if len(m.searchQuery) > 0 {
+				m.searchQuery = m.searchQuery[:len(m.searchQuery)-1]
+				m.filterUsers()
+			}
+		case "ctrl+u":
+			m.searchQuery = ""
+			m.filterUsers()
+		default:
+			if len(msg.String()) == 1 {
+				m.searchQuery += msg.String()
+				m.filterUsers()
+			}
+		}
+		return m, nil
+	}
+
+	switch {
+	case keys.Matches(msg, actionUp):
+		if m.selected > 0 {
+			m.selected--
+			if m.selected < m.scrollOffset {
+				m.scrollOffset = m.selected
+			}
+		}
+	case keys.Matches(msg, actionDown):
+		if m.selected < len(m.filteredUsers)-1 {
+			m.selected++
+			if m.selected >= m.scrollOffset+m.maxVisible {
+				m.scrollOffset = m.selected - m.maxVisible + 1
+			}
+		}
+	case keys.Matches(msg, actionSearch):
+		m.searchMode = true
+	case keys.Matches(msg, actionClear):
+		m.searchQuery = ""
+		m.filterUsers()
+	case keys.Matches(msg, actionRefresh):
+		if m.client != nil {
+			cmd := m.LoadActiveUsers(m.client)
+			return m, cmd
+		}
+	}
+	return m, nil
+}
+
+// visibleRange returns the range of users shown for the given height
+func (m ActiveUsersViewModel) visibleRange(height int) (int, int) {
+	maxVisible := m.maxVisible
+	if height-14 > 0 { // Account for title, search box, indicators and help
+		maxVisible = height - 14
+	}
+
+	start := m.scrollOffset
+	if m.selected >= start+maxVisible {
+		start = m.selected - maxVisible + 1
+	}
+	if m.selected < start {
+		start = m.selected
+	}
+	return start, min(start+maxVisible, len(m.filteredUsers))
+}
+
+// Mouse handles clicks and scrolling over the users list
+func (m ActiveUsersViewModel) Mouse(msg tea.MouseMsg, height int) (ActiveUsersViewModel, tea.Cmd) {
+	if m.searchMode || m.loading {
+		return m, nil
+	}
+
+	start, end := m.visibleRange(height)
+	rows := listRows{top: searchListTop, height: 1, first: start, end: end}
+	if start > 0 {
+		rows.top++ // "more above" indicator
+	}
+
+	switch intent, index := m.clicks.interpret(msg, rows); intent {
+	case mouseScrollUp:
+		return m.Update(keyMsgFor(actionUp))
+	case mouseScrollDown:
+		return m.Update(keyMsgFor(actionDown))
+	case mouseClick, mouseDoubleClick:
+		m.selected = index
+	}
+	return m, nil
+}
+
+// View renders the active users view
+func (m ActiveUsersViewModel) View(width, height int) string {
+	titleStyle := lipgloss.NewStyle().
+		Foreground(theme.Primary).
+		Bold(true).
+		MarginBottom(1)
+
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(theme.Muted)
+
+	itemStyle := lipgloss.NewStyle().
+		PaddingLeft(2)
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(theme.Primary).
+		Bold(true).
+		PaddingLeft(1).
+		Border(lipgloss.NormalBorder(), false, false, false, true).
+		BorderForeground(theme.Primary)
+
+	searchStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Muted).
+		Padding(0, 1).
+		MarginBottom(1)
+
+	searchActiveStyle := searchStyle.Copy().
+		BorderForeground(theme.Primary)
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(theme.Muted).
+		Italic(true).
+		MarginTop(1)
+
+	var content strings.Builder
+	title := "Active Users"
+	if m.client != nil {
+		title = "Active Users - " + m.client.GetTarget()
+	}
+	content.WriteString(titleStyle.Render(title))
+	content.WriteString("\n\n")
+
+	if m.loading {
+		content.WriteString(m.loader.View("Loading active users...") + "\n")
+		return content.String()
+	}
+
+	if m.err != nil {
+		errorStyle := lipgloss.NewStyle().Foreground(theme.Error)
+		content.WriteString(errorStyle.Render("Error: " + errorSummary(m.err)))
+		content.WriteString("\n\n")
+		content.WriteString(mutedStyle.Render("Only admins of the cluster can list its active users."))
+		content.WriteString("\n")
+		return content.String()
+	}
+
+	searchPrompt := "Search: "
+	if m.searchMode {
+		content.WriteString(searchActiveStyle.Render(searchPrompt + m.searchQuery + "█"))
+	} else if m.searchQuery != "" {
+		content.WriteString(searchStyle.Render(searchPrompt + m.searchQuery))
+	} else {
+		content.WriteString(searchStyle.Render(searchPrompt + "(/,s to search by username)"))
+	}
+	content.WriteString("\n\n")
+
+	if len(m.filteredUsers) == 0 {
+		if m.searchQuery != "" {
+			content.WriteString("No users match search query.\n")
+		} else {
+			content.WriteString("No users logged in during the last two months.\n")
+		}
+	} else {
+		nameWidth := 0
+		for _, user := range m.filteredUsers {
+			nameWidth = max(nameWidth, len(user.Username))
+		}
+
+		start, end := m.visibleRange(height)
+		if start > 0 {
+			content.WriteString(itemStyle.Render(fmt.Sprintf("  ↑ (%d more above)", start)))
+			content.WriteString("\n")
+		}
+		for i := start; i < end; i++ {
+			user := m.filteredUsers[i]
+			lastLogin := user.GetLastLogin()
+			line := fmt.Sprintf("%-*s  %-8s  %s", nameWidth, user.Username, user.Connector, formatTimeAgo(lastLogin))
+			if !lastLogin.IsZero() {
+				line += mutedStyle.Render(" (" + lastLogin.Format("2006-01-02 15:04") + ")")
+			}
+			if i == m.selected {
+				content.WriteString(selectedStyle.Render("> " + line))
+			} else {
+				content.WriteString(itemStyle.Render("  " + line))
+			}
+			content.WriteString("\n")
+		}
+		if end < len(m.filteredUsers) {
+			content.WriteString(itemStyle.Render(fmt.Sprintf("  ↓ (%d more below)", len(m.filteredUsers)-end)))
+			content.WriteString("\n")
+		}
+		content.WriteString(mutedStyle.Render(fmt.Sprintf("%d of %d users", len(m.filteredUsers), len(m.users))))
+		content.WriteString("\n")
+	}
+
+	var help string
+	if m.searchMode {
+		help = "Enter: finish search • Esc: cancel search • Ctrl+U: clear"
+	} else {
+		help = keys.HelpLine(viewKeys[ViewActiveUsers]...)
+	}
+	content.WriteString(helpStyle.Render(help))
+
+	return content.String()
+}
@@ -3,10 +3,13 @@ package tui
 import (
 	"fmt"
 	"os"
-	"os/exec"
+	"path/filepath"
 	"strings"
-	"time"
 
+	"flyby/internal/concourse"
+	"flyby/internal/config"
+
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -14,15 +17,25 @@ import (
 // AddTargetViewModel represents the add target form
 type AddTargetViewModel struct {
 	fields     []string
-	values     []string
+	inputs     []textinput.Model
 	focused    int
 	submitted  bool
 	err        error
 	saving     bool
 	flyCommand string
-	saveResult string
+	authPrompt string
+	clipboardCommand string
+	loader     loader
+	insecure   bool // skip TLS verification, toggled below the text fields
+	original   string // name of the target being edited, "" when adding one
+	duplicateOf string // name of the target whose settings were copied
+	inheritedCACert string // PEM of the copied target's CA cert, used when no path is given
 }
 
+// requiredFields is the number of leading fields that must be filled in, the
+// TLS certificate paths after them are optional
+const requiredFields = 3
+
 // TargetCreateMsg represents the result of target creation
 type TargetCreateMsg struct {
 	Success bool
@@ -31,6 +44,24 @@ type TargetCreateMsg struct {
 	Command string
 }
 
+// TargetReachableMsg says the new target's URL is a Concourse, so fly can
+// log in to it
+type TargetReachableMsg struct {
+	Name string
+	URL  string
+	Team string
+	TLS  concourse.TLSOptions
+}
+
+// UpdateTargetMsg asks the targets view to save an edited target
+type UpdateTargetMsg struct {
+	Original string // the target's name before editing
+	Name     string
+	URL      string
+	Team     string
+	TLS      concourse.TLSOptions
+}
+
 // ExitAndRunCommandMsg represents a request to exit TUI and run a command
 type ExitAndRunCommandMsg struct {
 	Command string
@@ -38,12 +69,109 @@ type ExitAndRunCommandMsg struct {
 }
 
 // NewAddTargetViewModel creates a new add target view model
-func NewAddTargetViewModel() AddTargetViewModel {
-	return AddTargetViewModel{
-		fields: []string{"Name", "URL", "Team"},
-		values: []string{"", "", ""},
+func NewAddTargetViewModel(clipboardCommand string) AddTargetViewModel {
+	m := AddTargetViewModel{
+		fields: []string{"Name", "URL", "Team", "CA Cert", "Client Cert", "Client Key"},
 		focused: 0,
+		clipboardCommand: clipboardCommand,
+		loader:           newLoader(),
+	}
+	m.inputs = newTargetInputs()
+	return m
+}
+
+// newTargetInputs creates the text inputs for the name, URL, team and TLS
+// certificate fields
+func newTargetInputs() []textinput.Model {
+	placeholders := []string{"e.g., production", "e.g., https://ci.example.com", "e.g., main (default: main)",
+		"optional, path to a PEM file", "optional, path to a PEM file", "optional, path to a PEM file"}
+	inputs := make([]textinput.Model, len(placeholders))
+	for i, placeholder := range placeholders {
+		input := textinput.New()
+		input.Prompt = ""
+		input.Placeholder = placeholder
+		input.Width = 36
+		inputs[i] = input
+	}
+	inputs[0].Focus()
+	return inputs
+}
+
+// value returns the trimmed contents of a form field
+func (m AddTargetViewModel) value(i int) string {
+	return strings.TrimSpace(m.inputs[i].Value())
+}
+
+// editing reports whether keys go to the focused field
+func (m AddTargetViewModel) editing() bool {
+	return !m.saving && m.authPrompt == ""
+}
+
+// awaitingAuth reports whether the form is waiting on an external fly login
+func (m AddTargetViewModel) awaitingAuth() bool {
+	return !m.saving && m.authPrompt != ""
+}
+
+// onInsecureToggle reports whether the focus is on the insecure toggle, which
+// follows the text fields
+func (m AddTargetViewModel) onInsecureToggle() bool {
+	return m.focused == len(m.inputs)
+}
+
+// focusField moves the focus to the given field, len(m.inputs) being the
+// insecure toggle
+func (m AddTargetViewModel) focusField(i int) (AddTargetViewModel, tea.Cmd) {
+	if !m.onInsecureToggle() {
+		m.inputs[m.focused].Blur()
+	}
+	m.focused = i
+	if m.onInsecureToggle() {
+		return m, nil
+	}
+	return m, m.inputs[m.focused].Focus()
+}
+
+// tlsOptions returns the TLS options from the form, with ~ expanded in paths
+func (m AddTargetViewModel) tlsOptions() concourse.TLSOptions {
+	path := func(i int) string {
+		if m.value(i) == "" {
+			return ""
+		}
+		return config.ExpandHome(m.value(i))
+	}
+	return concourse.TLSOptions{
+		Insecure:   m.insecure,
+		CACert:     path(3),
+		ClientCert: path(4),
+		ClientKey:  path(5),
+	}
+}
+
+// loginCommand returns the fly login command creating the target
+func (m AddTargetViewModel) loginCommand() string {
+	name, url, team := m.value(0), m.value(1), m.value(2)
+	if team == "" {
+		team = "main"
 	}
+	args := append([]string{"fly", "-t", name}, concourse.LoginArgs(url, team, m.tlsOptions())...)
+	return concourse.CommandRecord{Args: args}.Command()
+}
+
+// validateTLS checks the certificate paths before handing them to fly
+func (m AddTargetViewModel) validateTLS() error {
+	tls := m.tlsOptions()
+	if (tls.ClientCert == "") != (tls.ClientKey == "") {
+		return fmt.Errorf("client cert and client key must be given together")
+	}
+	for i, path := range []string{tls.CACert, tls.ClientCert, tls.ClientKey} {
+		if path == "" {
+			continue
+		}
+		if _, err := os.Stat(path); err != nil {
+			return fmt.Errorf("%s: %w", m.fields[requiredFields+i], err)
+		}
+	}
+	return nil
 }
 
 // Init initializes the add target view model
@@ -56,53 +184,62 @@ func (m AddTargetViewModel) Update(msg tea.Msg) (AddTargetViewModel, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
-		case "tab":
-			if !m.saving && m.saveResult == "" {
-				m.focused = (m.focused + 1) % len(m.fields)
+		case "tab", "down":
+			if m.editing() {
+				return m.focusField((m.focused + 1) % (len(m.fields) + 1))
+			}
+			return m, nil
+		case "shift+tab", "up":
+			if m.editing() {
+				return m.focusField((m.focused + len(m.fields)) % (len(m.fields) + 1))
 			}
-		case "shift+tab":
-			if !m.saving && m.saveResult == "" {
-				m.focused = (m.focused - 1 + len(m.fields)) % len(m.fields)
+			return m, nil
+		case " ":
+			if m.editing() && m.onInsecureToggle() {
+				m.insecure = !m.insecure
+				return m, nil
 			}
 		case "enter":
-			if m.canSubmit() && !m.saving && m.saveResult == "" {
+			if m.canSubmit() && m.editing() {
 				return m.startSave()
-			} else if m.saveResult != "" {
-				// If showing results, go back to targets view
-				return m, func() tea.Msg {
-					return SwitchViewMsg{View: ViewTargets}
-				}
+			} else if m.awaitingAuth() {
+				// If showing the login instructions, go back to targets view
+				return m, navigateBack()
 			}
+			return m, nil
 		case "r":
 			// Retry checking target authentication
-			if !m.saving && m.saveResult != "" && strings.Contains(m.saveResult, "Interactive authentication required") {
-				// Clear results and check if target is now authenticated
-				m.saveResult = ""
+			if m.awaitingAuth() {
+				// Clear the prompt and check if target is now authenticated
+				m.authPrompt = ""
 				m.err = nil
 				
-				name := strings.TrimSpace(m.values[0])
+				name := m.value(0)
+				command := m.loginCommand()
 				if name != "" {
 					m.saving = true
 					return m, func() tea.Msg {
-						checkCmd := exec.Command("fly", "-t", name, "status")
-						checkOutput, checkErr := checkCmd.CombinedOutput()
+						loggedIn, checkErr := concourse.NewClient(name).Status()
 						
-						if checkErr == nil && strings.Contains(string(checkOutput), "logged in successfully") {
+						if loggedIn {
 							return TargetCreateMsg{
 								Success: true,
-								Output:  fmt.Sprintf("✅ Target '%s' is now authenticated and ready to use!", name),
+								Output:  fmt.Sprintf("Target '%s' is now authenticated and ready to use!", name),
 								Error:   nil,
 								Command: fmt.Sprintf("fly -t %s status", name),
 							}
 						}
 						
 						// Still not authenticated, check what the error is
-						outputStr := strings.TrimSpace(string(checkOutput))
+						outputStr := ""
+						if checkErr != nil {
+							outputStr = checkErr.Error()
+						}
 						if strings.Contains(outputStr, "not found") || strings.Contains(outputStr, "no such") {
 							return TargetCreateMsg{
 								Success: false,
-								Output:  fmt.Sprintf("❌ Target '%s' not found. Please run the fly login command in a separate terminal:\n\nfly -t %s login -c %s -n %s\n\nThen press 'r' again to retry.", 
-									name, name, strings.TrimSpace(m.values[1]), strings.TrimSpace(m.values[2])),
+								Output:  fmt.Sprintf("❌ Target '%s' not found. Please run the fly login command in a separate terminal:\n\n%s\n\nThen press 'r' again to retry.", 
+									name, command),
 								Error:   nil,
 								Command: "",
 							}
@@ -110,117 +247,65 @@ func (m AddTargetViewModel) Update(msg tea.Msg) (AddTargetViewModel, tea.Cmd) {
 						
 						return TargetCreateMsg{
 							Success: false,
-							Output:  fmt.Sprintf("⏳ Target '%s' exists but authentication is still pending.\n\nIf you're still completing browser authentication, wait and press 'r' again.\n\nIf authentication failed, run this command in a separate terminal:\nfly -t %s login -c %s -n %s", 
-								name, name, strings.TrimSpace(m.values[1]), strings.TrimSpace(m.values[2])),
+							Output:  fmt.Sprintf("⏳ Target '%s' exists but authentication is still pending.\n\nIf you're still completing browser authentication, wait and press 'r' again.\n\nIf authentication failed, run this command in a separate terminal:\n%s", 
+								name, command),
 							Error:   nil,
 							Command: "",
 						}
 					}
 				}
-			} else {
-				// If we're in input mode and not showing auth error, treat 'r' as regular text input
-				if m.focused < len(m.values) && !m.saving && m.saveResult == "" {
-					m.values[m.focused] += "r"
-				}
+				return m, nil
 			}
-			return m, nil
 		case "c":
 			// Copy command to clipboard (when showing interactive auth message)
-			if !m.saving && m.saveResult != "" && strings.Contains(m.saveResult, "Interactive authentication required") {
-				name := strings.TrimSpace(m.values[0])
-				url := strings.TrimSpace(m.values[1])  
-				team := strings.TrimSpace(m.values[2])
-				if team == "" {
-					team = "main"
-				}
+			if m.awaitingAuth() {
+				command := m.loginCommand()
 				
-				command := fmt.Sprintf("fly -t %s login -c %s -n %s", name, url, team)
-				
-				// Try to copy to clipboard using pbcopy on macOS
-				copyCmd := exec.Command("pbcopy")
-				copyCmd.Stdin = strings.NewReader(command)
-				err := copyCmd.Run()
+				err := copyToClipboard(m.clipboardCommand, command)
 				
 				if err == nil {
-					// Update the result to show command was copied
-					m.saveResult = fmt.Sprintf("Interactive authentication required.\n\n✅ Command copied to clipboard!\n\nTo complete target creation:\n\n1. Open a new terminal window\n2. Paste and run the command (Cmd+V)\n3. Complete browser authentication  \n4. Press 'r' here to retry checking the target\n\nCommand: fly -t %s login -c %s -n %s", name, url, team)
-				}
-			} else {
-				// If we're in input mode and not showing auth error, treat 'c' as regular text input
-				if m.focused < len(m.values) && !m.saving && m.saveResult == "" {
-					m.values[m.focused] += "c"
+					// Update the prompt to show command was copied
+					m.authPrompt = fmt.Sprintf("Interactive authentication required.\n\n✅ Command copied to clipboard!\n\nTo complete target creation:\n\n1. Open a new terminal window\n2. Paste and run the command\n3. Complete browser authentication  \n4. Press 'r' here to retry checking the target\n\nCommand: %s", command)
 				}
+				return m, nil
 			}
-			return m, nil
 		case "esc":
-			return m, func() tea.Msg {
-				return SwitchViewMsg{View: ViewTargets}
-			}
-		case "ctrl+c":
-			// Allow copying - handled by terminal
-			return m, nil
-		case "ctrl+v":
-			// Paste is handled by terminal and comes as regular text input
-			return m, nil
-		default:
-			// Handle text input for the focused field
-			if m.focused < len(m.values) && !m.saving && m.saveResult == "" {
-				switch msg.String() {
-				case "backspace":
-					if len(m.values[m.focused]) > 0 {
-						m.values[m.focused] = m.values[m.focused][:len(m.values[m.focused])-1]
-					}
-				case "ctrl+a":
-					// Select all - not implemented but don't add as text
-				case "ctrl+u":
-					// Clear line
-					m.values[m.focused] = ""
-				default:
-					// Handle multi-character input (paste) and regular typing
-					if msg.String() != "" {
-						// Handle bracketed paste - remove the brackets if present
-						text := msg.String()
-						
-						// Check for bracketed paste sequences
-						if strings.HasPrefix(text, "[") && strings.HasSuffix(text, "]") && len(text) > 2 {
-							// Remove brackets from pasted content
-							text = text[1 : len(text)-1]
-						}
-						
-						// Filter out non-printable characters except spaces and common URL/name chars
-						cleaned := ""
-						for _, r := range text {
-							if (r >= 32 && r <= 126) || r == '\t' {
-								if r == '\t' {
-									// Convert tab to nothing in input
-									continue
-								}
-								cleaned += string(r)
-							}
-						}
-						if cleaned != "" {
-							m.values[m.focused] += cleaned
-						}
-					}
-				}
-			}
+			return m, navigateBack()
+		}
+		
+		// Everything else edits the focused field
+		if m.editing() && !m.onInsecureToggle() {
+			var cmd tea.Cmd
+			m.inputs[m.focused], cmd = m.inputs[m.focused].Update(msg)
+			return m, cmd
 		}
+		return m, nil
+	case TargetReachableMsg:
+		return m, m.login(msg)
 	case TargetCreateMsg:
 		m.saving = false
 		if msg.Error != nil {
 			m.err = msg.Error
-			m.saveResult = ""
 		} else if msg.Success {
-			m.saveResult = fmt.Sprintf("✓ Target created successfully: %s", msg.Output)
 			m.err = nil
-			// After successful creation, go back to targets view after a short delay
-			return m, tea.Tick(2*time.Second, func(time.Time) tea.Msg {
-				return SwitchViewMsg{View: ViewTargets}
-			})
+			// After successful creation, go back to targets view
+			return m, tea.Batch(
+				showToast(ToastSuccess, "%s", msg.Output),
+				func() tea.Msg {
+					return NavigateBackMsg{From: ViewAddTarget}
+				},
+			)
 		} else {
 			m.err = fmt.Errorf("Failed to create target: %s", msg.Output)
-			m.saveResult = ""
 		}
+	default:
+		// Cursor blinking and other input messages
+		if m.onInsecureToggle() {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.inputs[m.focused], cmd = m.inputs[m.focused].Update(msg)
+		return m, cmd
 	}
 	
 	return m, nil
@@ -228,8 +313,8 @@ func (m AddTargetViewModel) Update(msg tea.Msg) (AddTargetViewModel, tea.Cmd) {
 
 // canSubmit checks if the form can be submitted
 func (m AddTargetViewModel) canSubmit() bool {
-	for _, value := range m.values {
-		if strings.TrimSpace(value) == "" {
+	for i := 0; i < requiredFields; i++ {
+		if m.value(i) == "" {
 			return false
 		}
 	}
@@ -243,28 +328,50 @@ func (m AddTargetViewModel) startSave() (AddTargetViewModel, tea.Cmd) {
 	}
 	
 	// Prepare values
-	name := strings.TrimSpace(m.values[0])
-	url := strings.TrimSpace(m.values[1])
-	team := strings.TrimSpace(m.values[2])
+	name := m.value(0)
+	url := m.value(1)
+	team := m.value(2)
 	
 	// Default team to "main" if empty
 	if team == "" {
 		team = "main"
 	}
 	
+	if err := m.useInheritedCACert(); err != nil {
+		m.err = err
+		return m, nil
+	}
+	if err := m.validateTLS(); err != nil {
+		m.err = err
+		return m, nil
+	}
+	
+	if m.original != "" {
+		// Editing saves through the targets view, which offers a new login
+		update := UpdateTargetMsg{Original: m.original, Name: name, URL: url, Team: team, TLS: m.tlsOptions()}
+		return m, tea.Batch(
+			func() tea.Msg {
+				return update
+			},
+			func() tea.Msg {
+				return NavigateBackMsg{From: ViewAddTarget}
+			},
+		)
+	}
+	
 	// Generate fly command
-	m.flyCommand = fmt.Sprintf("fly -t %s login -c %s -n %s", name, url, team)
+	tls := m.tlsOptions()
+	command := m.loginCommand()
+	m.flyCommand = command
 	m.saving = true
 	m.err = nil
-	m.saveResult = ""
+	m.authPrompt = ""
 	
 	// Execute the fly command
 	return m, func() tea.Msg {
 		// First, check if the target already exists and is authenticated
-		checkCmd := exec.Command("fly", "-t", name, "status")
-		checkOutput, checkErr := checkCmd.CombinedOutput()
-		
-		if checkErr == nil && strings.Contains(string(checkOutput), "logged in successfully") {
+		client := concourse.NewClient(name)
+		if loggedIn, _ := client.Status(); loggedIn {
 			// Target already exists and is logged in
 			return TargetCreateMsg{
 				Success: true,
@@ -274,37 +381,41 @@ func (m AddTargetViewModel) startSave() (AddTargetViewModel, tea.Cmd) {
 			}
 		}
 		
-		// Perform interactive login using the same approach as the auth view
-		args := []string{"login", "-c", url}
-		if team != "" {
-			args = append(args, "-n", team)
+		// Make sure the URL is a Concourse before fly tries to log in to it
+		if _, err := concourse.FetchInfo(url, tls); err != nil {
+			return TargetCreateMsg{
+				Success: false,
+				Output:  fmt.Sprintf("Failed to create target: %s", err.Error()),
+				Error:   err,
+				Command: command,
+			}
 		}
-		args = append([]string{"-t", name}, args...)
-		
-		// Execute interactively (this will open browser) - same as LoginInteractive in client.go
-		cmd := exec.Command("fly", args...)
-		cmd.Stdin = os.Stdin
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
 		
-		err := cmd.Run()
+		return TargetReachableMsg{Name: name, URL: url, Team: team, TLS: tls}
+	}
+}
+
+// login runs fly login for the new target. fly prints the login URL and may
+// ask for a token, so FlyBy steps aside until it's done, like the auth view.
+func (m AddTargetViewModel) login(msg TargetReachableMsg) tea.Cmd {
+	command := m.flyCommand
+	login := concourse.NewClient(msg.Name).LoginInteractiveTLS(msg.URL, msg.Team, msg.TLS)
+	return tea.Exec(login, func(err error) tea.Msg {
 		if err != nil {
 			return TargetCreateMsg{
 				Success: false,
 				Output:  fmt.Sprintf("Failed to create target: %s", err.Error()),
 				Error:   err,
-				Command: fmt.Sprintf("fly -t %s login -c %s -n %s", name, url, team),
+				Command: command,
 			}
 		}
-		
-		// Login succeeded
 		return TargetCreateMsg{
 			Success: true,
-			Output:  fmt.Sprintf("Target '%s' created successfully!", name),
+			Output:  fmt.Sprintf("Target '%s' created successfully!", msg.Name),
 			Error:   nil,
-			Command: fmt.Sprintf("fly -t %s login -c %s -n %s", name, url, team),
+			Command: command,
 		}
-	}
+	})
 }
 
 // submit submits the form (old method - kept for compatibility)
@@ -317,19 +428,75 @@ func (m AddTargetViewModel) submit() tea.Cmd {
 
 // Reset resets the form
 func (m *AddTargetViewModel) Reset() {
-	m.values = []string{"", "", ""}
+	m.inputs = newTargetInputs()
 	m.focused = 0
+	m.insecure = false
+	m.original = ""
+	m.duplicateOf = ""
+	m.inheritedCACert = ""
 	m.submitted = false
 	m.err = nil
 	m.saving = false
 	m.flyCommand = ""
-	m.saveResult = ""
+	m.authPrompt = ""
+}
+
+// Edit fills the form with an existing target to change it
+func (m *AddTargetViewModel) Edit(target config.Target) {
+	m.Reset()
+	m.original = target.Name
+	m.insecure = target.Insecure
+	for i, value := range []string{target.Name, target.GetURL(), target.Team} {
+		m.inputs[i].SetValue(value)
+	}
+	for i := requiredFields; i < len(m.inputs); i++ {
+		m.inputs[i].Placeholder = "optional, leave empty to keep the current one"
+	}
+}
+
+// Duplicate fills the form with an existing target's URL and TLS settings,
+// leaving the name and team for a new target against the same Concourse
+func (m *AddTargetViewModel) Duplicate(target config.Target) {
+	m.Reset()
+	m.duplicateOf = target.Name
+	m.insecure = target.Insecure
+	m.inheritedCACert = target.CACert
+	m.inputs[1].SetValue(target.GetURL())
+	m.inputs[4].SetValue(target.ClientCert)
+	m.inputs[5].SetValue(target.ClientKey)
+	if target.CACert != "" {
+		m.inputs[3].Placeholder = fmt.Sprintf("optional, leave empty to use %s's", target.Name)
+	}
+}
+
+// useInheritedCACert writes the CA cert copied from another target to a file
+// fly can read, unless a CA cert path was given. The file is named after the
+// new target in the cache directory, so saving again reuses it.
+func (m *AddTargetViewModel) useInheritedCACert() error {
+	if m.inheritedCACert == "" || m.value(3) != "" {
+		return nil
+	}
+	
+	cacheDir, err := config.CacheDir()
+	if err != nil {
+		return fmt.Errorf("failed to copy %s's CA cert: %w", m.duplicateOf, err)
+	}
+	dir := filepath.Join(cacheDir, "ca-certs")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to copy %s's CA cert: %w", m.duplicateOf, err)
+	}
+	path := filepath.Join(dir, filepath.Base(m.value(0))+".pem")
+	if err := os.WriteFile(path, []byte(m.inheritedCACert), 0600); err != nil {
+		return fmt.Errorf("failed to copy %s's CA cert: %w", m.duplicateOf, err)
+	}
+	m.inputs[3].SetValue(path)
+	return nil
 }
 
 // View renders the add target view
 func (m AddTargetViewModel) View(width, height int) string {
 	titleStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("205")).
+		Foreground(theme.Primary).
 		Bold(true).
 		MarginBottom(2)
 	
@@ -339,145 +506,107 @@ func (m AddTargetViewModel) View(width, height int) string {
 		
 	inputStyle := lipgloss.NewStyle().
 		Border(lipgloss.NormalBorder()).
-		BorderForeground(lipgloss.Color("240")).
+		BorderForeground(theme.Muted).
 		Padding(0, 1).
 		Width(40)
 		
 	focusedInputStyle := inputStyle.Copy().
-		BorderForeground(lipgloss.Color("205"))
+		BorderForeground(theme.Primary)
 	
 	var content strings.Builder
-	content.WriteString(titleStyle.Render("Add New Target"))
+	if m.original != "" {
+		content.WriteString(titleStyle.Render("Edit Target " + m.original))
+	} else if m.duplicateOf != "" {
+		content.WriteString(titleStyle.Render("Add New Target Like " + m.duplicateOf))
+	} else {
+		content.WriteString(titleStyle.Render("Add New Target"))
+	}
 	content.WriteString("\n")
 	
 	for i, field := range m.fields {
 		content.WriteString(labelStyle.Render(field + ":"))
 		
 		var inputBox string
-		value := m.values[i]
-		placeholder := ""
-		
-		// Add placeholders
-		switch field {
-		case "Name":
-			if value == "" {
-				placeholder = "e.g., production"
-			}
-		case "URL":
-			if value == "" {
-				placeholder = "e.g., https://ci.example.com"
-			}
-		case "Team":
-			if value == "" {
-				placeholder = "e.g., main (default: main)"
-			}
-		}
-		
-		displayValue := value
-		if displayValue == "" && placeholder != "" {
-			displayValue = placeholder
-		}
-		
 		if i == m.focused && !m.saving {
-			// Show cursor
-			if value == "" && placeholder != "" {
-				inputBox = focusedInputStyle.Render(placeholder + "█")
-			} else {
-				inputBox = focusedInputStyle.Render(value + "█")
-			}
+			inputBox = focusedInputStyle.Render(m.inputs[i].View())
 		} else {
-			if value == "" && placeholder != "" {
-				placeholderStyle := inputStyle.Copy().Foreground(lipgloss.Color("240"))
-				inputBox = placeholderStyle.Render(placeholder)
-			} else {
-				inputBox = inputStyle.Render(value)
-			}
+			inputBox = inputStyle.Render(m.inputs[i].View())
 		}
 		
 		content.WriteString(inputBox)
 		content.WriteString("\n\n")
 	}
 	
+	// The insecure toggle follows the text fields
+	checkbox := "[ ]"
+	if m.insecure {
+		checkbox = "[x]"
+	}
+	toggle := checkbox + " Skip TLS verification (insecure)"
+	if m.onInsecureToggle() && !m.saving {
+		toggle = lipgloss.NewStyle().Foreground(theme.Primary).Bold(true).Render(toggle + "  (space to toggle)")
+	}
+	content.WriteString(toggle)
+	content.WriteString("\n\n")
+	
 	// Show fly command if saving or saved
 	if m.saving || m.flyCommand != "" {
 		commandStyle := lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("33")).
+			BorderForeground(theme.Info).
 			Padding(1).
 			MarginBottom(1)
 		
 		if m.saving {
-			content.WriteString(commandStyle.Render("🔄 Executing: " + m.flyCommand))
+			content.WriteString(commandStyle.Render(m.loader.View("Executing: " + m.flyCommand)))
 		} else if m.flyCommand != "" {
 			content.WriteString(commandStyle.Render("📝 Command executed: " + m.flyCommand))
 		}
 		content.WriteString("\n")
 	}
 	
-	// Show save result
-	if m.saveResult != "" {
-		// Check if this is an interactive authentication message
-		if strings.Contains(m.saveResult, "Interactive authentication required") {
-			// Show interactive auth message
-			authStyle := lipgloss.NewStyle().
-				Border(lipgloss.RoundedBorder()).
-				BorderForeground(lipgloss.Color("220")).
-				Padding(1).
-				MarginBottom(1).
-				Foreground(lipgloss.Color("220"))
-			
-			content.WriteString(authStyle.Render("🔐 " + m.saveResult))
-			content.WriteString("\n")
-			
-			helpStyle := lipgloss.NewStyle().
-				Foreground(lipgloss.Color("240")).
-				Italic(true)
-			content.WriteString(helpStyle.Render("Press Esc to return to targets view"))
-		} else if strings.Contains(m.saveResult, "already exists") {
-			// Show success message for existing target
-			successStyle := lipgloss.NewStyle().
-				Foreground(lipgloss.Color("46")).
-				Bold(true).
-				MarginBottom(1)
-			content.WriteString(successStyle.Render("✅ " + m.saveResult))
-			content.WriteString("\n")
-			content.WriteString("Returning to targets view...\n")
-		} else {
-			// Show regular result
-			resultStyle := lipgloss.NewStyle().
-				Foreground(lipgloss.Color("46")).
-				Bold(true).
-				MarginBottom(1)
-			content.WriteString(resultStyle.Render(m.saveResult))
-			content.WriteString("\n")
-			content.WriteString("Returning to targets view...\n")
-		}
+	// Show the login instructions while waiting on an external fly login
+	if m.authPrompt != "" {
+		authStyle := lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(theme.Warning).
+			Padding(1).
+			MarginBottom(1).
+			Foreground(theme.Warning)
+		
+		content.WriteString(authStyle.Render("🔐 " + m.authPrompt))
+		content.WriteString("\n")
+		
+		helpStyle := lipgloss.NewStyle().
+			Foreground(theme.Muted).
+			Italic(true)
+		content.WriteString(helpStyle.Render("Press Esc to return to targets view"))
 	}
 	
 	// Show help text
 	helpStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("240")).
+		Foreground(theme.Muted).
 		Italic(true).
 		MarginTop(1)
 	
 	var help string
 	if m.saving {
 		help = "Creating target... Please wait"
-	} else if m.saveResult != "" && strings.Contains(m.saveResult, "Interactive authentication required") {
+	} else if m.awaitingAuth() {
 		help = "Press 'r' to retry checking authentication • 'c' to copy command • Esc: Return to targets"
-	} else if m.saveResult != "" {
-		help = "Enter: Return to targets • Esc: Return to targets"
+	} else if m.original != "" {
+		help = "Tab/Shift+Tab: Navigate • Enter: Save Target • ←/→: Move cursor • Ctrl+U: Clear to start • Esc: Cancel"
 	} else {
-		help = "Tab/Shift+Tab: Navigate • Enter: Create Target • Ctrl+U: Clear field • Esc: Cancel"
+		help = "Tab/Shift+Tab: Navigate • Enter: Create Target • ←/→: Move cursor • Ctrl+U: Clear to start • Esc: Cancel"
 	}
 	content.WriteString(helpStyle.Render(help))
 	
 	if m.err != nil {
 		errorStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("196")).
+			Foreground(theme.Error).
 			MarginTop(1)
 		content.WriteString("\n")
-		content.WriteString(errorStyle.Render("Error: " + m.err.Error()))
+		content.WriteString(errorStyle.Render("Error: " + errorSummary(m.err)))
 	}
 	
 	return content.String()
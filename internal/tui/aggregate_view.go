@@ -0,0 +1,403 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"flyby/internal/concourse"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// aggregateRow is a pipeline of one of the aggregated targets
+type aggregateRow struct {
+	target   string
+	pipeline concourse.Pipeline
+}
+
+// AggregateViewModel lists the pipelines of several targets in one list,
+// each tagged with its target, so a pipeline deployed to several
+// environments shows up once per environment next to each other
+type AggregateViewModel struct {
+	targets      []string
+	requested    []string                     // the targets to list next, set when switching to the view
+	clients      map[string]*concourse.Client // by target, created on demand
+	load         int                          // tells results of the current load from older ones
+	listing      int                          // targets whose pipelines are still being listed
+	rows         []aggregateRow
+	filteredRows []aggregateRow
+	targetErrors map[string]error
+	selected     int
+	scrollOffset int
+	maxVisible   int
+	searchQuery  string
+	searchMode   bool
+	clicks       clickTracker
+	loader       loader
+}
+
+// AggregatePipelinesMsg represents the listed pipelines of one aggregated
+// target
+type AggregatePipelinesMsg struct {
+	Load      int
+	Target    string
+	Pipelines []concourse.Pipeline
+	Error     error
+}
+
+// NewAggregateViewModel creates a new aggregated pipelines model
+func NewAggregateViewModel() AggregateViewModel {
+	return AggregateViewModel{
+		clients:    make(map[string]*concourse.Client),
+		maxVisible: 10,
+		loader:     newLoader(),
+	}
+}
+
+// client returns the client of an aggregated target, creating it the first
+// time the target is listed
+func (m *AggregateViewModel) client(target string) *concourse.Client {
+	client, ok := m.clients[target]
+	if !ok {
+		client = concourse.NewClient(target)
+		m.clients[target] = client
+	}
+	return client
+}
+
+// Open lists the pipelines of the requested targets, unless they are the
+// ones already listed on an earlier visit
+func (m *AggregateViewModel) Open() tea.Cmd {
+	if m.requested != nil && strings.Join(m.requested, ",") != strings.Join(m.targets, ",") {
+		m.targets = m.requested
+		m.selected = 0
+		m.scrollOffset = 0
+		m.searchQuery = ""
+		m.rows = nil
+	} else if m.rows != nil && !m.canceled() {
+		m.requested = nil
+		return nil
+	}
+	m.requested = nil
+	return m.Load()
+}
+
+// Load lists the pipelines of every aggregated target at once
+func (m *AggregateViewModel) Load() tea.Cmd {
+	m.load++
+	m.listing = len(m.targets)
+	m.targetErrors = make(map[string]error)
+	m.rows = []aggregateRow{}
+	m.filterRows()
+
+	load := m.load
+	var cmds []tea.Cmd
+	for _, target := range m.targets {
+		target, client := target, m.client(target)
+		cmds = append(cmds, func() tea.Msg {
+			pipelines, err := client.GetPipelines(false)
+			return AggregatePipelinesMsg{Load: load, Target: target, Pipelines: pipelines, Error: err}
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
+// canceled returns true when listing a target was cut short by leaving the
+// view
+func (m AggregateViewModel) canceled() bool {
+	for _, err := range m.targetErrors {
+		if concourse.IsCanceled(err) {
+			return true
+		}
+	}
+	return m.listing > 0
+}
+
+// HandleAggregatePipelines adds the pipelines of a target to the list
+func (m AggregateViewModel) HandleAggregatePipelines(msg AggregatePipelinesMsg) AggregateViewModel {
+	if msg.Load != m.load {
+		return m
+	}
+	m.listing--
+	if msg.Error != nil {
+		m.targetErrors[msg.Target] = msg.Error
+		return m
+	}
+
+	var selected aggregateRow
+	if m.selected < len(m.filteredRows) {
+		selected = m.filteredRows[m.selected]
+	}
+	for _, pipeline := range msg.Pipelines {
+		m.rows = append(m.rows, aggregateRow{target: msg.Target, pipeline: pipeline})
+	}
+	// The same pipeline of every target together, targets in the order given
+	order := make(map[string]int)
+	for i, target := range m.targets {
+		order[target] = i
+	}
+	sort.SliceStable(m.rows, func(i, j int) bool {
+		a, b := m.rows[i], m.rows[j]
+		if a.pipeline.Ref() != b.pipeline.Ref() {
+			return a.pipeline.Ref() < b.pipeline.Ref()
+		}
+		return order[a.target] < order[b.target]
+	})
+	m.filterRows()
+	for i, row := range m.filteredRows {
+		if row.target == selected.target && row.pipeline.Ref() == selected.pipeline.Ref() {
+			m.selected = i
+		}
+	}
+	return m
+}
+
+// filterRows filters the pipelines based on the current search query
+func (m *AggregateViewModel) filterRows() {
+	query := strings.ToLower(m.searchQuery)
+	m.filteredRows = nil
+	for _, row := range m.rows {
+		if query == "" ||
+			strings.Contains(strings.ToLower(row.pipeline.Ref()), query) ||
+			strings.Contains(strings.ToLower(row.target), query) ||
+			strings.Contains(strings.ToLower(row.pipeline.TeamName), query) {
+			m.filteredRows = append(m.filteredRows, row)
+		}
+	}
+	if m.selected >= len(m.filteredRows) {
+		m.selected = 0
+		m.scrollOffset = 0
+	}
+}
+
+// Update handles key presses for the aggregated pipelines
+func (m AggregateViewModel) Update(msg tea.KeyMsg) (AggregateViewModel, tea.Cmd) {
+	if m.searchMode {
+		switch msg.String() {
+		case "enter":
+			m.searchMode = false
+		case "esc":
+			m.searchMode = false
+			m.searchQuery = ""
+			m.filterRows()
+		case "backspace":
+			if len(m.searchQuery) > 0 {
+				m.searchQuery = m.searchQuery[:len(m.searchQuery)-1]
+				m.filterRows()
+			}
+		case "ctrl+u":
+			m.searchQuery = ""
+			m.filterRows()
+		default:
+			if len(msg.String()) == 1 {
+				m.searchQuery += msg.String()
+				m.filterRows()
+			}
+		}
+		return m, nil
+	}
+
+	switch {
+	case keys.Matches(msg, actionUp):
+		if m.selected > 0 {
+			m.selected--
+			if m.selected < m.scrollOffset {
+				m.scrollOffset = m.selected
+			}
+		}
+	case keys.Matches(msg, actionJobs):
+		// Checked before down so the default "j" opens jobs here
+		if m.selected < len(m.filteredRows) {
+			row := m.filteredRows[m.selected]
+			return m, func() tea.Msg {
+				return SwitchViewMsg{View: ViewJobs, Target: row.target, Pipeline: row.pipeline.Ref()}
+			}
+		}
+	case keys.Matches(msg, actionDown):
+		if m.selected < len(m.filteredRows)-1 {
+			m.selected++
+			if m.selected >= m.scrollOffset+m.maxVisible {
+				m.scrollOffset = m.selected - m.maxVisible + 1
+			}
+		}
+	case keys.Matches(msg, actionResources):
+		if m.selected < len(m.filteredRows) {
+			row := m.filteredRows[m.selected]
+			return m, func() tea.Msg {
+				return SwitchViewMsg{View: ViewResources, Target: row.target, Pipeline: row.pipeline.Ref()}
+			}
+		}
+	case keys.Matches(msg, actionSearch):
+		m.searchMode = true
+	case keys.Matches(msg, actionRefresh):
+		cmd := m.Load()
+		return m, cmd
+	}
+	return m, nil
+}
+
+// visibleRange returns the range of pipelines shown for the given height
+func (m AggregateViewModel) visibleRange(height int) (int, int) {
+	maxVisible := m.maxVisible
+	if height-searchListTop-5 > 0 { // Account for indicators, summary and help
+		maxVisible = height - searchListTop - 5
+	}
+
+	start := m.scrollOffset
+	if m.selected >= start+maxVisible {
+		start = m.selected - maxVisible + 1
+	}
+	if m.selected < start {
+		start = m.selected
+	}
+	return start, min(start+maxVisible, len(m.filteredRows))
+}
+
+// Mouse handles clicks and scrolling over the aggregated pipelines
+func (m AggregateViewModel) Mouse(msg tea.MouseMsg, height int) (AggregateViewModel, tea.Cmd) {
+	if m.searchMode {
+		return m, nil
+	}
+
+	start, end := m.visibleRange(height)
+	rows := listRows{top: searchListTop, height: 1, first: start, end: end}
+	if start > 0 {
+		rows.top++ // "more above" indicator
+	}
+
+	switch intent, index := m.clicks.interpret(msg, rows); intent {
+	case mouseScrollUp:
+		return m.Update(keyMsgFor(actionUp))
+	case mouseScrollDown:
+		return m.Update(keyMsgFor(actionDown))
+	case mouseClick:
+		m.selected = index
+	case mouseDoubleClick:
+		m.selected = index
+		return m.Update(keyMsgFor(actionJobs))
+	}
+	return m, nil
+}
+
+// View renders the aggregated pipelines
+func (m AggregateViewModel) View(width, height int) string {
+	titleStyle := lipgloss.NewStyle().
+		Foreground(theme.Primary).
+		Bold(true).
+		MarginBottom(1)
+
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(theme.Muted)
+
+	itemStyle := lipgloss.NewStyle().
+		PaddingLeft(2)
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(theme.Primary).
+		Bold(true).
+		PaddingLeft(1).
+		Border(lipgloss.NormalBorder(), false, false, false, true).
+		BorderForeground(theme.Primary)
+
+	searchStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Muted).
+		Padding(0, 1).
+		MarginBottom(1)
+
+	searchActiveStyle := searchStyle.Copy().
+		BorderForeground(theme.Primary)
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(theme.Muted).
+		Italic(true).
+		MarginTop(1)
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render("Pipelines - " + strings.Join(m.targets, ", ")))
+	content.WriteString("\n\n")
+
+	searchText := m.searchQuery
+	switch {
+	case m.searchMode:
+		content.WriteString(searchActiveStyle.Render("Search: " + searchText + "█"))
+	case searchText != "":
+		content.WriteString(searchStyle.Render("Search: " + searchText))
+	default:
+		content.WriteString(searchStyle.Render("Search: (/,s to search)"))
+	}
+	content.WriteString("\n\n")
+
+	switch {
+	case len(m.targets) == 0:
+		content.WriteString("No logged in targets to list the pipelines of.\n")
+	case len(m.filteredRows) == 0 && m.listing == 0 && m.searchQuery != "":
+		content.WriteString("No pipelines match search query.\n")
+	case len(m.filteredRows) == 0 && m.listing == 0:
+		content.WriteString("No pipelines found.\n")
+	default:
+		targetWidth := 0
+		for _, target := range m.targets {
+			targetWidth = max(targetWidth, len(target))
+		}
+		start, end := m.visibleRange(height)
+		if start > 0 {
+			content.WriteString(itemStyle.Render(fmt.Sprintf("  ↑ (%d more above)", start)))
+			content.WriteString("\n")
+		}
+		for i := start; i < end; i++ {
+			row := m.filteredRows[i]
+			line := lipgloss.NewStyle().Foreground(theme.Info).Render(fmt.Sprintf("%-*s", targetWidth, row.target)) + "  "
+			if row.pipeline.TeamName != "" {
+				line += mutedStyle.Render(row.pipeline.TeamName + " › ")
+			}
+			line += row.pipeline.Name
+			if len(row.pipeline.InstanceVars) > 0 {
+				line += " " + mutedStyle.Render(row.pipeline.InstanceVars.String())
+			}
+			if row.pipeline.Paused {
+				line += " [PAUSED]"
+			}
+			if i == m.selected {
+				content.WriteString(selectedStyle.Render("> " + line))
+			} else {
+				content.WriteString(itemStyle.Render("  " + line))
+			}
+			content.WriteString("\n")
+		}
+		if end < len(m.filteredRows) {
+			content.WriteString(itemStyle.Render(fmt.Sprintf("  ↓ (%d more below)", len(m.filteredRows)-end)))
+			content.WriteString("\n")
+		}
+	}
+
+	summary := fmt.Sprintf("%d pipelines on %d targets", len(m.rows), len(m.targets)-len(m.targetErrors))
+	var failed []string
+	for _, target := range m.targets {
+		if err, ok := m.targetErrors[target]; ok {
+			failed = append(failed, fmt.Sprintf("%s (%s)", target, errorSummary(err)))
+		}
+	}
+	if len(failed) > 0 {
+		summary += ", failed to list: " + strings.Join(failed, ", ")
+	}
+	content.WriteString("\n")
+	if m.listing > 0 {
+		content.WriteString(m.loader.View(fmt.Sprintf("Listing pipelines, %d of %d targets done", len(m.targets)-m.listing, len(m.targets))))
+	} else {
+		content.WriteString(mutedStyle.Render(summary))
+	}
+	content.WriteString("\n")
+
+	var help string
+	if m.searchMode {
+		help = "Enter: finish search • Esc: cancel search • Ctrl+U: clear"
+	} else {
+		help = keys.HelpLine(viewKeys[ViewAggregate]...)
+	}
+	content.WriteString(helpStyle.Render(help))
+
+	return content.String()
+}
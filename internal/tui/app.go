@@ -3,10 +3,15 @@ package tui
 import (
 	"fmt"
 	"strings"
+	"sync"
 
 	"flyby/internal/config"
 	"flyby/internal/concourse"
+	"flyby/internal/state"
 
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -23,6 +28,18 @@ const (
 	ViewBuilds
 	ViewAddTarget
 	ViewAuth
+	ViewWatchlist
+	ViewHistory
+	ViewSync
+	ViewTeams
+	ViewActiveUsers
+	ViewGraph
+	ViewFlaky
+	ViewBuildLog
+	ViewDashboard
+	ViewConfig
+	ViewSearch
+	ViewAggregate
 )
 
 // Model represents the main TUI model
@@ -31,152 +48,514 @@ type Model struct {
 	width, height int
 	
 	// Components
-	mainView      MainViewModel
-	targetsView   TargetsViewModel  
-	pipelinesView PipelinesViewModel
-	jobsView      JobsViewModel
-	resourcesView ResourcesViewModel
-	buildsView    BuildsViewModel
-	addTargetView AddTargetViewModel
-	authView      AuthViewModel
+	mainView        MainViewModel
+	targetsView     TargetsViewModel  
+	pipelinesView   PipelinesViewModel
+	jobsView        JobsViewModel
+	resourcesView   ResourcesViewModel
+	buildsView      BuildsViewModel
+	addTargetView   AddTargetViewModel
+	authView        AuthViewModel
+	watchlistView   WatchlistViewModel
+	historyView     HistoryViewModel
+	syncView        SyncViewModel
+	teamsView       TeamsViewModel
+	activeUsersView ActiveUsersViewModel
+	graphView       GraphViewModel
+	flakyView       FlakyViewModel
+	buildLogView    BuildLogViewModel
+	dashboardView   DashboardViewModel
+	configView      ConfigViewModel
+	searchView      SearchViewModel
+	aggregateView   AggregateViewModel
+	notifier        notifier
+	prefetch        prefetcher
 	
 	// Dependencies
 	configManager *config.ConfigManager
+	settings      *config.Settings
+	stateStore    *state.Store
 	client        *concourse.Client
 	
 	// State
-	currentTarget string
-	err           error
+	currentTarget   string
+	currentPipeline string
+	currentJob      string
+	currentBuild    string
+	navStack        []navEntry
+	start           *SwitchViewMsg
+	session         *state.Session // where FlyBy last quit, offered to return to on startup
+	clusterInfo     map[string]concourse.Info // by target, for the header
+	userInfo        map[string]concourse.UserInfo // by target, for the header
+	err             error
+	
+	// Notifications
+	toasts      []toast
+	nextToastID int
+	confirm     *ConfirmMsg
+	errorModal  *errorModal
+	help        *viewport.Model
 }
 
 // App represents the TUI application
 type App struct {
-	model *Model
+	model    *Model
+	settings *config.Settings
+}
+
+// demoTargets are the targets shown in demo mode
+var demoTargets = map[string]config.Target{
+	"production": {API: "https://ci.example.com", Team: "main", Token: &config.Token{Type: "bearer", Value: "demo"}},
+	"staging":    {API: "https://ci-staging.example.com", Team: "main", Token: &config.Token{Type: "bearer", Value: "demo"}},
+	"sandbox":    {API: "https://ci-sandbox.example.com", Team: "platform", Token: &config.Token{Type: "bearer", Value: "demo"}},
 }
 
 // NewApp creates a new TUI application
-func NewApp() *App {
-	return &App{}
+func NewApp(settings *config.Settings) *App {
+	if settings == nil {
+		settings = config.DefaultSettings()
+	}
+	return &App{settings: settings}
 }
 
 // Run starts the TUI application
 func (a *App) Run() error {
-	configManager, err := config.NewConfigManager()
-	if err != nil {
-		return fmt.Errorf("failed to initialize config manager: %w", err)
+	if err := ApplyKeyBindings(a.settings.Keys); err != nil {
+		return fmt.Errorf("invalid key bindings in %s: %w", a.settings.GetPath(), err)
+	}
+	if err := ApplyCustomCommands(a.settings.Commands); err != nil {
+		return fmt.Errorf("invalid commands in %s: %w", a.settings.GetPath(), err)
+	}
+	if err := SetTheme(a.settings.Theme); err != nil {
+		return fmt.Errorf("invalid theme in %s: %w", a.settings.GetPath(), err)
+	}
+	if a.settings.NoColor || a.settings.Plain {
+		DisableColors()
+	}
+	SetASCIIOnly(a.settings.Plain)
+	concourse.SetCommandTimeouts(a.settings.GetCommandTimeouts())
+	concourse.SetRetries(a.settings.Retries)
+	config.SetFlyrcBackups(a.settings.FlyrcBackups)
+	if path := a.settings.GetHistoryLogPath(); path != "" {
+		if err := concourse.SetHistoryLog(path); err != nil {
+			return err
+		}
+	}
+	
+	var configManager *config.ConfigManager
+	var stateStore *state.Store
+	if a.settings.Demo {
+		// Keep the user's ~/.flyrc and FlyBy state untouched
+		concourse.SetDefaultExecutor(concourse.NewDemoExecutor())
+		configManager = config.NewMemoryConfigManager(demoTargets)
+		stateStore = state.NewMemoryStore()
+	} else {
+		var err error
+		configManager, err = config.NewConfigManager()
+		if err != nil {
+			return fmt.Errorf("failed to initialize config manager: %w", err)
+		}
+		
+		stateStore, err = state.NewStore()
+		if err != nil {
+			return fmt.Errorf("failed to initialize state store: %w", err)
+		}
+	}
+	if err := ApplyTargetGroups(a.settings.Groups, stateStore.GetGroup()); err != nil {
+		return fmt.Errorf("invalid groups in %s: %w", a.settings.GetPath(), err)
 	}
 	
 	model := &Model{
 		currentView:   ViewMain,
 		configManager: configManager,
+		settings:      a.settings,
+		stateStore:    stateStore,
+		clusterInfo:   make(map[string]concourse.Info),
+		userInfo:      make(map[string]concourse.UserInfo),
 	}
 	
 	// Initialize sub-models
-	model.mainView = NewMainViewModel()
-	model.targetsView = NewTargetsViewModel(configManager)
-	model.pipelinesView = NewPipelinesViewModel()
-	model.jobsView = NewJobsViewModel()
+	model.mainView = NewMainViewModel(stateStore)
+	model.targetsView = NewTargetsViewModel(configManager, a.settings.ClipboardCommand)
+	model.pipelinesView = NewPipelinesViewModel(stateStore, a.settings.ShowArchived)
+	model.jobsView = NewJobsViewModel(stateStore)
 	model.resourcesView = NewResourcesViewModel()
-	model.buildsView = NewBuildsViewModel(nil) // Client will be set when switching views
-	model.addTargetView = NewAddTargetViewModel()
-	model.authView = NewAuthViewModel()
+	model.buildsView = NewBuildsViewModel(nil, a.settings.BuildsCount) // Client will be set when switching views
+	model.addTargetView = NewAddTargetViewModel(a.settings.ClipboardCommand)
+	model.authView = NewAuthViewModel(a.settings.TerminalCommand)
+	model.watchlistView = NewWatchlistViewModel(stateStore, a.settings.GetRefreshInterval())
+	model.historyView = NewHistoryViewModel(a.settings.ClipboardCommand)
+	model.syncView = NewSyncViewModel()
+	model.teamsView = NewTeamsViewModel()
+	model.activeUsersView = NewActiveUsersViewModel()
+	model.graphView = NewGraphViewModel()
+	model.flakyView = NewFlakyViewModel()
+	model.buildLogView = NewBuildLogViewModel()
+	model.dashboardView = NewDashboardViewModel(stateStore)
+	model.searchView = NewSearchViewModel(configManager)
+	model.aggregateView = NewAggregateViewModel()
+	model.configView = NewConfigViewModel()
+	model.notifier = newNotifier(a.settings.Notifications, a.settings.Bell, a.settings.Hooks.BuildFinished != "")
+	model.prefetch = newPrefetcher()
+	
+	// Start on the requested or default target's pipelines when one is configured
+	startTarget := a.settings.DefaultTarget
+	if a.settings.StartTarget != "" {
+		if _, exists := configManager.GetTarget(a.settings.StartTarget); !exists {
+			names := configManager.GetTargetNames()
+			if len(names) == 0 {
+				return fmt.Errorf("unknown target '%s', no targets are configured", a.settings.StartTarget)
+			}
+			return fmt.Errorf("unknown target '%s', available targets: %s",
+				a.settings.StartTarget, strings.Join(names, ", "))
+		}
+		startTarget = a.settings.StartTarget
+	}
+	if _, exists := configManager.GetTarget(startTarget); exists {
+		// Starting outside of the active group shows all targets for this run
+		if !inActiveGroup(startTarget) {
+			activeGroup = ""
+			model.targetsView.loadTargets()
+		}
+		model.currentView = ViewTargets
+		model.navStack = []navEntry{{View: ViewMain}}
+		model.start = &SwitchViewMsg{View: ViewPipelines, Target: startTarget}
+		
+		// Deep links stack the levels above them, as if the user had navigated down
+		if pipeline := a.settings.StartPipeline; pipeline != "" {
+			model.navStack = append(model.navStack,
+				navEntry{View: ViewTargets},
+				navEntry{View: ViewPipelines, Target: startTarget})
+			model.start = &SwitchViewMsg{View: ViewJobs, Target: startTarget, Pipeline: pipeline, Replace: true}
+			if job := a.settings.StartJob; job != "" {
+				model.navStack = append(model.navStack, navEntry{View: ViewJobs, Target: startTarget, Pipeline: pipeline})
+				model.start.View = ViewBuilds
+				model.start.Job = job
+			}
+		}
+	}
+	
+	// Offer to return to where FlyBy last quit, unless told where to start
+	if session := stateStore.GetSession(); session != nil && a.settings.StartTarget == "" && a.settings.RestoreSession != "never" {
+		if model.start != nil && session.View == "pipelines" && session.Target == startTarget {
+			// Already starting there, just on the pipeline it was on
+			model.pipelinesView.selectNext = session.Selected
+		} else {
+			model.session = session
+		}
+	}
 	
 	a.model = model
 	
-	program := tea.NewProgram(model, tea.WithAltScreen())
-	_, err = program.Run()
+	program := tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseCellMotion())
+	_, err := program.Run()
+	// Don't leave fly running behind, fetching for views that are gone
+	concourse.CancelReads()
+	model.buildLogView.Stop()
+	if err == nil {
+		if saveErr := model.saveSession(); saveErr != nil {
+			return fmt.Errorf("failed to save the session: %w", saveErr)
+		}
+	}
 	return err
 }
 
 // Init initializes the model
 func (m *Model) Init() tea.Cmd {
-	return nil
+	var offer tea.Cmd
+	if m.session != nil {
+		offer = m.offerSession(*m.session)
+	}
+	if m.start != nil {
+		start := *m.start
+		return tea.Batch(func() tea.Msg {
+			return start
+		}, m.notifier.schedule(), watchFlyrc(), offer)
+	}
+	return tea.Batch(m.notifier.schedule(), watchFlyrc(), offer)
 }
 
 // Update handles messages
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	model, cmd := m.update(msg)
+	// Actions fly refused because it is out of sync offer to sync and retry them
+	if m.currentView != ViewSync && m.currentView != ViewAuth {
+		m.interruptForSync(actionError(msg))
+	}
+	// Keep a spinner going for every view that started waiting on fly, track
+	// running builds, and prefetch the pipeline the cursor rests on
+	return model, tea.Batch(cmd, m.spinLoaders(), m.buildsView.Track(m.currentView == ViewBuilds), m.prefetchSelected())
+}
+
+// update handles a single message
+func (m *Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width, m.height = msg.Width, msg.Height
+		if m.help != nil {
+			m.openHelp()
+		}
+		// The detail pane may now show beside the list, with the config preview
+		m.pipelinesView.width = msg.Width
+		return m, m.pipelinesView.LoadPreview()
+		
+	case spinner.TickMsg:
+		return m, m.updateLoaders(msg)
+		
+	case PrefetchTickMsg:
+		return m, m.HandlePrefetchTick(msg)
+		
+	case PrefetchedMsg:
+		m.prefetch.store(msg)
+		return m, nil
+		
+	case BuildsClockMsg:
+		return m, m.buildsView.HandleClock(m.currentView == ViewBuilds)
+		
+	case BuildsPollMsg:
+		return m, m.buildsView.HandlePoll(m.currentView == ViewBuilds)
+		
+	case BuildsPolledMsg:
+		return m, m.buildsView.HandlePolled(msg)
+		
+	case tea.MouseMsg:
+		if m.help != nil {
+			var cmd tea.Cmd
+			*m.help, cmd = m.help.Update(msg)
+			return m, cmd
+		}
+		if m.errorModal != nil {
+			var cmd tea.Cmd
+			m.errorModal.viewport, cmd = m.errorModal.viewport.Update(msg)
+			return m, cmd
+		}
+		if m.confirm != nil {
+			return m, nil
+		}
+		return m.handleMouse(msg)
+		
+	case ToastMsg:
+		return m, m.pushToast(msg)
+		
+	case dismissToastMsg:
+		m.dismissToast(msg.id)
+		return m, nil
+		
+	case ConfirmMsg:
+		m.confirm = &msg
 		return m, nil
 		
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "ctrl+c", "q":
+		switch {
+		case msg.String() == "ctrl+c":
 			return m, tea.Quit
-		case "esc":
-			// Handle hierarchical navigation
-			switch m.currentView {
-			case ViewBuilds:
-				m.currentView = ViewJobs
-				return m, nil
-			case ViewResources:
-				m.currentView = ViewPipelines
-				return m, nil
-			case ViewJobs:
-				m.currentView = ViewPipelines
-				return m, nil
-			case ViewPipelines:
-				m.currentView = ViewTargets
-				return m, nil
-			case ViewAddTarget:
-				m.currentView = ViewTargets
+		case m.confirm != nil:
+			// The confirmation dialog is modal
+			return m.handleConfirmKey(msg)
+		case m.errorModal != nil:
+			return m.handleErrorModalKey(msg)
+		case m.help != nil:
+			return m.handleHelpKey(msg)
+		case keys.Matches(msg, actionQuit):
+			if !m.capturesInput() {
+				return m, tea.Quit
+			}
+		case keys.Matches(msg, actionTheme):
+			cycleTheme()
+			return m, nil
+		case keys.Matches(msg, actionHelp):
+			if !m.capturesInput() {
+				m.openHelp()
 				return m, nil
-			case ViewAuth:
-				m.currentView = ViewTargets
+			}
+		case keys.Matches(msg, actionHistory):
+			if !m.capturesInput() && m.currentView != ViewHistory {
+				return m, func() tea.Msg {
+					return SwitchViewMsg{View: ViewHistory}
+				}
+			}
+		case keys.Matches(msg, actionErrorDetails):
+			if text := m.currentError(); text != "" && !m.capturesInput() {
+				m.openErrorModal(text)
 				return m, nil
-			default:
-				// From main menu or targets, do nothing (stay where we are)
+			}
+		case keys.Matches(msg, actionBack):
+			// Return to wherever we came from, unless the view needs esc itself
+			if !m.capturesInput() {
+				return m, m.popNav()
+			}
+		case keys.Matches(msg, actionRefresh):
+			// A refresh asks fly again rather than reusing its recent output
+			if !m.capturesInput() {
+				concourse.ClearCache()
+			}
+		case keys.Matches(msg, actionOpen):
+			if !m.capturesInput() {
+				if cmd := m.openSelected(); cmd != nil {
+					return m, cmd
+				}
+			}
+		case keys.Matches(msg, actionYank):
+			if !m.capturesInput() {
+				if cmd := m.yankSelected(); cmd != nil {
+					return m, cmd
+				}
+			}
+		case keys.Matches(msg, actionGlobalSearch):
+			if !m.capturesInput() && m.currentView != ViewSearch {
+				return m, func() tea.Msg {
+					return SwitchViewMsg{View: ViewSearch}
+				}
+			}
+		case keys.Matches(msg, actionTargetGroup):
+			if !m.capturesInput() {
+				return m, m.switchTargetGroup()
+			}
+		case keys.Matches(msg, actionJumpLevel):
+			// Jump up to a breadcrumb level
+			if !m.capturesInput() && len(m.breadcrumbLevels()) > 0 {
+				return m, m.jumpToLevel(keys.Index(msg, actionJumpLevel) + 1)
+			}
+		}
+		
+		// The user's own commands take precedence over the view's keys
+		if !m.capturesInput() {
+			if cmd := m.runCustomCommand(msg); cmd != nil {
+				return m, cmd
 			}
 		}
 		
 		// Route key messages to current view
 		return m.handleViewUpdate(msg)
 		
+	case FlyrcPollMsg:
+		return m, m.reloadFlyrc()
+		
+	case restoreSessionMsg:
+		return m, m.restoreSession(msg.Session)
+		
+	case NavigateBackMsg:
+		if msg.From != ViewMain && msg.From != m.currentView {
+			return m, nil
+		}
+		return m, m.popNav()
+		
 	case SwitchViewMsg:
+		// What the view being left still waits for is of no use anymore
+		concourse.CancelReads()
+		m.buildLogView.Stop()
+		if !msg.Replace {
+			m.pushNav()
+		}
+		previousTarget := m.currentTarget
 		m.currentView = msg.View
-		m.currentTarget = msg.Target
 		if msg.Target != "" {
+			m.currentTarget = msg.Target
 			m.client = concourse.NewClient(msg.Target)
+		} else if msg.View == ViewMain || msg.View == ViewTargets || msg.View == ViewWatchlist || msg.View == ViewAggregate {
+			m.currentTarget = ""
+		}
+		if msg.Pipeline != "" {
+			m.currentPipeline = msg.Pipeline
+		} else if msg.View == ViewJobs || msg.View == ViewResources {
+			m.currentPipeline = m.pipelinesView.GetSelectedPipeline()
+		}
+		if msg.Job != "" || msg.View != ViewBuilds {
+			m.currentJob = msg.Job
 		}
+		m.currentBuild = msg.Build
+		m.configView.editNext = msg.Edit
+		m.resourcesView.selectNext = msg.Resource
+		m.aggregateView.requested = msg.Targets
+		m.recordRecent(msg)
 		
-		// Handle builds view switching with specific job/pipeline
-		if msg.View == ViewBuilds && msg.Job != "" && msg.Pipeline != "" {
-			if m.client != nil {
-				// Set the client for the builds view
-				m.buildsView.client = m.client
-				return m, m.buildsView.LoadBuilds(msg.Pipeline, msg.Job)
-			}
+		// Ask for a new login up front rather than letting the first fly call fail
+		if msg.Target != "" && msg.Target != previousTarget && m.interruptForExpiredToken() {
+			return m, nil
 		}
 		
-		return m, m.handleViewSwitch()
+		return m, tea.Batch(m.handleViewSwitch(), m.loadClusterInfo(), m.loadUserInfo())
 		
 	case PipelinesLoadedMsg:
-		// Check if this is an authentication error
-		if concourse.IsAuthError(msg.Error) && m.currentTarget != "" {
-			// Get the target config and switch to auth view
-			if target, exists := m.configManager.GetTarget(m.currentTarget); exists {
-				m.authView.SetTarget(target, m.client)
-				m.currentView = ViewAuth
-				return m, nil
-			}
+		// Check if this is an authentication or fly version error
+		if m.currentView == ViewPipelines && m.interruptFor(msg.Error) {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.pipelinesView, cmd = m.pipelinesView.HandlePipelinesLoaded(msg)
+		return m, cmd
+		
+	case PipelineHealthMsg:
+		m.pipelinesView = m.pipelinesView.HandlePipelineHealth(msg)
+		return m, nil
+		
+	case ConfigPreviewMsg:
+		m.pipelinesView = m.pipelinesView.HandleConfigPreview(msg)
+		return m, nil
+		
+	case ConfigLoadedMsg:
+		if m.currentView == ViewConfig && m.interruptFor(msg.Error) {
+			return m, nil
 		}
-		m.pipelinesView = m.pipelinesView.HandlePipelinesLoaded(msg)
+		var cmd tea.Cmd
+		m.configView, cmd = m.configView.HandleConfigLoaded(msg)
+		return m, cmd
+		
+	case ConfigEditedMsg:
+		var cmd tea.Cmd
+		m.configView, cmd = m.configView.HandleConfigEdited(msg)
+		return m, cmd
+		
+	case ConfigValidatedMsg:
+		var cmd tea.Cmd
+		m.configView, cmd = m.configView.HandleConfigValidated(msg)
+		return m, cmd
+		
+	case ConfigAppliedMsg:
+		// The config previews beside the pipelines may be outdated now
+		m.pipelinesView.previews = nil
+		var cmd tea.Cmd
+		m.configView, cmd = m.configView.HandleConfigApplied(msg)
+		return m, cmd
+		
+	case WatchlistLoadedMsg:
+		m.watchlistView = m.watchlistView.HandleWatchlistLoaded(msg)
 		return m, nil
 		
+	case WatchlistTickMsg:
+		var cmd tea.Cmd
+		m.watchlistView, cmd = m.watchlistView.HandleTick(msg, m.currentView == ViewWatchlist)
+		return m, cmd
+		
 	case JobsLoadedMsg:
-		m.jobsView = m.jobsView.HandleJobsLoaded(msg)
+		if m.currentView == ViewJobs && m.interruptFor(msg.Error) {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.jobsView, cmd = m.jobsView.HandleJobsLoaded(msg)
+		return m, cmd
+		
+	case JobPreparationMsg:
+		m.jobsView = m.jobsView.HandleJobPreparation(msg)
 		return m, nil
 		
 	case ResourcesLoadedMsg:
+		if m.currentView == ViewResources && m.interruptFor(msg.Error) {
+			return m, nil
+		}
 		m.resourcesView = m.resourcesView.HandleResourcesLoaded(msg)
 		return m, nil
 		
 	case BuildsLoadedMsg:
-		m.buildsView.HandleBuildsLoaded(msg)
-		return m, nil
+		if m.currentView == ViewBuilds && m.interruptFor(msg.Error) {
+			return m, nil
+		}
+		return m, m.buildsView.HandleBuildsLoaded(msg)
 		
 	case BuildRerunResultMsg:
+		if msg.Success {
+			m.notifier.follow(jobRef{msg.Target, msg.Pipeline, msg.Job}, msg.Output)
+		}
 		// Handle build rerun result messages - let the builds view handle it
 		var cmd tea.Cmd
 		var newModel tea.Model
@@ -184,25 +563,173 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.buildsView = newModel.(BuildsViewModel)
 		return m, cmd
 		
-	case BuildRerunTickMsg:
-		// Handle build rerun tick messages - let the builds view handle it
-		var cmd tea.Cmd  
+	case BuildLogMsg:
+		var cmd tea.Cmd
+		m.buildLogView, cmd = m.buildLogView.HandleBuildLog(msg)
+		return m, cmd
+		
+	case BuildAbortedMsg:
 		var newModel tea.Model
+		var cmd tea.Cmd
 		newModel, cmd = m.buildsView.Update(msg)
 		m.buildsView = newModel.(BuildsViewModel)
 		return m, cmd
 		
-	case ClearRerunMessageMsg:
-		// Handle clear rerun message - let the builds view handle it
+	case PipelineDestroyedMsg:
 		var cmd tea.Cmd
-		var newModel tea.Model  
-		newModel, cmd = m.buildsView.Update(msg)
-		m.buildsView = newModel.(BuildsViewModel)
+		m.pipelinesView, cmd = m.pipelinesView.HandlePipelineDestroyed(msg)
+		return m, cmd
+		
+	case DeleteTargetMsg:
+		var cmd tea.Cmd
+		m.targetsView, cmd = m.targetsView.HandleDeleteTarget(msg)
+		return m, cmd
+		
+	case TargetLoggedOutMsg:
+		delete(m.userInfo, msg.Target)
+		var cmd tea.Cmd
+		m.targetsView, cmd = m.targetsView.HandleTargetLoggedOut(msg)
+		return m, cmd
+		
+	case EditTargetMsg:
+		m.pushNav()
+		m.currentView = ViewAddTarget
+		m.addTargetView.Edit(msg.Target)
+		return m, textinput.Blink
+		
+	case DuplicateTargetMsg:
+		m.pushNav()
+		m.currentView = ViewAddTarget
+		m.addTargetView.Duplicate(msg.Target)
+		return m, textinput.Blink
+		
+	case UpdateTargetMsg:
+		delete(m.clusterInfo, msg.Original)
+		delete(m.userInfo, msg.Original)
+		var cmd tea.Cmd
+		m.targetsView, cmd = m.targetsView.HandleUpdateTarget(msg)
+		return m, cmd
+		
+	case TargetRenamedMsg:
+		if m.currentTarget == msg.From {
+			m.currentTarget = msg.To
+			m.client = concourse.NewClient(msg.To)
+		}
+		if err := m.stateStore.RenameTarget(msg.From, msg.To); err != nil {
+			return m, showToast(ToastError, "Failed to move favorites and the watchlist to %s: %v", msg.To, err)
+		}
+		return m, nil
+		
+	case ClusterInfoMsg:
+		m.handleClusterInfo(msg)
+		return m, nil
+		
+	case UserInfoMsg:
+		m.handleUserInfo(msg)
+		return m, nil
+		
+	case TargetHealthMsg:
+		m.targetsView = m.targetsView.HandleTargetHealth(msg)
+		return m, nil
+		
+	case FlyrcRestoredMsg:
+		return m, m.handleFlyrcRestored(msg)
+		
+	case TargetTeamsMsg:
+		var cmd tea.Cmd
+		m.targetsView, cmd = m.targetsView.HandleTargetTeams(msg)
+		return m, cmd
+		
+	case TeamSwitchedMsg:
+		delete(m.userInfo, msg.Target)
+		var cmd tea.Cmd
+		m.targetsView, cmd = m.targetsView.HandleTeamSwitched(msg)
+		return m, cmd
+		
+	case TargetLoginMsg:
+		delete(m.userInfo, msg.Target)
+		var cmd tea.Cmd
+		m.targetsView, cmd = m.targetsView.HandleTargetLogin(msg)
+		return m, cmd
+		
+	case TeamsLoadedMsg:
+		if m.currentView == ViewTeams && m.interruptFor(msg.Error) {
+			return m, nil
+		}
+		m.teamsView = m.teamsView.HandleTeamsLoaded(msg)
+		return m, nil
+		
+	case TeamSetMsg:
+		var cmd tea.Cmd
+		m.teamsView, cmd = m.teamsView.HandleTeamSet(msg)
+		return m, cmd
+		
+	case TeamDestroyedMsg:
+		var cmd tea.Cmd
+		m.teamsView, cmd = m.teamsView.HandleTeamDestroyed(msg)
+		return m, cmd
+		
+	case ActiveUsersLoadedMsg:
+		if m.currentView == ViewActiveUsers && m.interruptFor(msg.Error) {
+			return m, nil
+		}
+		m.activeUsersView = m.activeUsersView.HandleActiveUsersLoaded(msg)
+		return m, nil
+		
+	case GraphLoadedMsg:
+		if m.currentView == ViewGraph && m.interruptFor(msg.Error) {
+			return m, nil
+		}
+		m.graphView = m.graphView.HandleGraphLoaded(msg)
+		return m, nil
+		
+	case FlakyJobsMsg:
+		if m.currentView == ViewFlaky && m.interruptFor(msg.Error) {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.flakyView, cmd = m.flakyView.HandleFlakyJobs(msg)
+		return m, cmd
+		
+	case FlakyHistoryMsg:
+		m.flakyView = m.flakyView.HandleFlakyHistory(msg)
+		return m, nil
+		
+	case DashboardPipelinesMsg:
+		if m.currentView == ViewDashboard && m.interruptFor(msg.Error) {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.dashboardView, cmd = m.dashboardView.HandleDashboardPipelines(msg)
+		return m, cmd
+		
+	case DashboardJobsMsg:
+		m.dashboardView = m.dashboardView.HandleDashboardJobs(msg)
+		return m, nil
+		
+	case SearchPipelinesMsg:
+		// Searching all targets notes the ones failing instead
+		if m.currentView == ViewSearch && !m.searchView.all && m.interruptFor(msg.Error) {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.searchView, cmd = m.searchView.HandleSearchPipelines(msg)
 		return m, cmd
 		
+	case SearchItemsMsg:
+		m.searchView = m.searchView.HandleSearchItems(msg)
+		return m, nil
+		
+	case AggregatePipelinesMsg:
+		m.aggregateView = m.aggregateView.HandleAggregatePipelines(msg)
+		return m, nil
+		
 	case ResourceCheckMsg:
 		var cmd tea.Cmd
 		m.resourcesView, cmd = m.resourcesView.HandleResourceCheck(msg)
+		if !msg.Success {
+			cmd = tea.Batch(cmd, m.resourceCheckFailedHook(msg))
+		}
 		return m, cmd
 		
 	case ReloadResourcesMsg:
@@ -212,32 +739,100 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 		
 	case TriggerJobMsg:
-		m.jobsView = m.jobsView.HandleTriggerJob(msg)
-		return m, nil
+		var hook tea.Cmd
+		if msg.Success {
+			job := strings.TrimPrefix(msg.Job, msg.Pipeline+"/")
+			m.notifier.follow(jobRef{msg.Target, msg.Pipeline, job}, msg.Output)
+			hook = m.jobTriggeredHook(msg.Target, msg.Pipeline, job, msg.Output)
+		}
+		var cmd tea.Cmd
+		m.jobsView, cmd = m.jobsView.HandleTriggerJob(msg)
+		return m, tea.Batch(cmd, hook)
 		
 	case TriggerJobRequestMsg:
 		if m.client != nil {
 			jobName := fmt.Sprintf("%s/%s", msg.Pipeline, msg.Job)
 			m.jobsView = m.jobsView.StartJobTrigger(jobName)
+			client := m.client
 			return m, func() tea.Msg {
-				success, output, err := m.client.TriggerJobWithOutput(msg.Pipeline, msg.Job)
+				success, output, err := client.TriggerJobWithOutput(msg.Pipeline, msg.Job)
 				return TriggerJobMsg{
-					Job:     jobName,
-					Output:  output,
-					Error:   err,
-					Success: success,
+					Target:   client.GetTarget(),
+					Pipeline: msg.Pipeline,
+					Job:      jobName,
+					Output:   output,
+					Error:    err,
+					Success:  success,
 				}
 			}
 		}
 		return m, nil
 		
+	case BatchTriggerRequestMsg:
+		if m.client != nil {
+			m.jobsView = m.jobsView.StartBatchTrigger(len(msg.Jobs))
+			client := m.client
+			return m, func() tea.Msg {
+				results := make([]BatchTriggerResult, len(msg.Jobs))
+				var wg sync.WaitGroup
+				for i, job := range msg.Jobs {
+					results[i].Job = fmt.Sprintf("%s/%s", msg.Pipeline, job.Name)
+					if msg.SkipPending && job.NextBuild.ID != 0 {
+						results[i].Skipped = true
+						continue
+					}
+					wg.Add(1)
+					go func(i int, job concourse.Job) {
+						defer wg.Done()
+						success, output, err := client.TriggerJobWithOutput(msg.Pipeline, job.Name)
+						results[i].Success = success
+						results[i].Output = output
+						results[i].Error = err
+					}(i, job)
+				}
+				wg.Wait()
+				return BatchTriggerMsg{Target: client.GetTarget(), Pipeline: msg.Pipeline, Results: results}
+			}
+		}
+		return m, nil
+		
+	case BatchTriggerMsg:
+		var hooks []tea.Cmd
+		for _, result := range msg.Results {
+			if result.Success {
+				job := strings.TrimPrefix(result.Job, msg.Pipeline+"/")
+				m.notifier.follow(jobRef{msg.Target, msg.Pipeline, job}, result.Output)
+				hooks = append(hooks, m.jobTriggeredHook(msg.Target, msg.Pipeline, job, result.Output))
+			}
+		}
+		m.jobsView = m.jobsView.HandleBatchTrigger(msg)
+		return m, tea.Batch(hooks...)
+		
+	case NotifyTickMsg:
+		return m, m.notifier.poll(m.stateStore.GetWatchlist())
+		
+	case NotifyPollMsg:
+		finished := m.notifier.finished(msg, m.stateStore.GetWatchlist())
+		return m, tea.Batch(m.notifier.report(finished), m.buildFinishedHooks(finished), m.notifier.schedule())
+		
+	case NotificationSentMsg:
+		if msg.Error != nil && !m.notifier.reported {
+			// Say it once rather than on every finished build
+			m.notifier.reported = true
+			return m, showToast(ToastError, "Desktop notifications failed: %v", msg.Error)
+		}
+		return m, nil
+		
 	case CheckResourceRequestMsg:
 		if m.client != nil {
 			resourceName := fmt.Sprintf("%s/%s", msg.Pipeline, msg.Resource)
 			m.resourcesView = m.resourcesView.StartResourceCheck(resourceName)
+			client := m.client
 			return m, func() tea.Msg {
-				success, output, err := m.client.CheckResourceWithOutput(msg.Pipeline, msg.Resource)
+				success, output, err := client.CheckResourceWithOutput(msg.Pipeline, msg.Resource)
 				return ResourceCheckMsg{
+					Target:   client.GetTarget(),
+					Pipeline: msg.Pipeline,
 					Resource: resourceName,
 					Output:   output,
 					Error:    err,
@@ -248,10 +843,33 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 		
 	case AuthenticationMsg:
+		if msg.Success {
+			// Pick up the token fly just saved, which may belong to another user
+			m.configManager.LoadConfig()
+			delete(m.userInfo, msg.Target)
+		}
 		var cmd tea.Cmd
 		m.authView, cmd = m.authView.HandleAuthResult(msg)
 		return m, cmd
 		
+	case TokenPastedMsg:
+		return m, m.saveToken(msg)
+		
+	case ExternalLoginMsg:
+		var cmd tea.Cmd
+		m.authView, cmd = m.authView.HandleExternalLogin(msg)
+		return m, cmd
+		
+	case FlySyncedMsg:
+		var cmd tea.Cmd
+		m.syncView, cmd = m.syncView.HandleSyncResult(msg)
+		return m, cmd
+		
+	case TargetReachableMsg:
+		var cmd tea.Cmd
+		m.addTargetView, cmd = m.addTargetView.Update(msg)
+		return m, cmd
+		
 	case TargetCreateMsg:
 		// Handle target creation result - let the add target view handle it
 		var cmd tea.Cmd
@@ -261,15 +879,68 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// If creation was successful, refresh targets when we switch back
 		if msg.Success {
 			// Reload targets configuration
-			m.targetsView = NewTargetsViewModel(m.configManager)
+			m.targetsView = NewTargetsViewModel(m.configManager, m.settings.ClipboardCommand)
 		}
 		
 		return m, cmd
 	}
 	
+	// Let the add target form's text inputs blink their cursors
+	if m.currentView == ViewAddTarget {
+		var cmd tea.Cmd
+		m.addTargetView, cmd = m.addTargetView.Update(msg)
+		return m, cmd
+	}
+	// Likewise for the login form's
+	if m.currentView == ViewAuth {
+		var cmd tea.Cmd
+		m.authView, cmd = m.authView.UpdateInput(msg)
+		return m, cmd
+	}
+	// And the teams view's set-team form and destroy prompt
+	if m.currentView == ViewTeams {
+		var cmd tea.Cmd
+		m.teamsView, cmd = m.teamsView.UpdateInput(msg)
+		return m, cmd
+	}
+	// And the build log's prompt for where to save it
+	if m.currentView == ViewBuildLog {
+		var cmd tea.Cmd
+		m.buildLogView, cmd = m.buildLogView.UpdateInput(msg)
+		return m, cmd
+	}
+	
 	return m, nil
 }
 
+// recordRecent remembers visited pipelines and jobs for the main menu's recent section
+func (m *Model) recordRecent(msg SwitchViewMsg) {
+	if m.currentTarget == "" || m.currentPipeline == "" {
+		return
+	}
+	
+	item := state.RecentItem{Target: m.currentTarget, Pipeline: m.currentPipeline}
+	switch msg.View {
+	case ViewJobs:
+	case ViewBuilds:
+		if msg.Job == "" {
+			return
+		}
+		item.Job = msg.Job
+	case ViewBuildLog:
+		if msg.Job == "" || msg.Build == "" {
+			return
+		}
+		item.Job = msg.Job
+		item.Build = msg.Build
+	default:
+		return
+	}
+	
+	// Recent items are a convenience, failing to persist them shouldn't interrupt navigation
+	_ = m.stateStore.AddRecent(item)
+}
+
 // handleViewUpdate routes updates to the current view
 func (m *Model) handleViewUpdate(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
@@ -295,6 +966,30 @@ func (m *Model) handleViewUpdate(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, cmd
 	case ViewAuth:
 		m.authView, cmd = m.authView.Update(msg)
+	case ViewSync:
+		m.syncView, cmd = m.syncView.Update(msg)
+	case ViewWatchlist:
+		m.watchlistView, cmd = m.watchlistView.Update(msg)
+	case ViewHistory:
+		m.historyView, cmd = m.historyView.Update(msg)
+	case ViewTeams:
+		m.teamsView, cmd = m.teamsView.Update(msg)
+	case ViewActiveUsers:
+		m.activeUsersView, cmd = m.activeUsersView.Update(msg)
+	case ViewGraph:
+		m.graphView, cmd = m.graphView.Update(msg)
+	case ViewFlaky:
+		m.flakyView, cmd = m.flakyView.Update(msg)
+	case ViewBuildLog:
+		m.buildLogView, cmd = m.buildLogView.Update(msg, m.contentHeight())
+	case ViewDashboard:
+		m.dashboardView, cmd = m.dashboardView.Update(msg)
+	case ViewConfig:
+		m.configView, cmd = m.configView.Update(msg, m.contentHeight())
+	case ViewSearch:
+		m.searchView, cmd = m.searchView.Update(msg)
+	case ViewAggregate:
+		m.aggregateView, cmd = m.aggregateView.Update(msg)
 	}
 	
 	return m, cmd
@@ -302,23 +997,87 @@ func (m *Model) handleViewUpdate(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // handleViewSwitch handles switching between views
 func (m *Model) handleViewSwitch() tea.Cmd {
+	if cmd := m.revalidateView(); cmd != nil {
+		return cmd
+	}
 	switch m.currentView {
+	case ViewTargets:
+		return m.targetsView.CheckHealth(false)
 	case ViewPipelines:
 		if m.client != nil {
+			target, _ := m.configManager.GetTarget(m.currentTarget)
+			m.pipelinesView.team = target.Team
 			return m.pipelinesView.LoadPipelines(m.client)
 		}
 	case ViewJobs:
-		if m.client != nil && m.pipelinesView.GetSelectedPipeline() != "" {
+		if m.client != nil && m.currentPipeline != "" {
 			// Set client for jobs view so it can refresh
 			m.jobsView.client = m.client
-			return m.jobsView.LoadJobs(m.client, m.pipelinesView.GetSelectedPipeline())
+			if jobs := m.prefetch.takeJobs(prefetchKey{m.currentTarget, m.currentPipeline}); jobs != nil {
+				var cmd tea.Cmd
+				m.jobsView, cmd = m.jobsView.HandleJobsLoaded(*jobs)
+				return cmd
+			}
+			m.jobsView.loading = true
+			return m.jobsView.LoadJobs(m.client, m.currentPipeline)
 		}
 	case ViewResources:
-		if m.client != nil && m.pipelinesView.GetSelectedPipeline() != "" {
+		if m.client != nil && m.currentPipeline != "" {
 			// Set client for resources view so it can refresh
 			m.resourcesView.client = m.client
-			return m.resourcesView.LoadResources(m.client, m.pipelinesView.GetSelectedPipeline())
+			if resources := m.prefetch.takeResources(prefetchKey{m.currentTarget, m.currentPipeline}); resources != nil {
+				m.resourcesView = m.resourcesView.HandleResourcesLoaded(*resources)
+				return nil
+			}
+			m.resourcesView.state = resourcesStateLoading
+			return m.resourcesView.LoadResources(m.client, m.currentPipeline)
+		}
+	case ViewBuilds:
+		if m.client != nil && m.currentPipeline != "" && m.currentJob != "" {
+			// Set the client for the builds view
+			m.buildsView.client = m.client
+			return m.buildsView.LoadBuilds(m.currentPipeline, m.currentJob)
+		}
+	case ViewWatchlist:
+		return m.watchlistView.Activate()
+	case ViewHistory:
+		m.historyView.Load()
+	case ViewTeams:
+		if m.client != nil {
+			return m.teamsView.LoadTeams(m.client)
+		}
+	case ViewActiveUsers:
+		if m.client != nil {
+			return m.activeUsersView.LoadActiveUsers(m.client)
+		}
+	case ViewGraph:
+		if m.client != nil && m.currentPipeline != "" {
+			return m.graphView.LoadGraph(m.client, m.currentPipeline)
+		}
+	case ViewFlaky:
+		if m.client != nil && m.currentPipeline != "" {
+			return m.flakyView.LoadReport(m.client, m.currentPipeline)
+		}
+	case ViewBuildLog:
+		if m.client != nil && m.currentPipeline != "" && m.currentJob != "" && m.currentBuild != "" {
+			return m.buildLogView.LoadLog(m.client, m.currentPipeline, m.currentJob, m.currentBuild)
 		}
+	case ViewDashboard:
+		if m.client != nil {
+			return m.dashboardView.LoadDashboard(m.client)
+		}
+	case ViewConfig:
+		if m.client != nil && m.currentPipeline != "" {
+			return m.configView.LoadConfig(m.client, m.currentPipeline)
+		}
+	case ViewSearch:
+		return m.searchView.Open(m.currentTarget)
+	case ViewAggregate:
+		return m.aggregateView.Open()
+	case ViewAddTarget:
+		// Start from an empty form each time
+		m.addTargetView.Reset()
+		return textinput.Blink
 	}
 	return nil
 }
@@ -331,81 +1090,205 @@ func (m *Model) View() string {
 	
 	// Header
 	header := m.renderHeader()
+	breadcrumbs := m.renderBreadcrumbs()
+	height := m.contentHeight()
 	
 	// Content
+	var content string
+	if m.help != nil {
+		content = m.renderHelp()
+	} else {
+		content = m.renderView(height)
+	}
+	
+	// Footer
+	footer := m.renderFooter()
+	
+	var view string
+	if breadcrumbs != "" {
+		view = lipgloss.JoinVertical(lipgloss.Left, header, breadcrumbs, content, footer)
+	} else {
+		view = lipgloss.JoinVertical(lipgloss.Left, header, content, footer)
+	}
+	return renderGlyphs(m.overlayToasts(m.overlayConfirm(m.overlayErrorModal(view))))
+}
+
+// renderView renders the current view's content
+func (m *Model) renderView(height int) string {
 	var content string
 	switch m.currentView {
 	case ViewMain:
-		content = m.mainView.View(m.width, m.height-3)
+		content = m.mainView.View(m.width, height)
 	case ViewTargets:
-		content = m.targetsView.View(m.width, m.height-3)
+		content = m.targetsView.View(m.width, height)
 	case ViewPipelines:
-		content = m.pipelinesView.View(m.width, m.height-3)
+		content = m.pipelinesView.View(m.width, height)
 	case ViewJobs:
-		content = m.jobsView.View(m.width, m.height-3, m.client.GetTarget())
+		content = m.jobsView.View(m.width, height, m.client.GetTarget())
 	case ViewResources:
-		content = m.resourcesView.View(m.width, m.height-3, m.client.GetTarget())
+		content = m.resourcesView.View(m.width, height, m.client.GetTarget())
 	case ViewBuilds:
-		content = m.buildsView.View()
+		content = m.buildsView.ViewHeight(height)
 	case ViewAddTarget:
-		content = m.addTargetView.View(m.width, m.height-3)
+		content = m.addTargetView.View(m.width, height)
 	case ViewAuth:
-		content = m.authView.View(m.width, m.height-3)
+		content = m.authView.View(m.width, height)
+	case ViewSync:
+		content = m.syncView.View(m.width, height)
+	case ViewWatchlist:
+		content = m.watchlistView.View(m.width, height)
+	case ViewHistory:
+		content = m.historyView.View(m.width, height)
+	case ViewTeams:
+		content = m.teamsView.View(m.width, height)
+	case ViewActiveUsers:
+		content = m.activeUsersView.View(m.width, height)
+	case ViewGraph:
+		content = m.graphView.View(m.width, height)
+	case ViewFlaky:
+		content = m.flakyView.View(m.width, height)
+	case ViewBuildLog:
+		content = m.buildLogView.View(m.width, height)
+	case ViewDashboard:
+		content = m.dashboardView.View(m.width, height)
+	case ViewConfig:
+		content = m.configView.View(m.width, height)
+	case ViewSearch:
+		content = m.searchView.View(m.width, height)
+	case ViewAggregate:
+		content = m.aggregateView.View(m.width, height)
 	}
-	
-	// Footer
-	footer := m.renderFooter()
-	
-	return lipgloss.JoinVertical(lipgloss.Left, header, content, footer)
+	return content
 }
 
 // renderHeader renders the application header
 func (m *Model) renderHeader() string {
 	style := lipgloss.NewStyle().
-		Background(lipgloss.Color("62")).
-		Foreground(lipgloss.Color("230")).
+		Background(theme.HeaderBg).
+		Foreground(theme.HeaderFg).
 		Bold(true).
 		Padding(0, 1).
 		Width(m.width)
 	
 	title := "FlyBy - Concourse CI Terminal UI"
+	if m.settings.Demo {
+		title += " (demo)"
+	}
+	if activeGroup != "" {
+		title += " | Group: " + activeGroup
+	}
 	if m.currentTarget != "" {
 		title += fmt.Sprintf(" | Target: %s", m.currentTarget)
+		target, _ := m.configManager.GetTarget(m.currentTarget)
+		if target.Team != "" {
+			title += " | Team: " + target.Team
+		}
+		if user := m.userInfo[m.currentTarget]; user.Teams != nil {
+			title += " as " + formatUser(user, target.Team)
+		}
+		var notes []string
+		if info := m.clusterInfo[m.currentTarget]; info.Version != "" {
+			notes = append(notes, "Concourse "+info.Version)
+		}
+		if target, exists := m.configManager.GetTarget(m.currentTarget); exists {
+			if expiry := formatTokenExpiry(target); expiry != "" {
+				notes = append(notes, expiry)
+			}
+		}
+		if len(notes) > 0 {
+			title += fmt.Sprintf(" (%s)", strings.Join(notes, ", "))
+		}
 	}
 	
 	return style.Render(title)
 }
 
+// contentHeight returns the height available to the current view
+func (m *Model) contentHeight() int {
+	height := m.height - 3
+	if len(m.breadcrumbLevels()) > 0 {
+		height--
+	}
+	return height
+}
+
+// renderBreadcrumbs renders the current target › pipeline › job › build
+// context with the keys that jump back up to each level
+func (m *Model) renderBreadcrumbs() string {
+	levels := m.breadcrumbLevels()
+	if len(levels) == 0 {
+		return ""
+	}
+	
+	style := lipgloss.NewStyle().
+		Foreground(theme.Text).
+		Padding(0, 1).
+		Width(m.width)
+	keyStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+	currentStyle := lipgloss.NewStyle().Foreground(theme.Primary).Bold(true)
+	
+	var crumbs []string
+	for i, level := range levels {
+		label := level.Target
+		switch level.View {
+		case ViewJobs:
+			label = level.Pipeline
+		case ViewBuilds:
+			label = level.Job
+		case ViewBuildLog:
+			label = "#" + level.Build
+		}
+		
+		key := ""
+		if jumpKeys := keys[actionJumpLevel].Keys; i < len(jumpKeys) {
+			key = keyStyle.Render(keyLabel(jumpKeys[i]) + ":")
+		}
+		if i == len(levels)-1 {
+			crumbs = append(crumbs, key+currentStyle.Render(label))
+		} else {
+			crumbs = append(crumbs, key+label)
+		}
+	}
+	
+	return style.Render(strings.Join(crumbs, " › "))
+}
+
 // renderFooter renders the application footer
 func (m *Model) renderFooter() string {
 	style := lipgloss.NewStyle().
-		Background(lipgloss.Color("236")).
-		Foreground(lipgloss.Color("252")).
+		Background(theme.FooterBg).
+		Foreground(theme.Text).
 		Padding(0, 1).
 		Width(m.width)
 	
-	var keyHelp []string
-	
-	switch m.currentView {
-	case ViewMain:
-		keyHelp = []string{"↑/↓: navigate", "enter: select", "q: quit"}
-	case ViewTargets:
-		keyHelp = []string{"↑/↓: navigate", "enter: select", "a: add target", "d: delete", "esc: back", "q: quit"}
-	case ViewPipelines:
-		keyHelp = []string{"↑/↓: navigate", "j: jobs", "r: resources", "t: trigger", "p: pause/unpause", "F5: refresh", "esc: back", "q: quit"}
-	case ViewJobs:
-		keyHelp = []string{"↑/↓: navigate", "enter: trigger", "b: builds", "F5: refresh", "esc: back", "q: quit"}
-	case ViewResources:
-		keyHelp = []string{"↑/↓: navigate", "enter: check", "F5: refresh", "esc: back", "q: quit"}
-	case ViewBuilds:
-		keyHelp = []string{"↑/↓: navigate", "enter: rerun build", "F5: refresh", "esc: back", "q: quit"}
-	case ViewAddTarget:
-		keyHelp = []string{"tab: next field", "enter: save", "esc: cancel", "q: quit"}
-	case ViewAuth:
-		keyHelp = []string{"enter/y: login", "n: cancel", "esc: back", "q: quit"}
+	if m.help != nil {
+		return style.Render(strings.Join([]string{fixedHelp("scroll", "up", "down"), keys.Label(actionHelp) + "/" + keys.Label(actionBack) + ": close"}, " • "))
+	}
+	// Forms and text inputs keep their keys, their letters are typed
+	quit := fixedHelp("quit", "ctrl+c")
+	if m.currentView == ViewAddTarget {
+		return style.Render(strings.Join([]string{fixedHelp("next field", "tab"), fixedHelp("save", "enter"), fixedHelp("cancel", "esc"), quit}, " • "))
+	}
+	if m.currentView == ViewAuth && m.authView.credentials && !m.authView.authenticating {
+		return style.Render(strings.Join([]string{fixedHelp("next field", "tab"), fixedHelp("login", "enter"), fixedHelp("back", "esc"), quit}, " • "))
+	}
+	if m.currentView == ViewAuth && m.authView.pasting && !m.authView.authenticating {
+		return style.Render(strings.Join([]string{fixedHelp("login", "enter"), fixedHelp("back", "esc"), quit}, " • "))
+	}
+	if m.currentView == ViewTeams && m.teamsView.form != nil {
+		return style.Render(strings.Join([]string{fixedHelp("next field", "tab"), fixedHelp("save", "enter"), fixedHelp("cancel", "esc"), quit}, " • "))
+	}
+	if m.currentView == ViewJobs && m.jobsView.links != nil {
+		return style.Render(strings.Join([]string{keys.HelpLine(actionUp, actionDown), keys.Label(actionSelect) + ": jump to job", keys.Label(actionBack) + ": close", quit}, " • "))
+	}
+	if m.currentView == ViewSearch {
+		return style.Render(strings.Join([]string{fixedHelp("navigate", "up", "down"), fixedHelp("open", "enter"), fixedHelp("back", "esc"), quit}, " • "))
+	}
+	if m.currentView == ViewTeams && m.teamsView.destroying {
+		return style.Render(strings.Join([]string{fixedHelp("destroy", "enter"), fixedHelp("cancel", "esc"), quit}, " • "))
 	}
 	
-	return style.Render(strings.Join(keyHelp, " • "))
+	return style.Render(keys.HelpLine(footerKeys[m.currentView]...))
 }
 
 // SwitchViewMsg is a message for switching views
@@ -414,5 +1297,10 @@ type SwitchViewMsg struct {
 	Target   string
 	Job      string
 	Pipeline string
+	Build    string // the build whose log to show
+	Resource string // the resource to select once the resources load
+	Targets  []string // the targets whose pipelines to list together
+	Edit     bool   // open the pipeline's config in the editor once it loads
 	Data     interface{}
+	Replace  bool // replace the current view in the navigation history instead of stacking on top of it
 }
\ No newline at end of file
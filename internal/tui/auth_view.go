@@ -3,10 +3,12 @@ package tui
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"flyby/internal/concourse"
 	"flyby/internal/config"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -18,8 +20,35 @@ type AuthViewModel struct {
 	authenticating bool
 	error         error
 	success       bool
+	resume        SwitchViewMsg
+	loader        loader
+	
+	// Username/password form for local-auth instances, where the browser
+	// flow isn't available (e.g. on a headless server)
+	credentials   bool
+	inputs        []textinput.Model
+	focused       int
+	
+	// A login run in another terminal, for when the browser flow can't
+	// share FlyBy's terminal
+	terminalCommand string
+	external        bool
+	externalCommand string
+	
+	// A token pasted from the Concourse login page, for SSH sessions where
+	// fly can't receive it from the browser
+	pasting bool
+	token   textinput.Model
 }
 
+// tokenLoginPort is the fly_port of the login page link for pasting a token.
+// No fly listens on it, so once logged in the page shows the token to copy.
+const tokenLoginPort = "1"
+
+// externalLoginPoll is how often FlyBy checks whether a login run in another
+// terminal has finished
+const externalLoginPoll = 2 * time.Second
+
 // AuthenticationMsg represents authentication result
 type AuthenticationMsg struct {
 	Success bool
@@ -27,34 +56,122 @@ type AuthenticationMsg struct {
 	Target  string
 }
 
+// ExternalLoginMsg reports whether the target is logged in yet, while a
+// login runs in another terminal
+type ExternalLoginMsg struct {
+	Target   string
+	LoggedIn bool
+}
+
+// TokenPastedMsg carries a token pasted into the login screen, to be saved
+// to the flyrc
+type TokenPastedMsg struct {
+	Target string
+	Token  string
+}
+
 // NewAuthViewModel creates a new authentication view model
-func NewAuthViewModel() AuthViewModel {
+func NewAuthViewModel(terminalCommand string) AuthViewModel {
 	return AuthViewModel{
-		authenticating: false,
-		success:        false,
+		authenticating:  false,
+		success:         false,
+		loader:          newLoader(),
+		terminalCommand: terminalCommand,
 	}
 }
 
-// SetTarget sets the target to authenticate with
-func (m *AuthViewModel) SetTarget(target config.Target, client *concourse.Client) {
+// SetTarget sets the target to authenticate with and the view to resume after login
+func (m *AuthViewModel) SetTarget(target config.Target, client *concourse.Client, resume SwitchViewMsg) {
 	m.target = target
 	m.client = client
+	m.resume = resume
 	m.authenticating = false
 	m.error = nil
 	m.success = false
+	m.credentials = false
+	m.external = false
+	m.pasting = false
 }
 
-// StartAuthentication begins the authentication process
-func (m *AuthViewModel) StartAuthentication() tea.Cmd {
+// OpenCredentials shows the username/password form
+func (m *AuthViewModel) OpenCredentials() tea.Cmd {
+	username := textinput.New()
+	username.Prompt = ""
+	username.Placeholder = "username"
+	username.Width = 36
+	
+	password := textinput.New()
+	password.Prompt = ""
+	password.Placeholder = "password"
+	password.Width = 36
+	password.EchoMode = textinput.EchoPassword
+	
+	m.inputs = []textinput.Model{username, password}
+	m.focused = 0
+	m.credentials = true
+	m.error = nil
+	return tea.Batch(m.inputs[0].Focus(), textinput.Blink)
+}
+
+// OpenTokenPaste shows the login page link and a field to paste the token
+// it ends with
+func (m *AuthViewModel) OpenTokenPaste() tea.Cmd {
+	m.token = textinput.New()
+	m.token.Prompt = ""
+	m.token.Placeholder = "bearer eyJhbGci..."
+	m.token.Width = 56
+	m.token.EchoMode = textinput.EchoPassword
+	m.pasting = true
+	m.error = nil
+	return tea.Batch(m.token.Focus(), textinput.Blink)
+}
+
+// tokenLoginURL returns the login page showing a token to paste
+func (m AuthViewModel) tokenLoginURL() string {
+	return strings.TrimRight(m.target.GetURL(), "/") + "/login?fly_port=" + tokenLoginPort
+}
+
+// updateTokenPaste handles keys while the token field is open
+func (m AuthViewModel) updateTokenPaste(msg tea.KeyMsg) (AuthViewModel, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		token := strings.TrimSpace(m.token.Value())
+		if token == "" {
+			return m, nil
+		}
+		m.authenticating = true
+		m.error = nil
+		target := m.target.Name
+		return m, func() tea.Msg {
+			return TokenPastedMsg{Target: target, Token: token}
+		}
+	case "esc":
+		m.pasting = false
+		return m, nil
+	}
+	
+	return m.UpdateInput(msg)
+}
+
+// focusField moves the cursor to another field of the credentials form
+func (m AuthViewModel) focusField(i int) (AuthViewModel, tea.Cmd) {
+	m.inputs[m.focused].Blur()
+	m.focused = i
+	return m, m.inputs[m.focused].Focus()
+}
+
+// StartPasswordLogin logs in with the username and password from the form
+func (m *AuthViewModel) StartPasswordLogin() tea.Cmd {
 	m.authenticating = true
 	m.error = nil
 	
 	client := m.client
 	target := m.target
+	username := strings.TrimSpace(m.inputs[0].Value())
+	password := m.inputs[1].Value()
 	
 	return func() tea.Msg {
-		// Perform interactive login
-		err := client.LoginInteractive(target.GetURL(), target.Team)
+		err := client.Login(target.Team, username, password)
 		return AuthenticationMsg{
 			Success: err == nil,
 			Error:   err,
@@ -63,6 +180,69 @@ func (m *AuthViewModel) StartAuthentication() tea.Cmd {
 	}
 }
 
+// StartAuthentication begins the authentication process
+func (m *AuthViewModel) StartAuthentication() tea.Cmd {
+	m.authenticating = true
+	m.error = nil
+	
+	target := m.target
+	
+	// fly prints the login URL and may ask for a token, so FlyBy steps aside
+	// until it's done
+	return tea.Exec(m.client.LoginInteractive(target.GetURL(), target.Team), func(err error) tea.Msg {
+		return AuthenticationMsg{
+			Success: err == nil,
+			Error:   err,
+			Target:  target.Name,
+		}
+	})
+}
+
+// StartExternalLogin runs fly login in a new tmux window or terminal window,
+// then waits for the target to be logged in
+func (m *AuthViewModel) StartExternalLogin() tea.Cmd {
+	m.error = nil
+	command, err := m.client.LoginCommand(m.target.GetURL(), m.target.Team, concourse.TLSOptions{})
+	if err == nil {
+		err = runInTerminal(m.terminalCommand, command)
+	}
+	if err != nil {
+		m.error = err
+		return nil
+	}
+	
+	m.external = true
+	m.externalCommand = command
+	return m.pollExternalLogin()
+}
+
+// pollExternalLogin checks a little later whether the target is logged in
+func (m AuthViewModel) pollExternalLogin() tea.Cmd {
+	client := m.client
+	target := m.target.Name
+	return tea.Tick(externalLoginPoll, func(time.Time) tea.Msg {
+		loggedIn, _ := client.Status()
+		return ExternalLoginMsg{Target: target, LoggedIn: loggedIn}
+	})
+}
+
+// HandleExternalLogin finishes the authentication once the login in the
+// other terminal is done, or keeps waiting for it
+func (m AuthViewModel) HandleExternalLogin(msg ExternalLoginMsg) (AuthViewModel, tea.Cmd) {
+	if !m.external || msg.Target != m.target.Name {
+		// Stopped waiting meanwhile
+		return m, nil
+	}
+	if !msg.LoggedIn {
+		return m, m.pollExternalLogin()
+	}
+	
+	m.external = false
+	return m, func() tea.Msg {
+		return AuthenticationMsg{Success: true, Target: msg.Target}
+	}
+}
+
 // Update handles messages for the authentication view
 func (m AuthViewModel) Update(msg tea.KeyMsg) (AuthViewModel, tea.Cmd) {
 	if m.authenticating {
@@ -70,22 +250,79 @@ func (m AuthViewModel) Update(msg tea.KeyMsg) (AuthViewModel, tea.Cmd) {
 		return m, nil
 	}
 	
-	switch msg.String() {
-	case "enter", "y":
+	if m.credentials {
+		return m.updateCredentials(msg)
+	}
+	
+	if m.pasting {
+		return m.updateTokenPaste(msg)
+	}
+	
+	if m.external {
+		if keys.Matches(msg, actionCancel) || keys.Matches(msg, actionBack) {
+			// Stop waiting, the login may still finish in the other terminal
+			m.external = false
+		}
+		return m, nil
+	}
+	
+	switch {
+	case keys.Matches(msg, actionLogin):
 		cmd := m.StartAuthentication()
 		return m, cmd
-	case "n":
-		// Go back to targets
-		return m, func() tea.Msg {
-			return SwitchViewMsg{View: ViewTargets}
+	case keys.Matches(msg, actionLoginTerminal):
+		cmd := m.StartExternalLogin()
+		return m, cmd
+	case keys.Matches(msg, actionPasswordLogin):
+		cmd := m.OpenCredentials()
+		return m, cmd
+	case keys.Matches(msg, actionTokenLogin):
+		cmd := m.OpenTokenPaste()
+		return m, cmd
+	case keys.Matches(msg, actionCancel), keys.Matches(msg, actionBack):
+		// Go back to wherever we came from
+		return m, navigateBack()
+	}
+	
+	return m, nil
+}
+
+// updateCredentials handles keys while the username/password form is open
+func (m AuthViewModel) updateCredentials(msg tea.KeyMsg) (AuthViewModel, tea.Cmd) {
+	switch msg.String() {
+	case "tab", "down":
+		return m.focusField((m.focused + 1) % len(m.inputs))
+	case "shift+tab", "up":
+		return m.focusField((m.focused - 1 + len(m.inputs)) % len(m.inputs))
+	case "enter":
+		if strings.TrimSpace(m.inputs[0].Value()) == "" || m.inputs[1].Value() == "" {
+			// Move on to the missing field instead of submitting
+			return m.focusField((m.focused + 1) % len(m.inputs))
 		}
+		cmd := m.StartPasswordLogin()
+		return m, cmd
 	case "esc":
-		return m, func() tea.Msg {
-			return SwitchViewMsg{View: ViewTargets}
-		}
+		m.credentials = false
+		return m, nil
 	}
 	
-	return m, nil
+	return m.UpdateInput(msg)
+}
+
+// UpdateInput passes a message, like a key or a cursor blink, to the focused
+// field of the credentials form or the token field
+func (m AuthViewModel) UpdateInput(msg tea.Msg) (AuthViewModel, tea.Cmd) {
+	if m.pasting {
+		var cmd tea.Cmd
+		m.token, cmd = m.token.Update(msg)
+		return m, cmd
+	}
+	if !m.credentials {
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.inputs[m.focused], cmd = m.inputs[m.focused].Update(msg)
+	return m, cmd
 }
 
 // HandleAuthResult handles authentication result message
@@ -94,10 +331,24 @@ func (m AuthViewModel) HandleAuthResult(msg AuthenticationMsg) (AuthViewModel, t
 	m.success = msg.Success
 	m.error = msg.Error
 	
+	if m.credentials && !m.success {
+		// Keep the username but have the password typed again
+		m.inputs[1].SetValue("")
+		return m.focusField(1)
+	}
+	if m.pasting && !m.success {
+		m.token.SetValue("")
+		return m, m.token.Focus()
+	}
+	
 	if m.success {
-		// Authentication successful, go to pipelines
+		// Authentication successful, resume the interrupted view
+		resume := m.resume
+		if resume.Target != msg.Target {
+			resume = SwitchViewMsg{View: ViewPipelines, Target: msg.Target, Replace: true}
+		}
 		return m, func() tea.Msg {
-			return SwitchViewMsg{View: ViewPipelines, Target: msg.Target}
+			return resume
 		}
 	}
 	
@@ -107,7 +358,7 @@ func (m AuthViewModel) HandleAuthResult(msg AuthenticationMsg) (AuthViewModel, t
 // View renders the authentication view
 func (m AuthViewModel) View(width, height int) string {
 	titleStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("205")).
+		Foreground(theme.Primary).
 		Bold(true).
 		MarginBottom(2)
 	
@@ -116,21 +367,26 @@ func (m AuthViewModel) View(width, height int) string {
 		MarginBottom(1)
 	
 	errorStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("196")).
+		Foreground(theme.Error).
 		Bold(true)
 	
 	successStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("46")).
+		Foreground(theme.Success).
 		Bold(true)
 	
 	promptStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("240")).
+		Foreground(theme.Muted).
 		Italic(true)
 	
 	var content strings.Builder
 	
-	if m.authenticating {
-		content.WriteString(titleStyle.Render("Authenticating..."))
+	if m.authenticating && m.credentials {
+		content.WriteString(m.loader.View(titleStyle.Render("Authenticating...")))
+		content.WriteString("\n\n")
+		content.WriteString(contentStyle.Render(fmt.Sprintf("Logging in to %s as %s...", m.target.Name, strings.TrimSpace(m.inputs[0].Value()))))
+		
+	} else if m.authenticating {
+		content.WriteString(m.loader.View(titleStyle.Render("Authenticating...")))
 		content.WriteString("\n\n")
 		content.WriteString(contentStyle.Render("Opening browser for authentication..."))
 		content.WriteString("\n")
@@ -138,21 +394,41 @@ func (m AuthViewModel) View(width, height int) string {
 		content.WriteString("\n\n")
 		content.WriteString(promptStyle.Render("Waiting for authentication to complete..."))
 		
+	} else if m.authenticating && m.pasting {
+		content.WriteString(m.loader.View(titleStyle.Render("Authenticating...")))
+		content.WriteString("\n\n")
+		content.WriteString(contentStyle.Render(fmt.Sprintf("Checking the token with %s...", m.target.Name)))
+		
+	} else if m.external {
+		content.WriteString(m.loader.View(titleStyle.Render("Waiting for Login...")))
+		content.WriteString("\n\n")
+		content.WriteString(contentStyle.Render("fly login is running in a new window:\n" + m.externalCommand))
+		content.WriteString("\n")
+		content.WriteString(contentStyle.Render("Complete the login there. FlyBy continues as soon as " + m.target.Name + " is logged in."))
+		content.WriteString("\n\n")
+		content.WriteString(promptStyle.Render(fmt.Sprintf("Press %s or %s to stop waiting", keys.Label(actionCancel), keys.Label(actionBack))))
+		
 	} else if m.success {
 		content.WriteString(titleStyle.Render("Authentication Successful!"))
 		content.WriteString("\n\n")
 		content.WriteString(successStyle.Render("✓ Successfully logged in to " + m.target.Name))
 		content.WriteString("\n")
-		content.WriteString(contentStyle.Render("Redirecting to pipelines..."))
+		content.WriteString(contentStyle.Render("Resuming..."))
+		
+	} else if m.credentials {
+		content.WriteString(m.credentialsView(titleStyle, contentStyle, errorStyle, promptStyle))
+		
+	} else if m.pasting {
+		content.WriteString(m.tokenView(titleStyle, contentStyle, errorStyle, promptStyle))
 		
 	} else if m.error != nil {
 		content.WriteString(titleStyle.Render("Authentication Failed"))
 		content.WriteString("\n\n")
-		content.WriteString(errorStyle.Render("✗ " + m.error.Error()))
+		content.WriteString(errorStyle.Render("✗ " + errorSummary(m.error)))
 		content.WriteString("\n\n")
 		content.WriteString(contentStyle.Render("Would you like to try again?"))
 		content.WriteString("\n\n")
-		content.WriteString(promptStyle.Render("Press Enter/y to retry, n to go back, or Esc to cancel"))
+		content.WriteString(promptStyle.Render(fmt.Sprintf("Press %s to retry, %s to log in from a new window, %s to paste a token, %s to use a username and password, %s to go back, or %s to cancel", keys.Label(actionLogin), keys.Label(actionLoginTerminal), keys.Label(actionTokenLogin), keys.Label(actionPasswordLogin), keys.Label(actionCancel), keys.Label(actionBack))))
 		
 	} else {
 		content.WriteString(titleStyle.Render("Authentication Required"))
@@ -163,12 +439,111 @@ func (m AuthViewModel) View(width, height int) string {
 		content.WriteString("\n")
 		content.WriteString(contentStyle.Render(fmt.Sprintf("URL: %s", m.target.GetURL())))
 		content.WriteString("\n\n")
-		content.WriteString(contentStyle.Render("You need to log in to access this Concourse instance."))
+		if m.target.TokenExpired() {
+			content.WriteString(contentStyle.Render("Your token for this Concourse instance has expired."))
+		} else {
+			content.WriteString(contentStyle.Render("You need to log in to access this Concourse instance."))
+		}
 		content.WriteString("\n")
-		content.WriteString(contentStyle.Render("This will open your browser for authentication."))
+		content.WriteString(contentStyle.Render("This will open your browser for authentication. To follow fly's login prompts, run it in a new tmux or terminal window instead. Over SSH, open the login page yourself and paste the token it shows, or log in with a local user's username and password."))
+		content.WriteString("\n\n")
+		content.WriteString(promptStyle.Render(fmt.Sprintf("Press %s to login, %s to log in from a new window, %s to paste a token, %s to use a username and password, %s to go back, or %s to cancel", keys.Label(actionLogin), keys.Label(actionLoginTerminal), keys.Label(actionTokenLogin), keys.Label(actionPasswordLogin), keys.Label(actionCancel), keys.Label(actionBack))))
+	}
+	
+	return content.String()
+}
+
+// credentialsView renders the username/password form
+func (m AuthViewModel) credentialsView(titleStyle, contentStyle, errorStyle, promptStyle lipgloss.Style) string {
+	labelStyle := lipgloss.NewStyle().
+		Bold(true).
+		Width(10)
+	
+	inputStyle := lipgloss.NewStyle().
+		Border(lipgloss.NormalBorder()).
+		BorderForeground(theme.Muted).
+		Padding(0, 1).
+		Width(40)
+	
+	focusedInputStyle := inputStyle.Copy().
+		BorderForeground(theme.Primary)
+	
+	var content strings.Builder
+	content.WriteString(titleStyle.Render("Log In with Username and Password"))
+	content.WriteString("\n\n")
+	content.WriteString(contentStyle.Render(fmt.Sprintf("Target: %s (team %s)", m.target.Name, m.target.Team)))
+	content.WriteString("\n")
+	if m.error != nil {
+		content.WriteString(errorStyle.Render("✗ " + errorSummary(m.error)))
 		content.WriteString("\n\n")
-		content.WriteString(promptStyle.Render("Press Enter/y to login, n to go back, or Esc to cancel"))
 	}
 	
+	for i, label := range []string{"Username", "Password"} {
+		style := inputStyle
+		if i == m.focused {
+			style = focusedInputStyle
+		}
+		content.WriteString(lipgloss.JoinHorizontal(lipgloss.Center, labelStyle.Render(label+":"), style.Render(m.inputs[i].View())))
+		content.WriteString("\n")
+	}
+	
+	content.WriteString("\n")
+	content.WriteString(promptStyle.Render("Only local users can log in this way. Tab: next field • Enter: login • Esc: back to browser login"))
 	return content.String()
-}
\ No newline at end of file
+}
+
+// tokenView renders the login page link and the token field
+func (m AuthViewModel) tokenView(titleStyle, contentStyle, errorStyle, promptStyle lipgloss.Style) string {
+	inputStyle := lipgloss.NewStyle().
+		Border(lipgloss.NormalBorder()).
+		BorderForeground(theme.Primary).
+		Padding(0, 1).
+		Width(60)
+	
+	var content strings.Builder
+	content.WriteString(titleStyle.Render("Log In with a Token"))
+	content.WriteString("\n\n")
+	content.WriteString(contentStyle.Render(fmt.Sprintf("Target: %s (team %s)", m.target.Name, m.target.Team)))
+	content.WriteString("\n")
+	content.WriteString(contentStyle.Render("1. Open this page in a browser on any machine and log in:"))
+	content.WriteString("\n")
+	// Unstyled, so the link stays whole for copying even when the terminal wraps it
+	content.WriteString(m.tokenLoginURL())
+	content.WriteString("\n")
+	content.WriteString(contentStyle.Render("2. Copy the token the page shows and paste it here:"))
+	content.WriteString("\n")
+	if m.error != nil {
+		content.WriteString(errorStyle.Render("✗ " + errorSummary(m.error)))
+		content.WriteString("\n\n")
+	}
+	content.WriteString(inputStyle.Render(m.token.View()))
+	content.WriteString("\n\n")
+	content.WriteString(promptStyle.Render("FlyBy saves the token to the flyrc like fly login does. Enter: login • Esc: back"))
+	return content.String()
+}
+
+// saveToken writes a pasted token to the flyrc, then checks with fly that
+// the target accepts it
+func (m *Model) saveToken(msg TokenPastedMsg) tea.Cmd {
+	failed := func(err error) tea.Cmd {
+		return func() tea.Msg {
+			return AuthenticationMsg{Success: false, Error: err, Target: msg.Target}
+		}
+	}
+	token, err := config.ParseToken(msg.Token)
+	if err != nil {
+		return failed(err)
+	}
+	if err := m.configManager.SetToken(msg.Target, token); err != nil {
+		return failed(fmt.Errorf("failed to save the token: %w", err))
+	}
+	
+	client := m.authView.client
+	return func() tea.Msg {
+		loggedIn, err := client.Status()
+		if err == nil && !loggedIn {
+			err = fmt.Errorf("%s didn't accept the token", msg.Target)
+		}
+		return AuthenticationMsg{Success: loggedIn, Error: err, Target: msg.Target}
+	}
+}
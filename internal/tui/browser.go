@@ -0,0 +1,121 @@
+package tui
+
+import (
+	"fmt"
+	"net/url"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"flyby/internal/concourse"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// webLocation is a page of the Concourse web UI: a pipeline, or one of its
+// jobs, builds or resources
+type webLocation struct {
+	team     string
+	pipeline string
+	vars     concourse.InstanceVars
+	job      string
+	build    string // of job
+	resource string
+}
+
+// url returns the address of the page on the Concourse at api
+func (l webLocation) url(api string) string {
+	path := []string{"teams", l.team, "pipelines", l.pipeline}
+	switch {
+	case l.resource != "":
+		path = append(path, "resources", l.resource)
+	case l.build != "":
+		path = append(path, "jobs", l.job, "builds", l.build)
+	case l.job != "":
+		path = append(path, "jobs", l.job)
+	}
+	for i, segment := range path {
+		path[i] = url.PathEscape(segment)
+	}
+
+	address := strings.TrimRight(api, "/") + "/" + strings.Join(path, "/")
+	if len(l.vars) > 0 {
+		address += "?" + l.vars.Query()
+	}
+	return address
+}
+
+// selectedLocation returns the web UI page of the pipeline, job, build or
+// resource selected in the current view, if it has one
+func (m *Model) selectedLocation() (webLocation, bool) {
+	switch m.currentView {
+	case ViewPipelines:
+		if m.pipelinesView.selected < len(m.pipelinesView.filteredPipelines) {
+			pipeline := m.pipelinesView.filteredPipelines[m.pipelinesView.selected]
+			return webLocation{team: pipeline.TeamName, pipeline: pipeline.Name, vars: pipeline.InstanceVars}, true
+		}
+	case ViewJobs:
+		if m.jobsView.selected < len(m.jobsView.filteredJobs) {
+			job := m.jobsView.filteredJobs[m.jobsView.selected]
+			return webLocation{team: job.TeamName, pipeline: job.PipelineName, vars: job.PipelineInstanceVars, job: job.Name}, true
+		}
+	case ViewBuilds:
+		if m.buildsView.state != buildsStateLoading && m.buildsView.cursor < len(m.buildsView.builds) {
+			build := m.buildsView.builds[m.buildsView.cursor]
+			return webLocation{team: build.TeamName, pipeline: build.PipelineName, vars: build.PipelineInstanceVars,
+				job: build.JobName, build: build.Name}, true
+		}
+	case ViewResources:
+		if m.resourcesView.selected < len(m.resourcesView.filteredResources) {
+			resource := m.resourcesView.filteredResources[m.resourcesView.selected]
+			return webLocation{team: resource.TeamName, pipeline: resource.PipelineName, vars: resource.PipelineInstanceVars,
+				resource: resource.Name}, true
+		}
+	}
+	return webLocation{}, false
+}
+
+// openSelected opens the selected pipeline, job, build or resource in the
+// browser, or returns nil when the current view has nothing to open
+func (m *Model) openSelected() tea.Cmd {
+	location, ok := m.selectedLocation()
+	if !ok {
+		return nil
+	}
+	target, exists := m.configManager.GetTarget(m.currentTarget)
+	if !exists || target.GetURL() == "" {
+		return showToast(ToastError, "No URL known for target %s", m.currentTarget)
+	}
+	if location.team == "" {
+		location.team = target.Team
+	}
+
+	address := location.url(target.GetURL())
+	return func() tea.Msg {
+		if err := openBrowser(address); err != nil {
+			return ToastMsg{Level: ToastError, Text: fmt.Sprintf("Failed to open %s: %v", address, err)}
+		}
+		return ToastMsg{Level: ToastInfo, Text: "Opened " + address}
+	}
+}
+
+// openBrowser opens address with the platform's tool for opening URLs
+func openBrowser(address string) error {
+	var name string
+	var args []string
+	switch runtime.GOOS {
+	case "darwin":
+		name, args = "open", []string{address}
+	case "windows":
+		// The empty title keeps start from taking the address for one, and cmd
+		// would split the command at the &s between instance vars
+		name, args = "cmd", []string{"/c", "start", "", strings.ReplaceAll(address, "&", "^&")}
+	default:
+		name, args = "xdg-open", []string{address}
+	}
+
+	if _, err := exec.LookPath(name); err != nil {
+		return fmt.Errorf("%s isn't installed", name)
+	}
+	return exec.Command(name, args...).Start()
+}
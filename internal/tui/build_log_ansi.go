@@ -0,0 +1,55 @@
+package tui
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// logEscape matches the escape sequences tasks write to their output: CSI
+// sequences like colors and cursor movement, OSC sequences like window
+// titles and hyperlinks, and two character escapes
+var logEscape = regexp.MustCompile(`\x1b(?:\[[0-?]*[ -/]*[@-~]|\][^\x07\x1b]*(?:\x07|\x1b\\)?|[@-Z\\-_])`)
+
+// logColors carries the colors a build log sets from one line to the next,
+// since tasks often color several lines with a single escape sequence
+type logColors struct {
+	active string // the color sequences in effect since the last reset
+}
+
+// apply keeps only the color sequences of a line, the others would move the
+// cursor around the view. The line starts with the colors earlier lines left
+// set and resets them at its end, so it renders the same on its own.
+func (c *logColors) apply(line string) string {
+	inherited := c.active
+	colored := false
+	line = logEscape.ReplaceAllStringFunc(line, func(sequence string) string {
+		if !strings.HasPrefix(sequence, "\x1b[") || !strings.HasSuffix(sequence, "m") {
+			return ""
+		}
+		colored = true
+		switch params := sequence[2 : len(sequence)-1]; {
+		case params == "" || params == "0":
+			c.active = ""
+		case strings.HasPrefix(params, "0;"):
+			c.active = sequence
+		default:
+			c.active += sequence
+		}
+		return sequence
+	})
+	if inherited == "" && !colored {
+		return line
+	}
+	return inherited + line + "\x1b[0m"
+}
+
+// showLogLine returns a log line as the view shows it, without its colors
+// when colors are disabled
+func showLogLine(line string) string {
+	if theme.Name == plainTheme.Name {
+		return ansi.Strip(line)
+	}
+	return line
+}
@@ -0,0 +1,156 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"flyby/internal/config"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// logExport asks where to save the build log, and whether with its colors
+type logExport struct {
+	path    textinput.Model
+	colored bool
+}
+
+// logFileUnsafe matches runs of characters kept out of the suggested file
+// name, like the quotes, colons and slashes of instance vars
+var logFileUnsafe = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// logFileName suggests a file name for the log, e.g. "app-deploy-4.log", or
+// "app-branch-main-deploy-4.log" for an instanced pipeline
+func (m BuildLogViewModel) logFileName() string {
+	name, vars, _ := strings.Cut(m.pipeline, "/")
+	var parts []string
+	for _, part := range []string{name, vars, m.job, m.build} {
+		if part = strings.Trim(logFileUnsafe.ReplaceAllString(part, "-"), "-"); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, "-") + ".log"
+}
+
+// startExport asks for the path to save the log to, suggesting a file named
+// after the build in the working directory
+func (m *BuildLogViewModel) startExport() tea.Cmd {
+	path := textinput.New()
+	path.Prompt = ""
+	path.Width = 60
+	path.SetValue(m.logFileName())
+	path.CursorEnd()
+	m.export = &logExport{path: path}
+	return tea.Batch(m.export.path.Focus(), textinput.Blink)
+}
+
+// updateExport handles keys while the path is asked for, saving the log on
+// enter once an existing file may be overwritten
+func (m BuildLogViewModel) updateExport(msg tea.KeyMsg) (BuildLogViewModel, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		path := config.ExpandHome(strings.TrimSpace(m.export.path.Value()))
+		if path == "" {
+			return m, showToast(ToastError, "Enter the path to save the log to")
+		}
+		if abs, err := filepath.Abs(path); err == nil {
+			path = abs
+		}
+		save := saveLog(path, m.exportText(m.export.colored), len(m.lines), m.running)
+		m.export = nil
+		if info, err := os.Stat(path); err == nil {
+			if info.IsDir() {
+				return m, showToast(ToastError, "%s is a directory", path)
+			}
+			return m, confirmAction("Overwrite file", fmt.Sprintf("%s already exists. Replace it with the build log?", path), save)
+		}
+		return m, save
+	case "esc":
+		m.export = nil
+		return m, nil
+	case "tab":
+		m.export.colored = !m.export.colored
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.export.path, cmd = m.export.path.Update(msg)
+	return m, cmd
+}
+
+// UpdateInput passes a message, like a cursor blink, to the path input
+func (m BuildLogViewModel) UpdateInput(msg tea.Msg) (BuildLogViewModel, tea.Cmd) {
+	if m.export == nil {
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.export.path, cmd = m.export.path.Update(msg)
+	return m, cmd
+}
+
+// exportText returns the whole log to save, with the times shown in the view
+// and with the colors the build printed or none at all
+func (m BuildLogViewModel) exportText(colored bool) string {
+	var text strings.Builder
+	for i, line := range m.lines {
+		if stamp := m.stampText(i); stamp != "" {
+			text.WriteString(stamp + " ")
+		}
+		if !colored {
+			line = ansi.Strip(line)
+		}
+		text.WriteString(line)
+		text.WriteString("\n")
+	}
+	return text.String()
+}
+
+// saveLog returns a command that writes a log to path, telling how it went
+func saveLog(path, text string, lines int, running bool) tea.Cmd {
+	return func() tea.Msg {
+		if err := os.WriteFile(path, []byte(text), 0644); err != nil {
+			var pathErr *os.PathError
+			if errors.As(err, &pathErr) {
+				err = pathErr.Err
+			}
+			return ToastMsg{Level: ToastError, Text: fmt.Sprintf("Failed to save the log to %s: %v", path, err)}
+		}
+		saved := fmt.Sprintf("Saved %d lines to %s", lines, path)
+		if running {
+			saved += ", the build is still running"
+		}
+		return ToastMsg{Level: ToastSuccess, Text: saved}
+	}
+}
+
+// exportView renders the prompt for the path below the log
+func (m BuildLogViewModel) exportView(width int) string {
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Primary).
+		Padding(0, 1).
+		Width(min(width-2, 70))
+	titleStyle := lipgloss.NewStyle().
+		Foreground(theme.Primary).
+		Bold(true)
+	mutedStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+
+	colors := "without colors"
+	if m.export.colored {
+		colors = "with colors, for viewing with less -R or cat"
+	}
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render("Save build log"))
+	content.WriteString("\n\n")
+	content.WriteString(m.export.path.View())
+	content.WriteString("\n\n")
+	content.WriteString(mutedStyle.Render(fmt.Sprintf("%d lines, %s", len(m.lines), colors)))
+	return boxStyle.Render(content.String())
+}
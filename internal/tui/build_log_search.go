@@ -0,0 +1,168 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// logSearch is a search of the build log, found again in lines as they arrive
+type logSearch struct {
+	query   string
+	typing  bool  // the query is being typed
+	from    int   // the line shown at the top when the search started
+	matches []int // lines matching the query, in order
+	current int   // index in matches of the match shown
+}
+
+// logLineMatches reports whether a log line contains the query, ignoring
+// case and color codes
+func logLineMatches(line, query string) bool {
+	return strings.Contains(strings.ToLower(ansi.Strip(line)), strings.ToLower(query))
+}
+
+// startSearch starts typing a new search from the lines shown
+func (m *BuildLogViewModel) startSearch(page int) {
+	m.search = logSearch{typing: true}
+	if rows, top := m.rows(), m.top(page); top < len(rows) {
+		m.search.from = rows[top]
+	}
+}
+
+// updateSearch handles keys while the search is typed, showing the first
+// match from where the search started as the query changes
+func (m BuildLogViewModel) updateSearch(msg tea.KeyMsg, page int) BuildLogViewModel {
+	switch msg.String() {
+	case "enter":
+		m.search.typing = false
+		if m.search.query == "" {
+			m.search = logSearch{}
+		}
+		return m
+	case "esc":
+		m.search = logSearch{}
+		return m
+	case "backspace":
+		if len(m.search.query) > 0 {
+			m.search.query = m.search.query[:len(m.search.query)-1]
+		}
+	case "ctrl+u":
+		m.search.query = ""
+	default:
+		if len(msg.String()) != 1 {
+			return m
+		}
+		m.search.query += msg.String()
+	}
+
+	m.search.matches = nil
+	if m.search.query == "" {
+		return m
+	}
+	for i, line := range m.lines {
+		if logLineMatches(line, m.search.query) {
+			m.search.matches = append(m.search.matches, i)
+		}
+	}
+	m.search.current = sort.SearchInts(m.search.matches, m.search.from) % max(len(m.search.matches), 1)
+	m.showMatch(page)
+	return m
+}
+
+// searchLines finds the search in lines that arrived, the first of which is
+// line from of the log
+func (m *BuildLogViewModel) searchLines(lines []string, from int) {
+	if m.search.query == "" {
+		return
+	}
+	for i, line := range lines {
+		if logLineMatches(line, m.search.query) {
+			m.search.matches = append(m.search.matches, from+i)
+		}
+	}
+}
+
+// nextMatch shows the match delta matches away from the current one,
+// wrapping around at either end of the log
+func (m *BuildLogViewModel) nextMatch(delta, page int) tea.Cmd {
+	count := len(m.search.matches)
+	if m.search.query == "" {
+		return nil
+	}
+	if count == 0 {
+		return showToast(ToastInfo, "No lines match %q", m.search.query)
+	}
+	m.search.current = ((m.search.current+delta)%count + count) % count
+	m.showMatch(page)
+	return nil
+}
+
+// showMatch scrolls the current match into view, a third down the page,
+// expanding the step it is in
+func (m *BuildLogViewModel) showMatch(page int) {
+	if len(m.search.matches) == 0 {
+		return
+	}
+	row := m.rowOf(m.search.matches[m.search.current])
+	m.offset = max(min(row-page/3, len(m.rows())-page), 0)
+	m.follow = false
+}
+
+// isMatch reports whether a line matches the search, and whether it is the
+// current match
+func (m BuildLogViewModel) isMatch(line int) (match, current bool) {
+	i := sort.SearchInts(m.search.matches, line)
+	if i == len(m.search.matches) || m.search.matches[i] != line {
+		return false, false
+	}
+	return true, i == m.search.current
+}
+
+// highlightMatches renders a matching line without its colors and with every
+// occurrence of the query highlighted
+func highlightMatches(line, query string, current bool) string {
+	style := lipgloss.NewStyle().Reverse(true)
+	if current {
+		style = style.Foreground(theme.Warning).Bold(true)
+	}
+
+	plain := ansi.Strip(line)
+	lower := strings.ToLower(plain)
+	query = strings.ToLower(query)
+	if len(lower) != len(plain) {
+		// Lowercasing changed the byte offsets, the line can't be cut up
+		return plain
+	}
+
+	var highlighted strings.Builder
+	for {
+		i := strings.Index(lower, query)
+		if i < 0 {
+			break
+		}
+		highlighted.WriteString(plain[:i])
+		highlighted.WriteString(style.Render(plain[i : i+len(query)]))
+		plain, lower = plain[i+len(query):], lower[i+len(query):]
+	}
+	highlighted.WriteString(plain)
+	return highlighted.String()
+}
+
+// searchStatus describes the search for the status line, or returns ""
+// without one
+func (m BuildLogViewModel) searchStatus() string {
+	switch {
+	case m.search.typing:
+		return fmt.Sprintf("search: %s█ • %d matches", m.search.query, len(m.search.matches))
+	case m.search.query == "":
+		return ""
+	case len(m.search.matches) == 0:
+		return fmt.Sprintf("no lines match %q", m.search.query)
+	default:
+		return fmt.Sprintf("match %d of %d for %q", m.search.current+1, len(m.search.matches), m.search.query)
+	}
+}
@@ -0,0 +1,187 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// logStepMarkers start the lines fly watch begins the output of a step with,
+// e.g. of fetching a resource or running a task
+var logStepMarkers = []string{"initializing", "selected worker:", "fetching ", "running "}
+
+// logStep is a step of the build log, a run of lines from one that starts a
+// step to the next one
+type logStep struct {
+	start       int  // index of its first line, which the view shows when it is collapsed
+	end         int  // one past its last line
+	markersOnly bool // no lines but ones starting steps yet
+}
+
+// isStepMarker reports whether a log line starts a step
+func isStepMarker(line string) bool {
+	plain := strings.TrimSpace(ansi.Strip(line))
+	for _, marker := range logStepMarkers {
+		if strings.HasPrefix(plain, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// findSteps adds the lines that arrived to the steps, the first of them
+// being line from of the log. Lines starting steps that follow each other,
+// like initializing and selected worker, start one step together.
+func (m *BuildLogViewModel) findSteps(lines []string, from int) {
+	for i, line := range lines {
+		last := len(m.steps) - 1
+		switch {
+		case !isStepMarker(line):
+			if last >= 0 {
+				m.steps[last].markersOnly = false
+			}
+		case last < 0 || !m.steps[last].markersOnly:
+			m.steps = append(m.steps, logStep{start: from + i, markersOnly: true})
+			last++
+		}
+		if last >= 0 {
+			m.steps[last].end = from + i + 1
+		}
+	}
+}
+
+// stepOf returns the index of the step a line belongs to, or -1 for lines
+// before the first step
+func (m BuildLogViewModel) stepOf(line int) int {
+	return sort.Search(len(m.steps), func(i int) bool {
+		return m.steps[i].start > line
+	}) - 1
+}
+
+// rows returns the lines the view shows, which are all but the ones after
+// the first line of collapsed steps
+func (m BuildLogViewModel) rows() []int {
+	rows := make([]int, 0, len(m.lines))
+	for i := 0; i < len(m.lines); i++ {
+		rows = append(rows, i)
+		if step := m.stepOf(i); step >= 0 && m.steps[step].start == i && m.collapsed[i] {
+			i = m.steps[step].end - 1
+		}
+	}
+	return rows
+}
+
+// rowOf returns the row showing a line, expanding the step hiding it
+func (m *BuildLogViewModel) rowOf(line int) int {
+	if step := m.stepOf(line); step >= 0 && m.steps[step].start != line {
+		delete(m.collapsed, m.steps[step].start)
+	}
+	rows := m.rows()
+	return sort.SearchInts(rows, line)
+}
+
+// currentStep returns the step chosen with the step keys, or else the one at
+// the top of the view, or -1 when there is none
+func (m BuildLogViewModel) currentStep(page int) int {
+	if m.step >= 0 && m.step < len(m.steps) {
+		return m.step
+	}
+	rows := m.rows()
+	if top := m.top(page); top < len(rows) {
+		return max(m.stepOf(rows[top]), min(0, len(m.steps)-1))
+	}
+	return -1
+}
+
+// nextStep chooses the step delta steps away from the current one and
+// scrolls its first line to the top of the view
+func (m *BuildLogViewModel) nextStep(delta, page int) {
+	if len(m.steps) == 0 {
+		return
+	}
+	if m.step < 0 || m.step >= len(m.steps) {
+		m.step = m.currentStep(page)
+		if delta > 0 && m.rowOf(m.steps[m.step].start) >= m.top(page) {
+			delta--
+		}
+	}
+	m.step = max(min(m.step+delta, len(m.steps)-1), 0)
+	m.showStep(page)
+}
+
+// showStep scrolls the chosen step's first line to the top of the view
+func (m *BuildLogViewModel) showStep(page int) {
+	row := m.rowOf(m.steps[m.step].start)
+	m.offset = max(min(row, len(m.rows())-page), 0)
+	m.follow = false
+}
+
+// toggleStep collapses the current step to its first line, or expands it
+func (m *BuildLogViewModel) toggleStep(page int) {
+	step := m.currentStep(page)
+	if step < 0 {
+		return
+	}
+	start := m.steps[step].start
+	if m.collapsed[start] {
+		delete(m.collapsed, start)
+	} else {
+		m.collapsed[start] = true
+	}
+	m.step = step
+	if !m.follow {
+		m.showStep(page)
+	}
+}
+
+// toggleAllSteps collapses every step, or expands them all when they all are
+func (m *BuildLogViewModel) toggleAllSteps(page int) {
+	expanded := false
+	for _, step := range m.steps {
+		if !m.collapsed[step.start] {
+			expanded = true
+		}
+	}
+	m.collapsed = make(map[int]bool)
+	if expanded {
+		for _, step := range m.steps {
+			m.collapsed[step.start] = true
+		}
+	}
+	if m.step >= 0 && m.step < len(m.steps) && !m.follow {
+		m.showStep(page)
+	}
+}
+
+// stepLine renders a log line with the outline of the steps: an arrow on
+// their first line telling whether they are collapsed, brighter for the
+// current step, and the other lines indented
+func (m BuildLogViewModel) stepLine(line int, text string, current int) string {
+	if len(m.steps) == 0 {
+		return text
+	}
+	step := m.stepOf(line)
+	if step < 0 || m.steps[step].start != line {
+		return "  " + text
+	}
+
+	arrowStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+	if step == current {
+		arrowStyle = lipgloss.NewStyle().Foreground(theme.Primary).Bold(true)
+	}
+	if !m.collapsed[line] {
+		return arrowStyle.Render("▾ ") + text
+	}
+	var hidden string
+	switch more := m.steps[step].end - m.steps[step].start - 1; more {
+	case 0:
+	case 1:
+		hidden = " (1 more line)"
+	default:
+		hidden = fmt.Sprintf(" (%d more lines)", more)
+	}
+	return arrowStyle.Render("▸ ") + text + lipgloss.NewStyle().Foreground(theme.Muted).Render(hidden)
+}
@@ -0,0 +1,107 @@
+package tui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// logTimestamps is how the build log shows the time of each line
+type logTimestamps int
+
+const (
+	logTimestampsOff     logTimestamps = iota
+	logTimestampsClock                 // the time of day fly reported
+	logTimestampsElapsed               // the time since the first line
+)
+
+// logTimestampsLabels describes each way of showing times for the view
+var logTimestampsLabels = map[logTimestamps]string{
+	logTimestampsOff:     "no times",
+	logTimestampsClock:   "time of day",
+	logTimestampsElapsed: "time since the start",
+}
+
+// logStamp matches the time fly watch --timestamps starts lines with,
+// bolded or not
+var logStamp = regexp.MustCompile(`^(?:\x1b\[[0-9;]*m)*(\d{2}:\d{2}:\d{2})(?:\x1b\[[0-9;]*m)*(?:[ \t]+|$)`)
+
+// splitStamp separates the time fly starts a line with from the rest of it,
+// returning no time for lines without one
+func splitStamp(line string) (stamp, text string) {
+	match := logStamp.FindStringSubmatchIndex(line)
+	if match == nil {
+		return "", line
+	}
+	return line[match[2]:match[3]], line[match[1]:]
+}
+
+// stampLines adds the times of lines that arrived, giving lines without one
+// the time of the line before them
+func (m *BuildLogViewModel) stampLines(stamps []string) {
+	for _, stamp := range stamps {
+		if stamp == "" && len(m.stamps) > 0 {
+			stamp = m.stamps[len(m.stamps)-1]
+		}
+		m.stamps = append(m.stamps, stamp)
+	}
+}
+
+// toggleTimestamps switches to the next way of showing times
+func (m *BuildLogViewModel) toggleTimestamps() tea.Cmd {
+	m.timestamps = (m.timestamps + 1) % logTimestamps(len(logTimestampsLabels))
+	if m.timestamps != logTimestampsOff && len(m.stamps) > 0 && m.stamps[len(m.stamps)-1] == "" {
+		m.timestamps = logTimestampsOff
+		return showToast(ToastInfo, "fly printed no times for this log, it may be too old for fly watch --timestamps")
+	}
+	return nil
+}
+
+// stampPrefix returns the time shown before a line, or "" without times
+func (m BuildLogViewModel) stampPrefix(line int) string {
+	stamp := m.stampText(line)
+	if stamp == "" {
+		return ""
+	}
+	return lipgloss.NewStyle().Foreground(theme.Muted).Render(stamp) + " "
+}
+
+// stampText returns the time of a line as the view shows it, blank for
+// lines without one, or "" without times
+func (m BuildLogViewModel) stampText(line int) string {
+	if m.timestamps == logTimestampsOff || line >= len(m.stamps) {
+		return ""
+	}
+	stamp, width := m.stamps[line], len("15:04:05")
+	if m.timestamps == logTimestampsElapsed {
+		stamp, width = elapsedStamp(m.stamps[0], stamp), len("+00:00:00")
+	}
+	if stamp == "" {
+		stamp = strings.Repeat(" ", width)
+	}
+	return stamp
+}
+
+// elapsedStamp returns the time from the first stamp to another, e.g.
+// "+00:01:05", counting a stamp earlier in the day than the first as the day
+// after, or "" when either is missing
+func elapsedStamp(first, stamp string) string {
+	start, err := time.Parse("15:04:05", first)
+	if err != nil {
+		return ""
+	}
+	at, err := time.Parse("15:04:05", stamp)
+	if err != nil {
+		return ""
+	}
+	elapsed := at.Sub(start)
+	if elapsed < 0 {
+		elapsed += 24 * time.Hour
+	}
+	seconds := int(elapsed.Seconds())
+	return fmt.Sprintf("+%02d:%02d:%02d", seconds/3600, seconds/60%60, seconds%60)
+}
@@ -0,0 +1,406 @@
+package tui
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"flyby/internal/concourse"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// buildLogChrome is how many lines around the log the view takes: the title
+// with its margin and the status line above, the help below
+const buildLogChrome = 7
+
+// buildLogScroll is how many lines the mouse wheel scrolls the log
+const buildLogScroll = 3
+
+// BuildLogViewModel represents the log of a build, followed while it runs
+type BuildLogViewModel struct {
+	client     *concourse.Client
+	pipeline   string
+	job        string
+	build      string
+	stream     *logStream
+	cancel     context.CancelFunc // kills fly watch
+	lines      []string
+	offset     int  // first line shown
+	follow     bool // keep the latest lines in view as they arrive
+	running    bool
+	err        error
+	loader     loader
+	search     logSearch
+	steps      []logStep
+	collapsed  map[int]bool // first lines of the collapsed steps
+	step       int          // the step chosen with the step keys, -1 for none
+	stamps     []string     // the time of each line, as fly reported it
+	timestamps logTimestamps
+	export     *logExport // asks where to save the log, while open
+}
+
+// logStream collects the output of fly watch as it arrives. Writes never
+// block, so fly can run to the end even once nobody reads the log anymore.
+type logStream struct {
+	mu      sync.Mutex
+	partial []byte
+	lines   []string
+	stamps  []string // the time fly started each line with
+	done    bool
+	err     error
+	ready   chan struct{} // signalled when lines arrive or the stream ends
+	colors  logColors
+}
+
+// BuildLogMsg carries the lines of a build log that arrived since the last one
+type BuildLogMsg struct {
+	stream *logStream
+	Lines  []string
+	Stamps []string
+	Done   bool
+	Error  error
+}
+
+// NewBuildLogViewModel creates a new build log view model
+func NewBuildLogViewModel() BuildLogViewModel {
+	return BuildLogViewModel{
+		loader:    newLoader(),
+		collapsed: make(map[int]bool),
+		step:      -1,
+	}
+}
+
+// newLogStream creates an empty log stream
+func newLogStream() *logStream {
+	return &logStream{ready: make(chan struct{}, 1)}
+}
+
+// Write collects complete lines of output
+func (s *logStream) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.partial = append(s.partial, p...)
+	for {
+		i := bytes.IndexByte(s.partial, '\n')
+		if i < 0 {
+			break
+		}
+		s.add(logLine(s.partial[:i]))
+		s.partial = s.partial[i+1:]
+	}
+	s.signal()
+	return len(p), nil
+}
+
+// close ends the stream, keeping an unterminated last line
+func (s *logStream) close(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.partial) > 0 {
+		s.add(logLine(s.partial))
+		s.partial = nil
+	}
+	s.done = true
+	s.err = err
+	s.signal()
+}
+
+// add collects a line, apart from the time fly started it with
+func (s *logStream) add(line string) {
+	stamp, text := splitStamp(line)
+	s.lines = append(s.lines, s.colors.apply(text))
+	s.stamps = append(s.stamps, stamp)
+}
+
+// signal wakes up the reader, if it isn't already due to wake up
+func (s *logStream) signal() {
+	select {
+	case s.ready <- struct{}{}:
+	default:
+	}
+}
+
+// next waits for more of the log
+func (s *logStream) next() tea.Cmd {
+	return func() tea.Msg {
+		<-s.ready
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		msg := BuildLogMsg{stream: s, Lines: s.lines, Stamps: s.stamps, Done: s.done, Error: s.err}
+		s.lines = nil
+		s.stamps = nil
+		return msg
+	}
+}
+
+// logLine cleans up a line of output: progress bars redraw their line with
+// carriage returns, only the last drawing is kept
+func logLine(line []byte) string {
+	text := strings.TrimRight(string(line), "\r")
+	if i := strings.LastIndexByte(text, '\r'); i >= 0 {
+		text = text[i+1:]
+	}
+	return text
+}
+
+// LoadLog starts following the log of a build
+func (m *BuildLogViewModel) LoadLog(client *concourse.Client, pipeline, job, build string) tea.Cmd {
+	// The log shown before is of no use anymore
+	m.Stop()
+	m.client = client
+	m.pipeline = pipeline
+	m.job = job
+	m.build = build
+	m.lines = nil
+	m.offset = 0
+	m.follow = true
+	m.running = true
+	m.err = nil
+	m.search.matches = nil
+	m.steps = nil
+	m.collapsed = make(map[int]bool)
+	m.step = -1
+	m.stamps = nil
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancel = cancel
+	stream := newLogStream()
+	m.stream = stream
+	go func() {
+		stream.close(client.WatchBuild(ctx, pipeline, job, build, true, stream))
+	}()
+	return stream.next()
+}
+
+// Stop kills fly watch, e.g. once the view is left, so it doesn't keep
+// collecting the log of a running build nobody reads. A log stopped before the
+// build finished is loaded again when the view is shown next.
+func (m *BuildLogViewModel) Stop() {
+	if m.cancel != nil {
+		m.cancel()
+		m.cancel = nil
+	}
+	if m.running {
+		m.stream = nil
+		m.running = false
+	}
+}
+
+// HandleBuildLog appends the lines that arrived, and keeps waiting for more
+// until the build has finished
+func (m BuildLogViewModel) HandleBuildLog(msg BuildLogMsg) (BuildLogViewModel, tea.Cmd) {
+	if msg.stream != m.stream {
+		// A log the view was showing before
+		return m, nil
+	}
+	m.searchLines(msg.Lines, len(m.lines))
+	m.findSteps(msg.Lines, len(m.lines))
+	m.stampLines(msg.Stamps)
+	m.lines = append(m.lines, msg.Lines...)
+	if msg.Done {
+		m.running = false
+		m.Stop()
+		m.err = msg.Error
+		return m, nil
+	}
+	return m, m.stream.next()
+}
+
+// Update handles key presses for the build log view
+func (m BuildLogViewModel) Update(msg tea.KeyMsg, height int) (BuildLogViewModel, tea.Cmd) {
+	page := m.pageSize(height)
+	if m.search.typing {
+		return m.updateSearch(msg, page), nil
+	}
+	if m.export != nil {
+		return m.updateExport(msg)
+	}
+	switch {
+	case keys.Matches(msg, actionUp):
+		m.scroll(-1, page)
+	case keys.Matches(msg, actionDown):
+		m.scroll(1, page)
+	case msg.String() == "pgup":
+		m.scroll(-page, page)
+	case msg.String() == "pgdown":
+		m.scroll(page, page)
+	case msg.String() == "home":
+		m.offset = 0
+		m.follow = false
+	case msg.String() == "end":
+		m.follow = true
+	case keys.Matches(msg, actionNextStep):
+		m.nextStep(1, page)
+	case keys.Matches(msg, actionPrevStep):
+		m.nextStep(-1, page)
+	case keys.Matches(msg, actionToggleStep):
+		m.toggleStep(page)
+	case keys.Matches(msg, actionToggleSteps):
+		m.toggleAllSteps(page)
+	case keys.Matches(msg, actionTimestamps):
+		return m, m.toggleTimestamps()
+	case keys.Matches(msg, actionSearch):
+		m.startSearch(page)
+	case keys.Matches(msg, actionNextMatch):
+		return m, m.nextMatch(1, page)
+	case keys.Matches(msg, actionPrevMatch):
+		return m, m.nextMatch(-1, page)
+	case keys.Matches(msg, actionClear):
+		m.search = logSearch{}
+	case keys.Matches(msg, actionPager):
+		if len(m.lines) > 0 {
+			return m, openPager(m.text())
+		}
+	case keys.Matches(msg, actionSaveLog):
+		if len(m.lines) > 0 {
+			return m, m.startExport()
+		}
+	case keys.Matches(msg, actionRefresh):
+		if m.client != nil {
+			cmd := m.LoadLog(m.client, m.pipeline, m.job, m.build)
+			return m, cmd
+		}
+	}
+	return m, nil
+}
+
+// text returns the whole log, with its colors unless colors are disabled and
+// the times shown in the view
+func (m BuildLogViewModel) text() string {
+	var text strings.Builder
+	for i, line := range m.lines {
+		text.WriteString(m.stampPrefix(i))
+		text.WriteString(showLogLine(line))
+		text.WriteString("\n")
+	}
+	return text.String()
+}
+
+// Mouse scrolls the log with the wheel
+func (m BuildLogViewModel) Mouse(msg tea.MouseMsg, height int) (BuildLogViewModel, tea.Cmd) {
+	if msg.Action != tea.MouseActionPress {
+		return m, nil
+	}
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		m.scroll(-buildLogScroll, m.pageSize(height))
+	case tea.MouseButtonWheelDown:
+		m.scroll(buildLogScroll, m.pageSize(height))
+	}
+	return m, nil
+}
+
+// pageSize returns how many log lines fit the given height
+func (m BuildLogViewModel) pageSize(height int) int {
+	return max(height-buildLogChrome, 1)
+}
+
+// top returns the first row shown, the last page while following
+func (m BuildLogViewModel) top(page int) int {
+	rows := len(m.rows())
+	if m.follow {
+		return max(rows-page, 0)
+	}
+	return min(m.offset, max(rows-page, 0))
+}
+
+// scroll moves the log by delta rows, following it again once scrolled to
+// the end
+func (m *BuildLogViewModel) scroll(delta, page int) {
+	last := max(len(m.rows())-page, 0)
+	m.offset = max(min(m.top(page)+delta, last), 0)
+	m.follow = m.offset == last
+}
+
+// View renders the build log view
+func (m BuildLogViewModel) View(width, height int) string {
+	titleStyle := lipgloss.NewStyle().
+		Foreground(theme.Primary).
+		Bold(true).
+		MarginBottom(1)
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(theme.Muted).
+		Italic(true).
+		MarginTop(1)
+
+	mutedStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render(fmt.Sprintf("Build Log - %s/%s #%s", m.pipeline, m.job, m.build)))
+	content.WriteString("\n\n")
+
+	page := m.pageSize(height)
+	var export string
+	if m.export != nil {
+		export = m.exportView(width)
+		page = max(page-lipgloss.Height(export), 1)
+	}
+	rows := m.rows()
+	top := m.top(page)
+	end := min(top+page, len(rows))
+	position := fmt.Sprintf("lines 0-0 of %d", len(m.lines))
+	if top < end {
+		last := rows[end-1]
+		if step := m.stepOf(last); step >= 0 && m.collapsed[last] {
+			last = m.steps[step].end - 1
+		}
+		position = fmt.Sprintf("lines %d-%d of %d", rows[top]+1, last+1, len(m.lines))
+	}
+	if m.timestamps != logTimestampsOff {
+		position += " • " + logTimestampsLabels[m.timestamps]
+	}
+	if search := m.searchStatus(); search != "" {
+		position += " • " + search
+	}
+	switch {
+	case m.err != nil:
+		errorStyle := lipgloss.NewStyle().Foreground(theme.Error)
+		content.WriteString(errorStyle.Render("Error: " + errorSummary(m.err)))
+	case m.running:
+		status := "following the build • " + position
+		if !m.follow {
+			status = "build running, " + keys.Label(actionDown) + " to the end follows it again • " + position
+		}
+		content.WriteString(m.loader.View(mutedStyle.Render(status)))
+	default:
+		content.WriteString(mutedStyle.Render("build finished • " + position))
+	}
+	content.WriteString("\n\n")
+
+	current := m.currentStep(page)
+	for _, row := range rows[top:end] {
+		line := showLogLine(m.lines[row])
+		if match, current := m.isMatch(row); match {
+			line = highlightMatches(line, m.search.query, current)
+		}
+		content.WriteString(ansi.Truncate(m.stampPrefix(row)+m.stepLine(row, line, current), width, "…"))
+		content.WriteString("\n")
+	}
+	if len(m.lines) == 0 && !m.running && m.err == nil {
+		content.WriteString("The build has no output.\n")
+	}
+
+	if export != "" {
+		content.WriteString(export)
+		content.WriteString("\n")
+	}
+
+	help := keys.HelpLine(viewKeys[ViewBuildLog]...) + " • PgUp/PgDn: page • Home/End: top/follow"
+	switch {
+	case m.export != nil:
+		help = strings.Join([]string{fixedHelp("save", "enter"), fixedHelp("with/without colors", "tab"), fixedHelp("cancel", "esc")}, " • ")
+	case m.search.typing:
+		help = "Enter: finish search • Esc: cancel search • Ctrl+U: clear"
+	case m.search.query != "":
+		help = keys.HelpLine(actionNextMatch, actionPrevMatch, actionClear) + " • " + help
+	}
+	content.WriteString(helpStyle.Render(help))
+
+	return content.String()
+}
@@ -2,6 +2,7 @@ package tui
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -12,6 +13,40 @@ import (
 	"github.com/charmbracelet/lipgloss"
 )
 
+// rerunReloads is how many times the builds are reloaded after a rerun,
+// rerunReloadDelay apart, waiting for the new build to show up
+const (
+	rerunReloads     = 5
+	rerunReloadDelay = time.Second
+)
+
+// durationTrendBuilds is how many of the latest finished builds the duration
+// sparkline shows
+const durationTrendBuilds = 20
+
+// buildsClockInterval is how often the elapsed time of running builds is
+// redrawn, and buildsPollInterval how often their status is reloaded
+const (
+	buildsClockInterval = time.Second
+	buildsPollInterval  = 5 * time.Second
+)
+
+// buildsSort is the order the builds are listed in
+type buildsSort int
+
+const (
+	buildsSortNewest   buildsSort = iota // latest start first, as fly lists them
+	buildsSortDuration                   // slowest first
+	buildsSortStatus                     // failed first
+)
+
+// buildsSortLabels describes each order for the view
+var buildsSortLabels = map[buildsSort]string{
+	buildsSortNewest:   "start time",
+	buildsSortDuration: "duration",
+	buildsSortStatus:   "status",
+}
+
 type buildsState int
 
 const (
@@ -25,19 +60,34 @@ type BuildsViewModel struct {
 	client       *concourse.Client
 	builds       []concourse.Build
 	cursor       int
+	scrollOffset int
+	maxVisible   int
 	state        buildsState
+	refreshing   bool // showing the builds of an earlier visit while they reload
 	err          error
 	job          string
 	pipeline     string
-	rerunMessage string
+	rerunBuild   int
+	rerunStarted string // build the last rerun started, selected once listed
+	rerunReloads int    // reloads left waiting for rerunStarted
+	selectNext   string // build to select once the builds load, e.g. from a restored session
+	fetchCount   int
+	sort         buildsSort
+	clicks       clickTracker
+	loader       loader
+	clockTicking bool // the elapsed time of running builds is being redrawn
+	polling      bool // the status of running builds is being reloaded
 }
 
 // NewBuildsViewModel creates a new builds view model
-func NewBuildsViewModel(client *concourse.Client) BuildsViewModel {
+func NewBuildsViewModel(client *concourse.Client, fetchCount int) BuildsViewModel {
 	return BuildsViewModel{
-		client: client,
-		cursor: 0,
-		state:  buildsStateLoading,
+		client:     client,
+		cursor:     0,
+		maxVisible: 10,
+		state:      buildsStateLoading,
+		fetchCount: fetchCount,
+		loader:     newLoader(),
 	}
 }
 
@@ -51,17 +101,34 @@ type BuildsLoadedMsg struct {
 
 // BuildRerunResultMsg represents the result of a build rerun operation
 type BuildRerunResultMsg struct {
-	Success bool
-	Output  string
-	Error   error
-	Build   int
+	Target   string
+	Pipeline string
+	Job      string
+	Success  bool
+	Output   string
+	Error    error
+	Build    int
 }
 
-// BuildRerunTickMsg for animation during rerunning
-type BuildRerunTickMsg struct{}
+// BuildsClockMsg redraws the elapsed time of running builds
+type BuildsClockMsg struct{}
+
+// BuildsPollMsg triggers reloading the status of running builds
+type BuildsPollMsg struct{}
+
+// BuildsPolledMsg carries the builds reloaded while some were running
+type BuildsPolledMsg struct {
+	Builds   []concourse.Build
+	Error    error
+	Job      string
+	Pipeline string
+}
 
-// ClearRerunMessageMsg to clear rerun messages
-type ClearRerunMessageMsg struct{}
+// BuildAbortedMsg represents the result of aborting a build
+type BuildAbortedMsg struct {
+	Build string
+	Error error
+}
 
 func (m BuildsViewModel) Init() tea.Cmd {
 	return nil
@@ -72,113 +139,181 @@ func (m BuildsViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		switch m.state {
 		case buildsStateLoading:
-			if msg.String() == "q" || msg.String() == "esc" {
+			if keys.Matches(msg, actionBack) {
 				// Go back to jobs view
-				return m, func() tea.Msg {
-					return SwitchViewMsg{View: ViewJobs}
-				}
+				return m, navigateBack()
 			}
 		case buildsStateList:
-			switch msg.String() {
-			case "f5":
+			switch {
+			case keys.Matches(msg, actionRefresh):
 				// Refresh builds
 				if m.client != nil && m.pipeline != "" && m.job != "" {
 					m.state = buildsStateLoading
 					return m, m.LoadBuilds(m.pipeline, m.job)
 				}
-			case "q", "esc":
+			case keys.Matches(msg, actionBack):
 				// Go back to jobs view
-				return m, func() tea.Msg {
-					return SwitchViewMsg{View: ViewJobs}
-				}
-			case "up", "k":
+				return m, navigateBack()
+			case keys.Matches(msg, actionUp):
 				if m.cursor > 0 {
 					m.cursor--
+					if m.cursor < m.scrollOffset {
+						m.scrollOffset = m.cursor
+					}
 				}
-			case "down", "j":
+			case keys.Matches(msg, actionDown):
 				if m.cursor < len(m.builds)-1 {
 					m.cursor++
+					if m.cursor >= m.scrollOffset+m.maxVisible {
+						m.scrollOffset = m.cursor - m.maxVisible + 1
+					}
 				}
-			case "enter":
+			case keys.Matches(msg, actionRerun):
 				if len(m.builds) > 0 {
 					selected := m.builds[m.cursor]
 					// Convert build name (string) to integer
 					buildNum, err := strconv.Atoi(selected.Name)
 					if err != nil {
-						m.rerunMessage = fmt.Sprintf("Error: Invalid build number %s", selected.Name)
-						return m, tea.Tick(5*time.Second, func(time.Time) tea.Msg {
-							return ClearRerunMessageMsg{}
-						})
+						return m, showToast(ToastError, "Invalid build number %s", selected.Name)
 					}
 					
 					// Start rerunning the selected build
 					m.state = buildsStateRerunning
-					m.rerunMessage = fmt.Sprintf("Rerunning build %s/%s #%d...", m.pipeline, m.job, buildNum)
+					m.rerunBuild = buildNum
 					
-					return m, tea.Batch(
-						func() tea.Msg {
-							success, output, err := m.client.RerunBuildWithOutput(m.pipeline, m.job, buildNum)
-							return BuildRerunResultMsg{
-								Success: success,
-								Output:  output,
-								Error:   err,
-								Build:   buildNum,
-							}
-						},
-						tea.Tick(100*time.Millisecond, func(time.Time) tea.Msg {
-							return BuildRerunTickMsg{}
-						}),
-					)
+					return m, func() tea.Msg {
+						success, output, err := m.client.RerunBuildWithOutput(m.pipeline, m.job, buildNum)
+						return BuildRerunResultMsg{
+							Target:   m.client.GetTarget(),
+							Pipeline: m.pipeline,
+							Job:      m.job,
+							Success:  success,
+							Output:   output,
+							Error:    err,
+							Build:    buildNum,
+						}
+					}
+				}
+			case keys.Matches(msg, actionLog):
+				if len(m.builds) > 0 {
+					build := m.builds[m.cursor]
+					pipeline, job := m.pipeline, m.job
+					return m, func() tea.Msg {
+						return SwitchViewMsg{View: ViewBuildLog, Pipeline: pipeline, Job: job, Build: build.Name}
+					}
 				}
+			case keys.Matches(msg, actionAbort):
+				if len(m.builds) > 0 {
+					return m, m.confirmAbort()
+				}
+			case keys.Matches(msg, actionSort):
+				m.sort = (m.sort + 1) % buildsSort(len(buildsSortLabels))
+				m.sortBuilds()
+				m.cursor = 0
+				m.scrollOffset = 0
 			}
 		case buildsStateRerunning:
 			// Only allow quitting during rerunning state
-			if msg.String() == "q" || msg.String() == "esc" {
-				return m, func() tea.Msg {
-					return SwitchViewMsg{View: ViewJobs}
-				}
+			if keys.Matches(msg, actionBack) {
+				return m, navigateBack()
 			}
 		}
+	case BuildAbortedMsg:
+		if msg.Error != nil {
+			return m, showToast(ToastError, "%v", msg.Error)
+		}
+		// Reload so the build shows as aborted
+		reload := m.LoadBuilds(m.pipeline, m.job)
+		return m, tea.Batch(showToast(ToastSuccess, "Aborted build %s/%s #%s", m.pipeline, m.job, msg.Build), reload)
 	case BuildRerunResultMsg:
+		m.state = buildsStateList
 		if msg.Error != nil {
-			m.state = buildsStateList
-			m.rerunMessage = fmt.Sprintf("Error: %v", msg.Error)
-		} else if msg.Success {
-			m.state = buildsStateList
-			m.rerunMessage = fmt.Sprintf("✓ Successfully reran build %s/%s #%d: %s", m.pipeline, m.job, msg.Build, msg.Output)
-			// Reload builds after successful rerun to show the new build
+			return m, showToast(ToastError, "Failed to rerun build %s/%s #%d: %v", m.pipeline, m.job, msg.Build, msg.Error)
+		} else if !msg.Success {
+			return m, showToast(ToastError, "Failed to rerun build %s/%s #%d: %s", m.pipeline, m.job, msg.Build, msg.Output)
+		}
+		// Reload builds after successful rerun to show and select the new build
+		m.rerunStarted = startedBuild(msg.Output)
+		if m.rerunStarted == "" {
 			return m, tea.Batch(
-				tea.Tick(5*time.Second, func(time.Time) tea.Msg {
-					return ClearRerunMessageMsg{}
-				}),
-				tea.Tick(2*time.Second, func(time.Time) tea.Msg {
-					// Reload builds after a short delay to let the new build appear
-					builds, err := m.client.GetBuilds(m.pipeline, m.job, 50)
-					if err != nil {
-						return BuildsLoadedMsg{Error: err, Job: m.job, Pipeline: m.pipeline}
-					}
-					return BuildsLoadedMsg{Builds: builds, Job: m.job, Pipeline: m.pipeline}
-				}),
+				showToast(ToastSuccess, "Reran build %s/%s #%d\n%s", m.pipeline, m.job, msg.Build, msg.Output),
+				m.reloadBuilds(0),
 			)
-		} else {
-			m.state = buildsStateList
-			m.rerunMessage = fmt.Sprintf("✗ Failed to rerun build %s/%s #%d: %s", m.pipeline, m.job, msg.Build, msg.Output)
 		}
-		// Clear the message after 5 seconds
-		return m, tea.Tick(5*time.Second, func(time.Time) tea.Msg {
-			return ClearRerunMessageMsg{}
+		m.rerunReloads = rerunReloads
+		return m, tea.Batch(
+			showToast(ToastSuccess, "Reran build %s/%s #%d as #%s", m.pipeline, m.job, msg.Build, m.rerunStarted),
+			m.reloadBuilds(0),
+		)
+	}
+	
+	return m, nil
+}
+
+// confirmAbort asks the user before aborting the selected build
+func (m BuildsViewModel) confirmAbort() tea.Cmd {
+	build := m.builds[m.cursor]
+	switch build.Status {
+	case "started", "pending":
+	default:
+		return showToast(ToastInfo, "Build #%s is not running", build.Name)
+	}
+	
+	client, pipeline, job := m.client, m.pipeline, m.job
+	return confirmAction("Abort build",
+		fmt.Sprintf("Abort build %s/%s #%s?", pipeline, job, build.Name),
+		func() tea.Msg {
+			return BuildAbortedMsg{Build: build.Name, Error: client.AbortBuild(pipeline, job, build.Name)}
 		})
-	case BuildRerunTickMsg:
-		if m.state == buildsStateRerunning {
-			// Continue ticking animation
-			return m, tea.Tick(100*time.Millisecond, func(time.Time) tea.Msg {
-				return BuildRerunTickMsg{}
-			})
-		}
-	case ClearRerunMessageMsg:
-		m.rerunMessage = ""
+}
+
+// visibleRange returns the range of builds shown for the given height,
+// keeping the selected build in view
+func (m BuildsViewModel) visibleRange(height int) (int, int) {
+	maxVisible := m.maxVisible
+	reserved := 20 // Account for title, indicators, build info and help
+	if m.state == buildsStateRerunning {
+		reserved += 3 // and the rerun status
+	}
+	if height-reserved > 0 {
+		maxVisible = height - reserved
 	}
 	
+	start := m.scrollOffset
+	if m.cursor >= start+maxVisible {
+		start = m.cursor - maxVisible + 1
+	}
+	if m.cursor < start {
+		start = m.cursor
+	}
+	return start, min(start+maxVisible, len(m.builds))
+}
+
+// Mouse handles clicks and scrolling over the builds list
+func (m BuildsViewModel) Mouse(msg tea.MouseMsg, height int) (BuildsViewModel, tea.Cmd) {
+	if m.state != buildsStateList || m.err != nil {
+		return m, nil
+	}
+
+	// Builds are listed one per line right below the title
+	start, end := m.visibleRange(height)
+	rows := listRows{top: 3, height: 1, first: start, end: end}
+	if start > 0 {
+		rows.top++ // "more above" indicator
+	}
+	var updated tea.Model
+	var cmd tea.Cmd
+	switch intent, index := m.clicks.interpret(msg, rows); intent {
+	case mouseScrollUp:
+		updated, cmd = m.Update(keyMsgFor(actionUp))
+		return updated.(BuildsViewModel), cmd
+	case mouseScrollDown:
+		updated, cmd = m.Update(keyMsgFor(actionDown))
+		return updated.(BuildsViewModel), cmd
+	case mouseClick, mouseDoubleClick:
+		// Rerunning a build stays on the keyboard, so a double-click only selects
+		m.cursor = index
+	}
 	return m, nil
 }
 
@@ -189,9 +324,27 @@ func (m *BuildsViewModel) LoadBuilds(pipeline, job string) tea.Cmd {
 	m.job = job
 	m.pipeline = pipeline
 	m.cursor = 0
+	m.scrollOffset = 0
+	m.refreshing = false
 	
+	return m.fetchBuilds(m.client)
+}
+
+// Revalidate reloads the builds while still showing the ones loaded on an
+// earlier visit, or returns nil when there are none to show
+func (m *BuildsViewModel) Revalidate() tea.Cmd {
+	if m.client == nil || m.job == "" || m.state != buildsStateList || m.err != nil {
+		return nil
+	}
+	m.refreshing = true
+	return m.fetchBuilds(m.client)
+}
+
+// fetchBuilds fetches the builds of the job shown through client
+func (m BuildsViewModel) fetchBuilds(client *concourse.Client) tea.Cmd {
+	pipeline, job, count := m.pipeline, m.job, m.fetchCount
 	return func() tea.Msg {
-		builds, err := m.client.GetBuilds(pipeline, job, 50) // Get last 50 builds
+		builds, err := client.GetBuilds(pipeline, job, count)
 		if err != nil {
 			return BuildsLoadedMsg{Error: err, Job: job, Pipeline: pipeline}
 		}
@@ -200,13 +353,224 @@ func (m *BuildsViewModel) LoadBuilds(pipeline, job string) tea.Cmd {
 }
 
 // HandleBuildsLoaded handles the builds loaded message
-func (m *BuildsViewModel) HandleBuildsLoaded(msg BuildsLoadedMsg) {
+func (m *BuildsViewModel) HandleBuildsLoaded(msg BuildsLoadedMsg) tea.Cmd {
+	if msg.Job != m.job || msg.Pipeline != m.pipeline {
+		m.rerunStarted = ""
+	}
+	selected := ""
+	if m.refreshing && m.cursor < len(m.builds) {
+		// Stay on the build selected before refreshing
+		selected = m.builds[m.cursor].Name
+	}
+	if selected == "" && msg.Error == nil {
+		selected, m.selectNext = m.selectNext, ""
+	}
 	m.builds = msg.Builds
 	m.err = msg.Error
 	m.job = msg.Job
 	m.pipeline = msg.Pipeline
 	m.state = buildsStateList
+	m.refreshing = false
 	m.cursor = 0
+	m.sortBuilds()
+	for i, build := range m.builds {
+		if build.Name == selected {
+			m.cursor = i
+		}
+	}
+	
+	if m.rerunStarted == "" || msg.Error != nil {
+		return nil
+	}
+	for i, build := range m.builds {
+		if build.Name == m.rerunStarted {
+			m.cursor = i
+			m.rerunStarted = ""
+			return showToast(ToastInfo, "Build #%s is selected, press %s to follow its log", build.Name, keys.Label(actionLog))
+		}
+	}
+	m.rerunReloads--
+	if m.rerunReloads <= 0 {
+		m.rerunStarted = ""
+		return nil
+	}
+	return m.reloadBuilds(rerunReloadDelay)
+}
+
+// reloadBuilds reloads the builds after delay, keeping the list on screen.
+// It asks fly every time, the cache would hide the build being waited for.
+func (m BuildsViewModel) reloadBuilds(delay time.Duration) tea.Cmd {
+	load := m.fetchBuilds(m.client.Uncached())
+	if delay == 0 {
+		return load
+	}
+	return tea.Tick(delay, func(time.Time) tea.Msg {
+		return load()
+	})
+}
+
+// hasRunningBuilds returns true when a listed build hasn't finished yet
+func (m BuildsViewModel) hasRunningBuilds() bool {
+	if m.state == buildsStateLoading {
+		return false
+	}
+	for _, build := range m.builds {
+		if build.Status == "started" || build.Status == "pending" {
+			return true
+		}
+	}
+	return false
+}
+
+// Track starts redrawing the elapsed time of running builds and reloading
+// their status, when the view shows some and isn't already doing so
+func (m *BuildsViewModel) Track(active bool) tea.Cmd {
+	if !active || !m.hasRunningBuilds() {
+		return nil
+	}
+	var cmds []tea.Cmd
+	if !m.clockTicking {
+		m.clockTicking = true
+		cmds = append(cmds, buildsClockTick())
+	}
+	if !m.polling {
+		m.polling = true
+		cmds = append(cmds, buildsPollTick())
+	}
+	return tea.Batch(cmds...)
+}
+
+// HandleClock keeps the clock going while the view shows running builds
+func (m *BuildsViewModel) HandleClock(active bool) tea.Cmd {
+	if !active || !m.hasRunningBuilds() {
+		m.clockTicking = false
+		return nil
+	}
+	return buildsClockTick()
+}
+
+// buildsClockTick waits for the next redraw of the elapsed times
+func buildsClockTick() tea.Cmd {
+	return tea.Tick(buildsClockInterval, func(time.Time) tea.Msg {
+		return BuildsClockMsg{}
+	})
+}
+
+// HandlePoll reloads the builds while the view shows running builds
+func (m *BuildsViewModel) HandlePoll(active bool) tea.Cmd {
+	if !active || !m.hasRunningBuilds() {
+		m.polling = false
+		return nil
+	}
+	// Polls run more often than fly's output is cached
+	client, pipeline, job, count := m.client.Uncached(), m.pipeline, m.job, m.fetchCount
+	return func() tea.Msg {
+		builds, err := client.GetBuilds(pipeline, job, count)
+		return BuildsPolledMsg{Builds: builds, Error: err, Job: job, Pipeline: pipeline}
+	}
+}
+
+// HandlePolled updates the builds in place, keeping the selected build
+// selected, and polls again while some are still running. A failed poll is
+// left for the next one, F5 shows the error.
+func (m *BuildsViewModel) HandlePolled(msg BuildsPolledMsg) tea.Cmd {
+	if msg.Error == nil && msg.Job == m.job && msg.Pipeline == m.pipeline && m.state == buildsStateList && m.err == nil {
+		selected := ""
+		if m.cursor < len(m.builds) {
+			selected = m.builds[m.cursor].Name
+		}
+		m.builds = msg.Builds
+		m.sortBuilds()
+		m.cursor = 0
+		for i, build := range m.builds {
+			if build.Name == selected {
+				m.cursor = i
+			}
+		}
+	}
+	if !m.hasRunningBuilds() {
+		m.polling = false
+		return nil
+	}
+	return buildsPollTick()
+}
+
+// buildsPollTick waits for the next reload of running builds
+func buildsPollTick() tea.Cmd {
+	return tea.Tick(buildsPollInterval, func(time.Time) tea.Msg {
+		return BuildsPollMsg{}
+	})
+}
+
+// sortBuilds orders the builds by the chosen sort, newest first within the
+// same duration or status
+func (m *BuildsViewModel) sortBuilds() {
+	newestFirst(m.builds)
+	switch m.sort {
+	case buildsSortDuration:
+		sort.SliceStable(m.builds, func(i, j int) bool {
+			return buildDuration(m.builds[i]) > buildDuration(m.builds[j])
+		})
+	case buildsSortStatus:
+		sort.SliceStable(m.builds, func(i, j int) bool {
+			return buildStatusRank(m.builds[i].Status) < buildStatusRank(m.builds[j].Status)
+		})
+	}
+}
+
+// newestFirst orders builds the way fly lists them, latest first
+func newestFirst(builds []concourse.Build) {
+	sort.SliceStable(builds, func(i, j int) bool {
+		return builds[i].ID > builds[j].ID
+	})
+}
+
+// buildDuration returns how long a build took, or has been running so far
+func buildDuration(build concourse.Build) time.Duration {
+	start, end := build.GetStartTime(), build.GetEndTime()
+	switch {
+	case start.IsZero():
+		return 0
+	case end.IsZero():
+		return time.Since(start)
+	}
+	return end.Sub(start)
+}
+
+// buildStatusRank ranks a build status for sorting, failures first and
+// successes last
+func buildStatusRank(status string) int {
+	switch status {
+	case "failed":
+		return 0
+	case "errored":
+		return 1
+	case "aborted":
+		return 2
+	case "started":
+		return 3
+	case "pending":
+		return 4
+	case "succeeded":
+		return 5
+	}
+	return 6
+}
+
+// buildElapsed returns how long a build took, or has been running so far
+func buildElapsed(build concourse.Build) string {
+	start, end := build.GetStartTime(), build.GetEndTime()
+	switch {
+	case start.IsZero() && build.Status == "pending":
+		return "waiting to start"
+	case start.IsZero():
+		return "unknown"
+	case !end.IsZero():
+		return formatBuildDuration(end.Sub(start))
+	case build.Status == "started" || build.Status == "pending":
+		return "running " + formatBuildDuration(time.Since(start))
+	}
+	return "unknown"
 }
 
 // formatTimeAgo returns a human-readable relative time string
@@ -242,19 +606,80 @@ func formatBuildTimeAgo(t time.Time) string {
 	}
 }
 
-// View renders the builds view
+// formatBuildDuration formats how long a build took
+func formatBuildDuration(dur time.Duration) string {
+	if dur < time.Minute {
+		return fmt.Sprintf("%ds", int(dur.Seconds()))
+	} else if dur < time.Hour {
+		return fmt.Sprintf("%dm%ds", int(dur.Minutes()), int(dur.Seconds())%60)
+	}
+	return fmt.Sprintf("%dh%dm", int(dur.Hours()), int(dur.Minutes())%60)
+}
+
+// renderDurationTrend renders a sparkline of how long the latest finished
+// builds took, oldest first, each bar colored by the build's status
+func (m BuildsViewModel) renderDurationTrend() string {
+	var finished []concourse.Build
+	builds := append([]concourse.Build(nil), m.builds...)
+	newestFirst(builds)
+	for _, build := range builds {
+		if !build.GetStartTime().IsZero() && !build.GetEndTime().IsZero() {
+			finished = append(finished, build)
+		}
+		if len(finished) == durationTrendBuilds {
+			break
+		}
+	}
+	if len(finished) < 2 {
+		return ""
+	}
+
+	durations := make([]float64, len(finished))
+	for i, build := range finished {
+		// Builds are listed newest first
+		durations[len(finished)-1-i] = build.GetEndTime().Sub(build.GetStartTime()).Seconds()
+	}
+
+	var trend strings.Builder
+	for i, bar := range sparkline(durations) {
+		color := theme.Muted
+		switch finished[len(finished)-1-i].Status {
+		case "succeeded":
+			color = theme.Success
+		case "failed", "errored":
+			color = theme.Error
+		case "aborted":
+			color = theme.Warning
+		}
+		trend.WriteString(lipgloss.NewStyle().Foreground(color).Render(string(bar)))
+	}
+
+	sorted := append([]float64(nil), durations...)
+	sort.Float64s(sorted)
+	latest := time.Duration(durations[len(durations)-1]) * time.Second
+	median := time.Duration(sorted[len(sorted)/2]) * time.Second
+	summary := fmt.Sprintf(" latest %s • median %s", formatBuildDuration(latest), formatBuildDuration(median))
+	return trend.String() + lipgloss.NewStyle().Foreground(theme.Muted).Render(summary)
+}
+
+// View renders the builds view listing the default number of builds
 func (m BuildsViewModel) View() string {
+	return m.ViewHeight(0)
+}
+
+// ViewHeight renders the builds view, listing as many builds as fit height
+func (m BuildsViewModel) ViewHeight(height int) string {
 	titleStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("205")).
+		Foreground(theme.Primary).
 		Bold(true).
 		MarginBottom(1)
 
 	selectedStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("205")).
+		Foreground(theme.Primary).
 		Bold(true).
 		PaddingLeft(1).
 		Border(lipgloss.NormalBorder(), false, false, false, true).
-		BorderForeground(lipgloss.Color("205"))
+		BorderForeground(theme.Primary)
 
 	itemStyle := lipgloss.NewStyle().
 		PaddingLeft(2)
@@ -264,49 +689,57 @@ func (m BuildsViewModel) View() string {
 	if m.job != "" {
 		title = fmt.Sprintf("Builds - %s/%s", m.pipeline, m.job)
 	}
-	content.WriteString(titleStyle.Render(title))
+	if m.sort != buildsSortNewest {
+		title += fmt.Sprintf(" (by %s)", buildsSortLabels[m.sort])
+	}
+	if m.refreshing && m.state != buildsStateLoading {
+		title += staleNote
+	}
+	header := titleStyle.Render(title)
+	if trend := m.renderDurationTrend(); trend != "" && m.state != buildsStateLoading {
+		header = lipgloss.JoinHorizontal(lipgloss.Top, header, "  ", trend)
+	}
+	content.WriteString(header)
 	content.WriteString("\n\n")
 
 	switch m.state {
 	case buildsStateLoading:
-		content.WriteString("Loading builds...\n")
+		content.WriteString(m.loader.View("Loading builds...") + "\n")
 	case buildsStateList, buildsStateRerunning:
 		if m.err != nil {
-			errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
-			content.WriteString(errorStyle.Render(fmt.Sprintf("Error: %v", m.err)))
+			errorStyle := lipgloss.NewStyle().Foreground(theme.Error)
+			content.WriteString(errorStyle.Render("Error: " + errorSummary(m.err)))
 			content.WriteString("\n")
 		} else if len(m.builds) == 0 {
 			content.WriteString("No builds found.\n")
 		} else {
-			// Show builds list
-			for i, build := range m.builds {
+			start, end := m.visibleRange(height)
+			
+			// Add scroll indicator at top
+			if start > 0 {
+				content.WriteString(itemStyle.Render("  ↑ (more above)"))
+				content.WriteString("\n")
+			}
+			
+			// Show visible builds only
+			for i := start; i < end; i++ {
+				build := m.builds[i]
 				status := strings.ToUpper(build.Status)
-				statusColor := "240" // default gray
+				statusColor := theme.Muted
 				
 				switch status {
 				case "SUCCEEDED":
-					statusColor = "46" // green
+					statusColor = theme.Success
 				case "FAILED":
-					statusColor = "196" // red
+					statusColor = theme.Error
 				case "STARTED", "PENDING":
-					statusColor = "226" // yellow
+					statusColor = theme.Warning
 				}
 				
-				statusStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(statusColor)).Bold(true)
+				statusStyle := lipgloss.NewStyle().Foreground(statusColor).Bold(true)
 				
 				startTime := formatBuildTimeAgo(build.GetStartTime())
-				duration := "unknown"
-				
-				if !build.GetStartTime().IsZero() && !build.GetEndTime().IsZero() {
-					dur := build.GetEndTime().Sub(build.GetStartTime())
-					if dur < time.Minute {
-						duration = fmt.Sprintf("%ds", int(dur.Seconds()))
-					} else if dur < time.Hour {
-						duration = fmt.Sprintf("%dm%ds", int(dur.Minutes()), int(dur.Seconds())%60)
-					} else {
-						duration = fmt.Sprintf("%dh%dm", int(dur.Hours()), int(dur.Minutes())%60)
-					}
-				}
+				duration := buildElapsed(build)
 				
 				line := fmt.Sprintf("#%s %s %s (%s)", build.Name, statusStyle.Render(fmt.Sprintf("[%s]", status)), startTime, duration)
 				
@@ -317,12 +750,18 @@ func (m BuildsViewModel) View() string {
 				}
 				content.WriteString("\n")
 			}
+			
+			// Add scroll indicator at bottom
+			if end < len(m.builds) {
+				content.WriteString(itemStyle.Render("  ↓ (more below)"))
+				content.WriteString("\n")
+			}
 
 			// Show selected build info
 			content.WriteString("\n")
 			infoStyle := lipgloss.NewStyle().
 				Border(lipgloss.RoundedBorder()).
-				BorderForeground(lipgloss.Color("240")).
+				BorderForeground(theme.Muted).
 				Padding(1).
 				MarginTop(1)
 
@@ -337,45 +776,35 @@ func (m BuildsViewModel) View() string {
 			if !build.GetEndTime().IsZero() {
 				info += fmt.Sprintf("\nEnded: %s", build.GetEndTime().Format("2006-01-02 15:04:05"))
 			}
+			
+			info += fmt.Sprintf("\nDuration: %s", buildElapsed(build))
 
 			content.WriteString(infoStyle.Render(info))
 		}
 		
-		// Show rerun status/message
+		// Show rerun status
 		if m.state == buildsStateRerunning {
-			content.WriteString("\n\n")
+			content.WriteString("\n\n\n")
 			loadingStyle := lipgloss.NewStyle().
-				Foreground(lipgloss.Color("226")).
-				Bold(true).
-				MarginTop(1)
-			content.WriteString(loadingStyle.Render("🔄 " + m.rerunMessage))
-		} else if m.rerunMessage != "" {
-			content.WriteString("\n\n")
-			if strings.Contains(m.rerunMessage, "✓") {
-				successStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("46")).Bold(true)
-				content.WriteString(successStyle.Render(m.rerunMessage))
-			} else if strings.Contains(m.rerunMessage, "✗") || strings.Contains(m.rerunMessage, "Error") {
-				errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true)
-				content.WriteString(errorStyle.Render(m.rerunMessage))
-			} else {
-				content.WriteString(m.rerunMessage)
-			}
+				Foreground(theme.Warning).
+				Bold(true)
+			content.WriteString(m.loader.View(loadingStyle.Render(fmt.Sprintf("Rerunning build %s/%s #%d...", m.pipeline, m.job, m.rerunBuild))))
 		}
 	}
 
 	// Add instructions
 	content.WriteString("\n\n")
 	instructionsStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("240")).
+		Foreground(theme.Muted).
 		Italic(true)
 	
 	switch m.state {
 	case buildsStateLoading:
 		content.WriteString(instructionsStyle.Render("Press 'q' or 'esc' to go back"))
 	case buildsStateList:
-		content.WriteString(instructionsStyle.Render("↑/↓: Navigate • Enter: Rerun build • q/esc: Back to jobs"))
+		content.WriteString(instructionsStyle.Render(keys.HelpLine(viewKeys[ViewBuilds]...)))
 	case buildsStateRerunning:
-		content.WriteString(instructionsStyle.Render("Rerunning build... • q/esc: Back to jobs"))
+		content.WriteString(instructionsStyle.Render("Rerunning build... • " + keys.Help(actionBack)))
 	}
 
 	return content.String()
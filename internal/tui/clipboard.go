@@ -0,0 +1,36 @@
+package tui
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// clipboardCommands are tried in order when no clipboard command is configured
+var clipboardCommands = [][]string{
+	{"pbcopy"},
+	{"wl-copy"},
+	{"xclip", "-selection", "clipboard"},
+	{"xsel", "--clipboard", "--input"},
+	{"clip.exe"},
+}
+
+// copyToClipboard copies text using the configured command, or the first
+// clipboard tool found in PATH
+func copyToClipboard(command, text string) error {
+	candidates := clipboardCommands
+	if strings.TrimSpace(command) != "" {
+		candidates = [][]string{strings.Fields(command)}
+	}
+
+	for _, candidate := range candidates {
+		if _, err := exec.LookPath(candidate[0]); err != nil {
+			continue
+		}
+		cmd := exec.Command(candidate[0], candidate[1:]...)
+		cmd.Stdin = strings.NewReader(text)
+		return cmd.Run()
+	}
+
+	return fmt.Errorf("no clipboard command available, set clipboard_command in the FlyBy config")
+}
@@ -0,0 +1,42 @@
+package tui
+
+import (
+	"flyby/internal/concourse"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ClusterInfoMsg carries the version information of a target's Concourse
+type ClusterInfoMsg struct {
+	Target string
+	Info   concourse.Info
+	Error  error
+}
+
+// loadClusterInfo fetches the current target's cluster info for the header,
+// unless it was fetched before
+func (m *Model) loadClusterInfo() tea.Cmd {
+	if m.currentTarget == "" || m.client == nil {
+		return nil
+	}
+	if _, fetched := m.clusterInfo[m.currentTarget]; fetched {
+		return nil
+	}
+
+	client := m.client
+	return func() tea.Msg {
+		info, err := client.GetInfo()
+		return ClusterInfoMsg{Target: client.GetTarget(), Info: info, Error: err}
+	}
+}
+
+// handleClusterInfo remembers a target's cluster info. A failure is kept as
+// empty info so it isn't retried on every view switch, the header just goes
+// without the version; older fly versions don't have fly curl.
+func (m *Model) handleClusterInfo(msg ClusterInfoMsg) {
+	if concourse.IsCanceled(msg.Error) {
+		// Fetched again on the next view switch
+		return
+	}
+	m.clusterInfo[msg.Target] = msg.Info
+}
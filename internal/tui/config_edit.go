@@ -0,0 +1,351 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// configDiffContext is how many unchanged lines the diff of an edited config
+// shows around each change
+const configDiffContext = 3
+
+// maxDiffCells bounds the table used to diff the changed part of a config.
+// Bigger changes are shown as the old lines removed and the new ones added.
+const maxDiffCells = 4_000_000
+
+// configEdit is a change to a pipeline's config, made in the editor and not
+// set yet
+type configEdit struct {
+	path     string   // temp file holding the edited config
+	diff     []string // unified diff against the loaded config
+	added    int
+	removed  int
+	invalid  []string // what fly validate-pipeline said was wrong, if anything
+	applying bool
+}
+
+// ConfigEditedMsg is sent once the editor of a pipeline's config exits
+type ConfigEditedMsg struct {
+	Pipeline string
+	Path     string
+	Error    error
+}
+
+// ConfigValidatedMsg carries the changes made to a pipeline's config and
+// whether fly accepts them
+type ConfigValidatedMsg struct {
+	Pipeline string
+	Path     string
+	Diff     []string
+	Added    int
+	Removed  int
+	Valid    bool
+	Output   string
+	Error    error
+}
+
+// ConfigAppliedMsg carries the result of setting a pipeline's edited config
+type ConfigAppliedMsg struct {
+	Pipeline string
+	Success  bool
+	Output   string
+	Error    error
+}
+
+// EditConfig opens the pipeline's config in the user's editor, suspending
+// FlyBy until it exits. A pending edit is reopened rather than started over.
+func (m *ConfigViewModel) EditConfig() tea.Cmd {
+	if m.loading || m.err != nil || m.lines == nil {
+		return nil
+	}
+	path := ""
+	if m.edit != nil {
+		path = m.edit.path
+	} else {
+		name, _, _ := strings.Cut(m.pipeline, "/")
+		file, err := os.CreateTemp("", "flyby-"+name+"-*.yml")
+		if err != nil {
+			return showToast(ToastError, "Failed to create a file to edit: %v", err)
+		}
+		_, err = file.WriteString(strings.Join(m.lines, "\n") + "\n")
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			os.Remove(file.Name())
+			return showToast(ToastError, "Failed to write the config to edit: %v", err)
+		}
+		path = file.Name()
+	}
+
+	pipeline := m.pipeline
+	return tea.ExecProcess(editorCommand(path), func(err error) tea.Msg {
+		return ConfigEditedMsg{Pipeline: pipeline, Path: path, Error: err}
+	})
+}
+
+// editorCommand returns the command opening path in $VISUAL or $EDITOR, or
+// else the platform's usual editor
+func editorCommand(path string) *exec.Cmd {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	// The variables may hold arguments too, e.g. "code --wait"
+	args := strings.Fields(editor)
+	if len(args) == 0 {
+		args = []string{"vi"}
+		if runtime.GOOS == "windows" {
+			args = []string{"notepad"}
+		}
+	}
+	return exec.Command(args[0], append(args[1:], path)...)
+}
+
+// HandleConfigEdited diffs the edited config against the loaded one and has
+// fly validate it
+func (m ConfigViewModel) HandleConfigEdited(msg ConfigEditedMsg) (ConfigViewModel, tea.Cmd) {
+	if msg.Pipeline != m.pipeline {
+		os.Remove(msg.Path)
+		return m, nil
+	}
+	if msg.Error != nil {
+		if m.edit == nil {
+			os.Remove(msg.Path)
+		}
+		return m, showToast(ToastError, "Failed to run the editor: %v", msg.Error)
+	}
+
+	client, before := m.client, m.lines
+	return m, func() tea.Msg {
+		result := ConfigValidatedMsg{Pipeline: msg.Pipeline, Path: msg.Path}
+		data, err := os.ReadFile(msg.Path)
+		if err != nil {
+			result.Error = err
+			return result
+		}
+		after := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+		result.Diff, result.Added, result.Removed = diffLines(before, after)
+		if len(result.Diff) > 0 {
+			result.Valid, result.Output, result.Error = client.ValidatePipelineConfig(msg.Path)
+		}
+		return result
+	}
+}
+
+// HandleConfigValidated shows the changes to the config, or what's wrong
+// with them
+func (m ConfigViewModel) HandleConfigValidated(msg ConfigValidatedMsg) (ConfigViewModel, tea.Cmd) {
+	if msg.Pipeline != m.pipeline {
+		os.Remove(msg.Path)
+		return m, nil
+	}
+	if len(msg.Diff) == 0 && msg.Error == nil {
+		os.Remove(msg.Path)
+		m.edit = nil
+		return m, showToast(ToastInfo, "No changes to pipeline %s", m.pipeline)
+	}
+
+	m.edit = &configEdit{path: msg.Path, diff: msg.Diff, added: msg.Added, removed: msg.Removed}
+	m.offset = 0
+	switch {
+	case msg.Error != nil:
+		m.edit.invalid = strings.Split(strings.TrimSpace(msg.Error.Error()), "\n")
+	case !msg.Valid:
+		m.edit.invalid = strings.Split(msg.Output, "\n")
+	}
+	return m, nil
+}
+
+// applyEdit sets the pipeline's config to the edited one
+func (m *ConfigViewModel) applyEdit() tea.Cmd {
+	if m.edit.invalid != nil || m.edit.applying {
+		return nil
+	}
+	m.edit.applying = true
+	client, pipeline, path := m.client, m.pipeline, m.edit.path
+	return func() tea.Msg {
+		success, output, err := client.SetPipelineConfig(pipeline, path)
+		return ConfigAppliedMsg{Pipeline: pipeline, Success: success, Output: output, Error: err}
+	}
+}
+
+// discardEdit throws the edited config away
+func (m *ConfigViewModel) discardEdit() tea.Cmd {
+	os.Remove(m.edit.path)
+	m.edit = nil
+	m.offset = 0
+	return showToast(ToastInfo, "Discarded the changes to pipeline %s", m.pipeline)
+}
+
+// HandleConfigApplied reloads the config once the edited one is set, or
+// keeps the edit around to try again
+func (m ConfigViewModel) HandleConfigApplied(msg ConfigAppliedMsg) (ConfigViewModel, tea.Cmd) {
+	if msg.Pipeline != m.pipeline || m.edit == nil {
+		return m, nil
+	}
+	m.edit.applying = false
+	if msg.Error != nil || !msg.Success {
+		output := msg.Output
+		if msg.Error != nil {
+			output = errorSummary(msg.Error)
+		}
+		return m, showToast(ToastError, "Failed to set pipeline %s: %s", m.pipeline, output)
+	}
+
+	os.Remove(m.edit.path)
+	m.edit = nil
+	reload := m.LoadConfig(m.client, m.pipeline)
+	return m, tea.Batch(reload, showToast(ToastSuccess, "Updated pipeline %s", m.pipeline))
+}
+
+// updateEdit handles key presses while an edited config waits to be applied
+func (m ConfigViewModel) updateEdit(msg tea.KeyMsg) (ConfigViewModel, tea.Cmd) {
+	switch {
+	case keys.Matches(msg, actionConfirm):
+		cmd := m.applyEdit()
+		return m, cmd
+	case keys.Matches(msg, actionEditConfig):
+		if !m.edit.applying {
+			cmd := m.EditConfig()
+			return m, cmd
+		}
+	case keys.Matches(msg, actionCancel), keys.Matches(msg, actionBack):
+		if !m.edit.applying {
+			cmd := m.discardEdit()
+			return m, cmd
+		}
+	}
+	return m, nil
+}
+
+// diffOp is a line of a diff: kept, removed or added
+type diffOp struct {
+	kind   byte // ' ', '-' or '+'
+	text   string
+	before int // lines of the old config ahead of this one
+	after  int // lines of the new config ahead of this one
+}
+
+// diffLines returns a unified diff turning before into after, with the
+// number of lines added and removed
+func diffLines(before, after []string) (diff []string, added, removed int) {
+	ops := diffOps(before, after)
+	var changed []int
+	for i, op := range ops {
+		switch op.kind {
+		case '+':
+			added++
+			changed = append(changed, i)
+		case '-':
+			removed++
+			changed = append(changed, i)
+		}
+	}
+
+	for i := 0; i < len(changed); {
+		// Changes close enough for their context to meet share a hunk
+		j := i
+		for j+1 < len(changed) && changed[j+1]-changed[j] <= 2*configDiffContext+1 {
+			j++
+		}
+		start := max(changed[i]-configDiffContext, 0)
+		end := min(changed[j]+configDiffContext+1, len(ops))
+
+		beforeCount, afterCount := 0, 0
+		for _, op := range ops[start:end] {
+			if op.kind != '+' {
+				beforeCount++
+			}
+			if op.kind != '-' {
+				afterCount++
+			}
+		}
+		diff = append(diff, fmt.Sprintf("@@ -%d,%d +%d,%d @@", ops[start].before+1, beforeCount, ops[start].after+1, afterCount))
+		for _, op := range ops[start:end] {
+			diff = append(diff, string(op.kind)+op.text)
+		}
+		i = j + 1
+	}
+	return diff, added, removed
+}
+
+// diffOps lines up before and after along their longest common subsequence
+func diffOps(before, after []string) []diffOp {
+	prefix := 0
+	for prefix < len(before) && prefix < len(after) && before[prefix] == after[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(before)-prefix && suffix < len(after)-prefix &&
+		before[len(before)-1-suffix] == after[len(after)-1-suffix] {
+		suffix++
+	}
+	oldLines, newLines := before[prefix:len(before)-suffix], after[prefix:len(after)-suffix]
+
+	var ops []diffOp
+	i, j := 0, 0
+	keep := func(text string) {
+		ops = append(ops, diffOp{kind: ' ', text: text, before: i, after: j})
+		i, j = i+1, j+1
+	}
+	remove := func() {
+		ops = append(ops, diffOp{kind: '-', text: before[i], before: i, after: j})
+		i++
+	}
+	add := func() {
+		ops = append(ops, diffOp{kind: '+', text: after[j], before: i, after: j})
+		j++
+	}
+
+	for i < prefix {
+		keep(before[i])
+	}
+	if (len(oldLines)+1)*(len(newLines)+1) > maxDiffCells {
+		for range oldLines {
+			remove()
+		}
+		for range newLines {
+			add()
+		}
+	} else {
+		// common[a][b] is the length of the longest common subsequence of
+		// oldLines[a:] and newLines[b:]
+		common := make([][]int, len(oldLines)+1)
+		for a := range common {
+			common[a] = make([]int, len(newLines)+1)
+		}
+		for a := len(oldLines) - 1; a >= 0; a-- {
+			for b := len(newLines) - 1; b >= 0; b-- {
+				if oldLines[a] == newLines[b] {
+					common[a][b] = common[a+1][b+1] + 1
+				} else {
+					common[a][b] = max(common[a+1][b], common[a][b+1])
+				}
+			}
+		}
+		a, b := 0, 0
+		for a < len(oldLines) || b < len(newLines) {
+			switch {
+			case a < len(oldLines) && b < len(newLines) && oldLines[a] == newLines[b]:
+				keep(oldLines[a])
+				a, b = a+1, b+1
+			case b == len(newLines) || (a < len(oldLines) && common[a+1][b] >= common[a][b+1]):
+				remove()
+				a++
+			default:
+				add()
+				b++
+			}
+		}
+	}
+	for i < len(before) {
+		keep(before[i])
+	}
+	return ops
+}
@@ -0,0 +1,229 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"flyby/internal/concourse"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// configChrome is how many lines around the config the view takes: the title
+// with its margin and the status line above, the help below
+const configChrome = 7
+
+// ConfigViewModel represents the full config of a pipeline
+type ConfigViewModel struct {
+	client   *concourse.Client
+	pipeline string
+	lines    []string
+	offset   int // first line shown
+	loading  bool
+	err      error
+	loader   loader
+	edit     *configEdit // an edited config waiting to be applied
+	editNext bool        // open the config in the editor once it loads
+}
+
+// ConfigLoadedMsg carries the config of a pipeline
+type ConfigLoadedMsg struct {
+	Pipeline string
+	Config   string
+	Error    error
+}
+
+// NewConfigViewModel creates a new config view model
+func NewConfigViewModel() ConfigViewModel {
+	return ConfigViewModel{
+		loader: newLoader(),
+	}
+}
+
+// LoadConfig loads the config of a pipeline
+func (m *ConfigViewModel) LoadConfig(client *concourse.Client, pipeline string) tea.Cmd {
+	m.client = client
+	m.pipeline = pipeline
+	m.lines = nil
+	m.offset = 0
+	m.loading = true
+	m.err = nil
+	if m.edit != nil {
+		os.Remove(m.edit.path)
+		m.edit = nil
+	}
+
+	return func() tea.Msg {
+		config, err := client.GetPipelineYAML(pipeline)
+		return ConfigLoadedMsg{Pipeline: pipeline, Config: config, Error: err}
+	}
+}
+
+// HandleConfigLoaded shows the loaded config, and opens it in the editor
+// when asked to
+func (m ConfigViewModel) HandleConfigLoaded(msg ConfigLoadedMsg) (ConfigViewModel, tea.Cmd) {
+	if msg.Pipeline != m.pipeline {
+		// A config the view was showing before
+		return m, nil
+	}
+	m.loading = false
+	m.err = msg.Error
+	if msg.Error != nil {
+		m.editNext = false
+		return m, nil
+	}
+	m.lines = strings.Split(strings.TrimRight(msg.Config, "\n"), "\n")
+	if m.editNext {
+		m.editNext = false
+		return m, m.EditConfig()
+	}
+	return m, nil
+}
+
+// Update handles key presses for the config view
+func (m ConfigViewModel) Update(msg tea.KeyMsg, height int) (ConfigViewModel, tea.Cmd) {
+	page := m.pageSize(height)
+	switch {
+	case keys.Matches(msg, actionUp):
+		m.scroll(-1, page)
+	case keys.Matches(msg, actionDown):
+		m.scroll(1, page)
+	case msg.String() == "pgup":
+		m.scroll(-page, page)
+	case msg.String() == "pgdown":
+		m.scroll(page, page)
+	case msg.String() == "home":
+		m.offset = 0
+	case msg.String() == "end":
+		m.scroll(len(m.shown()), page)
+	case keys.Matches(msg, actionPager):
+		if lines := m.shown(); len(lines) > 0 {
+			return m, openPager(strings.Join(lines, "\n") + "\n")
+		}
+	case m.edit != nil:
+		return m.updateEdit(msg)
+	case keys.Matches(msg, actionRefresh):
+		if m.client != nil {
+			cmd := m.LoadConfig(m.client, m.pipeline)
+			return m, cmd
+		}
+	case keys.Matches(msg, actionEditConfig):
+		cmd := m.EditConfig()
+		return m, cmd
+	}
+	return m, nil
+}
+
+// Mouse scrolls the config with the wheel
+func (m ConfigViewModel) Mouse(msg tea.MouseMsg, height int) (ConfigViewModel, tea.Cmd) {
+	if msg.Action != tea.MouseActionPress {
+		return m, nil
+	}
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		m.scroll(-buildLogScroll, m.pageSize(height))
+	case tea.MouseButtonWheelDown:
+		m.scroll(buildLogScroll, m.pageSize(height))
+	}
+	return m, nil
+}
+
+// pageSize returns how many config lines fit the given height
+func (m ConfigViewModel) pageSize(height int) int {
+	return max(height-configChrome, 1)
+}
+
+// shown returns the lines the view shows: the config, or the changes made
+// to it in the editor, or what's wrong with them
+func (m ConfigViewModel) shown() []string {
+	switch {
+	case m.edit == nil:
+		return m.lines
+	case m.edit.invalid != nil:
+		return m.edit.invalid
+	}
+	return m.edit.diff
+}
+
+// scroll moves the config by delta lines
+func (m *ConfigViewModel) scroll(delta, page int) {
+	last := max(len(m.shown())-page, 0)
+	m.offset = max(min(m.offset+delta, last), 0)
+}
+
+// View renders the config view
+func (m ConfigViewModel) View(width, height int) string {
+	titleStyle := lipgloss.NewStyle().
+		Foreground(theme.Primary).
+		Bold(true).
+		MarginBottom(1)
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(theme.Muted).
+		Italic(true).
+		MarginTop(1)
+
+	mutedStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render("Pipeline Config - " + m.pipeline))
+	content.WriteString("\n\n")
+
+	errorStyle := lipgloss.NewStyle().Foreground(theme.Error)
+	lines := m.shown()
+	page := m.pageSize(height)
+	top := min(m.offset, max(len(lines)-page, 0))
+	end := min(top+page, len(lines))
+	position := fmt.Sprintf("lines %d-%d of %d", min(top+1, end), end, len(lines))
+	switch {
+	case m.loading:
+		content.WriteString(m.loader.View("Loading config..."))
+	case m.err != nil:
+		content.WriteString(errorStyle.Render("Error: " + errorSummary(m.err)))
+	case m.edit != nil && m.edit.applying:
+		content.WriteString(m.loader.View("Setting pipeline..."))
+	case m.edit != nil && m.edit.invalid != nil:
+		content.WriteString(errorStyle.Render("fly rejected the edited config") + mutedStyle.Render(" • "+position))
+	case m.edit != nil:
+		content.WriteString(fmt.Sprintf("Apply these changes? +%d -%d lines", m.edit.added, m.edit.removed) + mutedStyle.Render(" • "+position))
+	default:
+		content.WriteString(mutedStyle.Render(position))
+	}
+	content.WriteString("\n\n")
+
+	for _, line := range lines[top:end] {
+		line = ansi.Truncate(line, width, "…")
+		if m.edit != nil && m.edit.invalid == nil {
+			line = renderDiffLine(line)
+		}
+		content.WriteString(line)
+		content.WriteString("\n")
+	}
+
+	help := keys.HelpLine(viewKeys[ViewConfig]...)
+	switch {
+	case m.edit != nil && m.edit.invalid != nil:
+		help = keys.HelpLine(actionEditConfig, actionPager, actionCancel)
+	case m.edit != nil:
+		help = keys.HelpLine(actionConfirm, actionEditConfig, actionPager, actionCancel)
+	}
+	content.WriteString(helpStyle.Render(help + " • PgUp/PgDn: page • Home/End: top/bottom"))
+
+	return content.String()
+}
+
+// renderDiffLine colors a line of a diff by what happened to it
+func renderDiffLine(line string) string {
+	switch {
+	case strings.HasPrefix(line, "@@"):
+		return lipgloss.NewStyle().Foreground(theme.Info).Render(line)
+	case strings.HasPrefix(line, "+"):
+		return lipgloss.NewStyle().Foreground(theme.Success).Render(line)
+	case strings.HasPrefix(line, "-"):
+		return lipgloss.NewStyle().Foreground(theme.Error).Render(line)
+	}
+	return line
+}
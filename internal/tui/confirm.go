@@ -0,0 +1,92 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ConfirmMsg asks the user to confirm a destructive action before it runs
+type ConfirmMsg struct {
+	Title     string
+	Message   string
+	OnConfirm tea.Cmd // run when the user confirms
+
+	// Alternative is a second way to confirm, e.g. logging out of a target
+	// before deleting it, running OnAlternative instead
+	Alternative   keyAction
+	OnAlternative tea.Cmd
+}
+
+// confirmAction returns a command that opens a confirmation dialog for action
+func confirmAction(title, message string, action tea.Cmd) tea.Cmd {
+	return func() tea.Msg {
+		return ConfirmMsg{Title: title, Message: message, OnConfirm: action}
+	}
+}
+
+// confirmActionOr returns a command that opens a confirmation dialog for
+// action, which the key of alternative confirms by running alternativeAction
+// instead
+func confirmActionOr(title, message string, action tea.Cmd, alternative keyAction, alternativeAction tea.Cmd) tea.Cmd {
+	return func() tea.Msg {
+		return ConfirmMsg{Title: title, Message: message, OnConfirm: action, Alternative: alternative, OnAlternative: alternativeAction}
+	}
+}
+
+// handleConfirmKey handles keys while the confirmation dialog is open
+func (m *Model) handleConfirmKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case keys.Matches(msg, actionConfirm):
+		cmd := m.confirm.OnConfirm
+		m.confirm = nil
+		return m, cmd
+	case m.confirm.Alternative != "" && keys.Matches(msg, m.confirm.Alternative):
+		cmd := m.confirm.OnAlternative
+		m.confirm = nil
+		return m, cmd
+	case keys.Matches(msg, actionCancel), keys.Matches(msg, actionBack):
+		m.confirm = nil
+	}
+	return m, nil
+}
+
+// renderConfirm renders the confirmation dialog box
+func (m *Model) renderConfirm() string {
+	width := min(50, m.width-4)
+	titleStyle := lipgloss.NewStyle().
+		Foreground(theme.Error).
+		Bold(true)
+	helpStyle := lipgloss.NewStyle().
+		Foreground(theme.Muted).
+		Italic(true)
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Error).
+		Foreground(theme.Text).
+		Padding(1, 2).
+		Width(width)
+
+	actions := []keyAction{actionConfirm, actionCancel}
+	if m.confirm.Alternative != "" {
+		actions = []keyAction{actionConfirm, m.confirm.Alternative, actionCancel}
+	}
+	body := fmt.Sprintf("%s\n\n%s\n\n%s",
+		titleStyle.Render(m.confirm.Title),
+		m.confirm.Message,
+		helpStyle.Render(keys.HelpLine(actions...)))
+	return boxStyle.Render(body)
+}
+
+// overlayConfirm draws the confirmation dialog in the middle of the view
+func (m *Model) overlayConfirm(view string) string {
+	if m.confirm == nil {
+		return view
+	}
+
+	dialog := m.renderConfirm()
+	left := max(0, (m.width-lipgloss.Width(dialog))/2)
+	top := max(0, (lipgloss.Height(view)-lipgloss.Height(dialog))/2)
+	return placeOverlay(view, dialog, left, top)
+}
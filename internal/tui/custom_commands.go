@@ -0,0 +1,247 @@
+package tui
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+
+	"flyby/internal/concourse"
+	"flyby/internal/config"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// customCommandViews are the views custom commands can be bound in, by the
+// name the FlyBy config file uses
+var customCommandViews = map[string]ViewType{
+	"targets":   ViewTargets,
+	"pipelines": ViewPipelines,
+	"jobs":      ViewJobs,
+	"builds":    ViewBuilds,
+	"resources": ViewResources,
+}
+
+// customPlaceholder matches a placeholder in a custom command, e.g. {job}
+var customPlaceholder = regexp.MustCompile(`\{([a-z_]+)\}`)
+
+// customPlaceholders are the placeholders a custom command may use
+var customPlaceholders = map[string]bool{
+	"target": true, "team": true, "pipeline": true, "job": true, "build": true, "resource": true,
+}
+
+// customCommands holds the user's own commands from the FlyBy config file
+var customCommands []config.CustomCommand
+
+// ApplyCustomCommands checks the user's own commands and binds them to their
+// keys
+func ApplyCustomCommands(commands []config.CustomCommand) error {
+	for _, command := range commands {
+		if command.Name == "" || command.Key == "" || command.Command == "" {
+			return fmt.Errorf("custom command %q needs a name, a key and a command", command.Name)
+		}
+		if len(command.Views) == 0 {
+			return fmt.Errorf("custom command %q isn't bound in any view", command.Name)
+		}
+		for _, view := range command.Views {
+			if _, ok := customCommandViews[view]; !ok {
+				return fmt.Errorf("custom command %q names unknown view %q, expected one of %s",
+					command.Name, view, strings.Join(customCommandViewNames(), ", "))
+			}
+		}
+		for _, match := range customPlaceholder.FindAllStringSubmatch(command.Command, -1) {
+			if !customPlaceholders[match[1]] {
+				return fmt.Errorf("custom command %q has unknown placeholder %s", command.Name, match[0])
+			}
+		}
+	}
+	customCommands = commands
+	return nil
+}
+
+// customCommandViewNames returns the names of the views custom commands can
+// be bound in, sorted
+func customCommandViewNames() []string {
+	names := make([]string, 0, len(customCommandViews))
+	for name := range customCommandViews {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// customCommandsIn returns the custom commands bound in a view
+func customCommandsIn(view ViewType) []config.CustomCommand {
+	var bound []config.CustomCommand
+	for _, command := range customCommands {
+		for _, name := range command.Views {
+			if customCommandViews[name] == view {
+				bound = append(bound, command)
+				break
+			}
+		}
+	}
+	return bound
+}
+
+// placeholderValues returns what the placeholders of custom commands stand
+// for in the current view, leaving out those without a value
+func (m *Model) placeholderValues() map[string]string {
+	values := map[string]string{"target": m.currentTarget}
+	if m.currentView == ViewTargets {
+		if m.targetsView.selected >= len(m.targetsView.filteredTargets) {
+			return nil
+		}
+		target := m.targetsView.filteredTargets[m.targetsView.selected]
+		return map[string]string{"target": target.Name, "team": target.Team}
+	}
+
+	location, ok := m.selectedLocation()
+	if !ok {
+		return nil
+	}
+	values["team"] = location.team
+	if location.team == "" {
+		if target, exists := m.configManager.GetTarget(m.currentTarget); exists {
+			values["team"] = target.Team
+		}
+	}
+	values["pipeline"] = concourse.PipelineRef(location.pipeline, location.vars)
+	values["job"] = location.job
+	values["build"] = location.build
+	values["resource"] = location.resource
+	for name, value := range values {
+		if value == "" {
+			delete(values, name)
+		}
+	}
+	return values
+}
+
+// runCustomCommand runs the custom command bound to the key in the current
+// view, or returns nil when there is none
+func (m *Model) runCustomCommand(msg tea.KeyMsg) tea.Cmd {
+	for _, command := range customCommandsIn(m.currentView) {
+		if command.Key != msg.String() {
+			continue
+		}
+
+		values := m.placeholderValues()
+		if values == nil {
+			return showToast(ToastError, "Select something to run %s on", command.Name)
+		}
+		var missing []string
+		line := customPlaceholder.ReplaceAllStringFunc(command.Command, func(placeholder string) string {
+			name := strings.Trim(placeholder, "{}")
+			value, ok := values[name]
+			if !ok {
+				missing = append(missing, name)
+			}
+			return shellQuote(value)
+		})
+		if len(missing) > 0 {
+			return showToast(ToastError, "%s needs a %s, which %s doesn't have", command.Name, missing[0], viewTitles[m.currentView])
+		}
+
+		environ, err := concourse.Environ()
+		if err != nil {
+			return showToast(ToastError, "Failed to run %s: %v", command.Name, err)
+		}
+		cmd := shellCommand(context.Background(), line)
+		cmd.Env = environ
+		if command.Background {
+			return runInBackground(command.Name, cmd)
+		}
+		return tea.Exec(&pausedCommand{cmd: cmd}, func(err error) tea.Msg {
+			if err != nil {
+				return ToastMsg{Level: ToastError, Text: fmt.Sprintf("%s failed: %v", command.Name, err)}
+			}
+			return nil
+		})
+	}
+	return nil
+}
+
+// runInBackground runs a custom command while FlyBy carries on, reporting
+// the last line it printed
+func runInBackground(name string, cmd *exec.Cmd) tea.Cmd {
+	return func() tea.Msg {
+		output, err := cmd.CombinedOutput()
+		lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+		last := lines[len(lines)-1]
+		if err != nil {
+			if last == "" {
+				last = err.Error()
+			}
+			return ToastMsg{Level: ToastError, Text: fmt.Sprintf("%s failed: %s", name, last)}
+		}
+		if last == "" {
+			last = "done"
+		}
+		return ToastMsg{Level: ToastSuccess, Text: fmt.Sprintf("%s: %s", name, last)}
+	}
+}
+
+// shellCommand returns a command running line in the platform's shell,
+// killed when ctx is done
+func shellCommand(ctx context.Context, line string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/c", line)
+	}
+	return exec.CommandContext(ctx, "sh", "-c", line)
+}
+
+// shellQuote quotes a value substituted into a shell command line, so names
+// with spaces or instance vars with quotes stay a single argument
+func shellQuote(value string) string {
+	if value != "" && !strings.ContainsAny(value, " \t\n\"'\\$`!*?[]{}()<>|&;#~") {
+		return value
+	}
+	if runtime.GOOS == "windows" {
+		return `"` + strings.ReplaceAll(value, `"`, `""`) + `"`
+	}
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// pausedCommand runs a custom command with the terminal to itself, then
+// waits for Enter so its output can be read before FlyBy comes back
+type pausedCommand struct {
+	cmd    *exec.Cmd
+	stdin  io.Reader
+	stdout io.Writer
+}
+
+// SetStdin sets the terminal input
+func (c *pausedCommand) SetStdin(r io.Reader) { c.stdin = r }
+
+// SetStdout sets the terminal output
+func (c *pausedCommand) SetStdout(w io.Writer) { c.stdout = w }
+
+// SetStderr sets where the command writes its errors
+func (c *pausedCommand) SetStderr(w io.Writer) { c.cmd.Stderr = w }
+
+// Run runs the command, then waits for Enter
+func (c *pausedCommand) Run() error {
+	if c.stdin == nil {
+		c.stdin = os.Stdin
+	}
+	if c.stdout == nil {
+		c.stdout = os.Stdout
+	}
+	if c.cmd.Stderr == nil {
+		c.cmd.Stderr = os.Stderr
+	}
+	c.cmd.Stdin = c.stdin
+	c.cmd.Stdout = c.stdout
+
+	err := c.cmd.Run()
+	fmt.Fprint(c.stdout, "\nPress Enter to return to FlyBy")
+	bufio.NewReader(c.stdin).ReadString('\n')
+	return err
+}
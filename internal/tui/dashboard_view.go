@@ -0,0 +1,453 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"flyby/internal/concourse"
+	"flyby/internal/state"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// dashboardConcurrency bounds the number of pipelines whose jobs load at once
+const dashboardConcurrency = 4
+
+// dashboardListTop is the line of the first pipeline: title, margin, blank
+// line, progress or summary and another blank line
+const dashboardListTop = 5
+
+// dashboardRow holds the jobs of one pipeline on the dashboard
+type dashboardRow struct {
+	jobs   []concourse.Job
+	loaded bool
+	err    error
+}
+
+// failing returns how many of the row's jobs last failed or errored
+func (r dashboardRow) failing() int {
+	count := 0
+	for _, job := range r.jobs {
+		if job.FinishedBuild.Status == "failed" || job.FinishedBuild.Status == "errored" {
+			count++
+		}
+	}
+	return count
+}
+
+// DashboardViewModel represents the latest job statuses of every pipeline of
+// a target at a glance, or of its starred pipelines only
+type DashboardViewModel struct {
+	client        *concourse.Client
+	stateStore    *state.Store
+	favoritesOnly bool
+	load          int                     // tells results of the current load from older ones
+	all           []concourse.Pipeline    // as fly lists them
+	pipelines     []concourse.Pipeline    // shown, starred ones first
+	rows          map[string]dashboardRow // by pipeline Ref, once their jobs started loading
+	semaphore     chan struct{}
+	selected      int
+	scrollOffset  int
+	maxVisible    int
+	loading       bool // listing the pipelines
+	err           error
+	clicks        clickTracker
+	loader        loader
+}
+
+// DashboardPipelinesMsg represents the listed pipelines of the dashboard
+type DashboardPipelinesMsg struct {
+	Load      int
+	Pipelines []concourse.Pipeline
+	Error     error
+}
+
+// DashboardJobsMsg represents the loaded jobs of one pipeline of the dashboard
+type DashboardJobsMsg struct {
+	Load     int
+	Pipeline string
+	Jobs     []concourse.Job
+	Error    error
+}
+
+// NewDashboardViewModel creates a new dashboard model
+func NewDashboardViewModel(stateStore *state.Store) DashboardViewModel {
+	return DashboardViewModel{
+		stateStore: stateStore,
+		maxVisible: 10,
+		loader:     newLoader(),
+	}
+}
+
+// LoadDashboard lists the pipelines of the client's target, to then load
+// their jobs
+func (m *DashboardViewModel) LoadDashboard(client *concourse.Client) tea.Cmd {
+	if m.client == nil || client.GetTarget() != m.client.GetTarget() {
+		m.selected = 0
+		m.scrollOffset = 0
+	}
+	m.client = client
+	m.load++
+	m.loading = true
+	m.err = nil
+	m.rows = make(map[string]dashboardRow)
+	m.semaphore = make(chan struct{}, dashboardConcurrency)
+
+	load := m.load
+	return func() tea.Msg {
+		pipelines, err := client.GetPipelines(false)
+		return DashboardPipelinesMsg{Load: load, Pipelines: pipelines, Error: err}
+	}
+}
+
+// HandleDashboardPipelines shows the listed pipelines and starts loading the
+// jobs of those shown, a few at a time
+func (m DashboardViewModel) HandleDashboardPipelines(msg DashboardPipelinesMsg) (DashboardViewModel, tea.Cmd) {
+	if msg.Load != m.load {
+		return m, nil
+	}
+	m.loading = false
+	m.err = msg.Error
+	m.all = msg.Pipelines
+	m.filterPipelines()
+	cmd := m.loadRows()
+	return m, cmd
+}
+
+// loadRows loads the jobs of the shown pipelines that aren't loaded or loading
+func (m *DashboardViewModel) loadRows() tea.Cmd {
+	client, load, semaphore := m.client, m.load, m.semaphore
+	var cmds []tea.Cmd
+	for _, pipeline := range m.pipelines {
+		ref := pipeline.Ref()
+		if _, ok := m.rows[ref]; ok {
+			continue
+		}
+		m.rows[ref] = dashboardRow{}
+		cmds = append(cmds, func() tea.Msg {
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+			jobs, err := client.GetJobs(ref)
+			return DashboardJobsMsg{Load: load, Pipeline: ref, Jobs: jobs, Error: err}
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
+// HandleDashboardJobs fills in the row of a pipeline whose jobs loaded
+func (m DashboardViewModel) HandleDashboardJobs(msg DashboardJobsMsg) DashboardViewModel {
+	if msg.Load != m.load {
+		return m
+	}
+	m.rows[msg.Pipeline] = dashboardRow{jobs: msg.Jobs, loaded: true, err: msg.Error}
+	return m
+}
+
+// isFavorite returns true if the pipeline is starred for the current target
+func (m DashboardViewModel) isFavorite(pipeline concourse.Pipeline) bool {
+	if m.stateStore == nil || m.client == nil {
+		return false
+	}
+	return m.stateStore.IsFavorite(m.client.GetTarget(), pipeline.Ref())
+}
+
+// filterPipelines picks the pipelines shown, starred ones first, keeping the
+// selected pipeline selected when it is still shown
+func (m *DashboardViewModel) filterPipelines() {
+	selected := ""
+	if m.selected < len(m.pipelines) {
+		selected = m.pipelines[m.selected].Ref()
+	}
+
+	m.pipelines = nil
+	for _, pipeline := range m.all {
+		if !m.favoritesOnly || m.isFavorite(pipeline) {
+			m.pipelines = append(m.pipelines, pipeline)
+		}
+	}
+	sort.SliceStable(m.pipelines, func(i, j int) bool {
+		return m.isFavorite(m.pipelines[i]) && !m.isFavorite(m.pipelines[j])
+	})
+
+	m.selected = 0
+	for i, pipeline := range m.pipelines {
+		if pipeline.Ref() == selected {
+			m.selected = i
+		}
+	}
+	m.scrollOffset = min(m.scrollOffset, m.selected)
+}
+
+// pending returns how many shown pipelines still wait for their jobs
+func (m DashboardViewModel) pending() int {
+	if m.loading || m.err != nil {
+		return 0
+	}
+	count := 0
+	for _, pipeline := range m.pipelines {
+		if row, ok := m.rows[pipeline.Ref()]; ok && !row.loaded {
+			count++
+		}
+	}
+	return count
+}
+
+// canceled returns true when loading was cut short by leaving the view
+func (m DashboardViewModel) canceled() bool {
+	if concourse.IsCanceled(m.err) {
+		return true
+	}
+	for _, row := range m.rows {
+		if concourse.IsCanceled(row.err) {
+			return true
+		}
+	}
+	return false
+}
+
+// Update handles key presses for the dashboard
+func (m DashboardViewModel) Update(msg tea.KeyMsg) (DashboardViewModel, tea.Cmd) {
+	switch {
+	case keys.Matches(msg, actionUp):
+		if m.selected > 0 {
+			m.selected--
+			if m.selected < m.scrollOffset {
+				m.scrollOffset = m.selected
+			}
+		}
+	case keys.Matches(msg, actionDown):
+		if m.selected < len(m.pipelines)-1 {
+			m.selected++
+			if m.selected >= m.scrollOffset+m.maxVisible {
+				m.scrollOffset = m.selected - m.maxVisible + 1
+			}
+		}
+	case keys.Matches(msg, actionSelect):
+		if m.selected < len(m.pipelines) {
+			pipeline := m.pipelines[m.selected].Ref()
+			return m, func() tea.Msg {
+				return SwitchViewMsg{View: ViewJobs, Pipeline: pipeline}
+			}
+		}
+	case keys.Matches(msg, actionFavoritesOnly):
+		if !m.loading && m.err == nil {
+			m.favoritesOnly = !m.favoritesOnly
+			m.filterPipelines()
+			cmd := m.loadRows()
+			return m, cmd
+		}
+	case keys.Matches(msg, actionRefresh):
+		if m.client != nil {
+			cmd := m.LoadDashboard(m.client)
+			return m, cmd
+		}
+	}
+	return m, nil
+}
+
+// visibleRange returns the range of pipelines shown for the given height
+func (m DashboardViewModel) visibleRange(height int) (int, int) {
+	maxVisible := m.maxVisible
+	if height-11 > 0 { // Account for title, progress, indicators and help
+		maxVisible = height - 11
+	}
+
+	start := m.scrollOffset
+	if m.selected >= start+maxVisible {
+		start = m.selected - maxVisible + 1
+	}
+	if m.selected < start {
+		start = m.selected
+	}
+	return start, min(start+maxVisible, len(m.pipelines))
+}
+
+// Mouse handles clicks and scrolling over the dashboard
+func (m DashboardViewModel) Mouse(msg tea.MouseMsg, height int) (DashboardViewModel, tea.Cmd) {
+	if m.loading || m.err != nil {
+		return m, nil
+	}
+
+	start, end := m.visibleRange(height)
+	rows := listRows{top: dashboardListTop, height: 1, first: start, end: end}
+	if start > 0 {
+		rows.top++ // "more above" indicator
+	}
+
+	switch intent, index := m.clicks.interpret(msg, rows); intent {
+	case mouseScrollUp:
+		return m.Update(keyMsgFor(actionUp))
+	case mouseScrollDown:
+		return m.Update(keyMsgFor(actionDown))
+	case mouseClick:
+		m.selected = index
+	case mouseDoubleClick:
+		m.selected = index
+		return m.Update(keyMsgFor(actionSelect))
+	}
+	return m, nil
+}
+
+// renderJobDots renders the latest status of each job as a colored dot, in
+// pipeline order, ending with how many more there are when they don't all fit
+func renderJobDots(jobs []concourse.Job, width int) string {
+	shown := jobs
+	if len(jobs) > width {
+		shown = jobs[:max(width-4, 1)]
+	}
+	var strip strings.Builder
+	for _, job := range shown {
+		dot, color := "●", theme.Muted
+		switch {
+		case job.NextBuild.ID != 0:
+			color = theme.Info
+		case job.FinishedBuild.Status == "succeeded":
+			color = theme.Success
+		case job.FinishedBuild.Status == "failed", job.FinishedBuild.Status == "errored":
+			color = theme.Error
+		case job.FinishedBuild.Status == "aborted":
+			color = theme.Warning
+		default:
+			dot = "○"
+		}
+		strip.WriteString(lipgloss.NewStyle().Foreground(color).Render(dot))
+	}
+	if len(shown) < len(jobs) {
+		strip.WriteString(lipgloss.NewStyle().Foreground(theme.Muted).Render(fmt.Sprintf(" +%d", len(jobs)-len(shown))))
+	}
+	return strip.String()
+}
+
+// renderRow renders the jobs of a pipeline for its line of the grid
+func (m DashboardViewModel) renderRow(pipeline concourse.Pipeline, width int) string {
+	mutedStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+	row := m.rows[pipeline.Ref()]
+	var cell string
+	switch {
+	case !row.loaded:
+		cell = mutedStyle.Render("loading...")
+	case row.err != nil:
+		cell = lipgloss.NewStyle().Foreground(theme.Error).Render(errorSummary(row.err))
+	case len(row.jobs) == 0:
+		cell = mutedStyle.Render("no jobs")
+	default:
+		cell = renderJobDots(row.jobs, width)
+		if failing := row.failing(); failing > 0 {
+			cell += lipgloss.NewStyle().Foreground(theme.Error).Render(fmt.Sprintf("  %d failing", failing))
+		}
+	}
+	if pipeline.Paused {
+		cell += lipgloss.NewStyle().Foreground(theme.Warning).Render("  paused")
+	}
+	return cell
+}
+
+// View renders the dashboard
+func (m DashboardViewModel) View(width, height int) string {
+	titleStyle := lipgloss.NewStyle().
+		Foreground(theme.Primary).
+		Bold(true).
+		MarginBottom(1)
+
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(theme.Muted)
+
+	itemStyle := lipgloss.NewStyle().
+		PaddingLeft(2)
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(theme.Primary).
+		Bold(true).
+		PaddingLeft(1).
+		Border(lipgloss.NormalBorder(), false, false, false, true).
+		BorderForeground(theme.Primary)
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(theme.Muted).
+		Italic(true).
+		MarginTop(1)
+
+	var content strings.Builder
+	title := "Dashboard"
+	if m.client != nil {
+		title += " - " + m.client.GetTarget()
+	}
+	if m.favoritesOnly {
+		title += " (favorites only)"
+	}
+	content.WriteString(titleStyle.Render(title))
+	content.WriteString("\n\n")
+
+	if m.loading {
+		content.WriteString(m.loader.View("Loading pipelines...") + "\n")
+		return content.String()
+	}
+
+	if m.err != nil {
+		errorStyle := lipgloss.NewStyle().Foreground(theme.Error)
+		content.WriteString(errorStyle.Render("Error: " + errorSummary(m.err)))
+		content.WriteString("\n")
+		return content.String()
+	}
+
+	if pending := m.pending(); pending > 0 {
+		content.WriteString(m.loader.View(fmt.Sprintf("Loading jobs, %d of %d pipelines done", len(m.pipelines)-pending, len(m.pipelines))))
+	} else {
+		failing := 0
+		for _, pipeline := range m.pipelines {
+			if m.rows[pipeline.Ref()].failing() > 0 {
+				failing++
+			}
+		}
+		content.WriteString(mutedStyle.Render(fmt.Sprintf("%d pipelines, %d with failing jobs", len(m.pipelines), failing)))
+	}
+	content.WriteString("\n\n")
+
+	switch {
+	case len(m.pipelines) == 0 && m.favoritesOnly:
+		content.WriteString(fmt.Sprintf("No starred pipelines, star them with %s in the pipelines view.\n", keys.Label(actionFavorite)))
+	case len(m.pipelines) == 0:
+		content.WriteString("No pipelines found.\n")
+	default:
+		nameWidth := 0
+		for _, pipeline := range m.pipelines {
+			nameWidth = max(nameWidth, len(pipeline.Ref()))
+		}
+		nameWidth = min(nameWidth, max(width/3, 20))
+		dotsWidth := max(width-nameWidth-30, 10)
+
+		start, end := m.visibleRange(height)
+		if start > 0 {
+			content.WriteString(itemStyle.Render(fmt.Sprintf("  ↑ (%d more above)", start)))
+			content.WriteString("\n")
+		}
+		for i := start; i < end; i++ {
+			pipeline := m.pipelines[i]
+			name := pipeline.Ref()
+			if m.isFavorite(pipeline) {
+				name = "★ " + name
+			}
+			if len(name) > nameWidth {
+				name = name[:nameWidth-1] + "…"
+			}
+			line := fmt.Sprintf("%-*s  ", nameWidth, name) + m.renderRow(pipeline, dotsWidth)
+			if i == m.selected {
+				content.WriteString(selectedStyle.Render("> " + line))
+			} else {
+				content.WriteString(itemStyle.Render("  " + line))
+			}
+			content.WriteString("\n")
+		}
+		if end < len(m.pipelines) {
+			content.WriteString(itemStyle.Render(fmt.Sprintf("  ↓ (%d more below)", len(m.pipelines)-end)))
+			content.WriteString("\n")
+		}
+	}
+
+	help := keys.HelpLine(viewKeys[ViewDashboard]...)
+	content.WriteString(helpStyle.Render(help))
+
+	return content.String()
+}
@@ -0,0 +1,147 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// errorSummaryWidth is the longest error views show inline
+const errorSummaryWidth = 80
+
+// errorModal shows the full text of an error in a scrollable viewport
+type errorModal struct {
+	text     string
+	viewport viewport.Model
+}
+
+// errorSummary renders err as a single line, pointing at the error modal
+// when part of the message had to be cut
+func errorSummary(err error) string {
+	text := strings.TrimSpace(err.Error())
+	summary := ansi.Truncate(strings.SplitN(text, "\n", 2)[0], errorSummaryWidth, "…")
+	if summary != text {
+		summary += fmt.Sprintf(" (%s: details)", keys.Label(actionErrorDetails))
+	}
+	return summary
+}
+
+// currentError returns the full text of the error on screen, preferring the
+// newest error toast over the current view's error state
+func (m *Model) currentError() string {
+	for i := len(m.toasts) - 1; i >= 0; i-- {
+		if m.toasts[i].level == ToastError {
+			return m.toasts[i].text
+		}
+	}
+
+	var err error
+	switch m.currentView {
+	case ViewPipelines:
+		err = m.pipelinesView.err
+	case ViewJobs:
+		err = m.jobsView.err
+		if err == nil {
+			err = m.jobsView.watchErr
+		}
+	case ViewResources:
+		err = m.resourcesView.err
+		if err == nil {
+			err = m.resourcesView.checkError
+		}
+	case ViewBuilds:
+		err = m.buildsView.err
+	case ViewAddTarget:
+		err = m.addTargetView.err
+	case ViewAuth:
+		err = m.authView.error
+	case ViewSync:
+		err = m.syncView.error
+	case ViewWatchlist:
+		err = m.watchlistView.err
+	case ViewTeams:
+		err = m.teamsView.err
+	case ViewActiveUsers:
+		err = m.activeUsersView.err
+	case ViewGraph:
+		err = m.graphView.err
+	case ViewFlaky:
+		err = m.flakyView.err
+	case ViewBuildLog:
+		err = m.buildLogView.err
+	case ViewDashboard:
+		err = m.dashboardView.err
+	case ViewConfig:
+		err = m.configView.err
+	case ViewSearch:
+		err = m.searchView.err
+	}
+	if err == nil {
+		return ""
+	}
+	return strings.TrimSpace(err.Error())
+}
+
+// openErrorModal shows the current error in the error modal
+func (m *Model) openErrorModal(text string) {
+	width := max(20, min(100, m.width-8))
+	height := max(3, m.contentHeight()-8)
+	wrapped := lipgloss.NewStyle().Width(width).Render(text)
+
+	vp := viewport.New(width, min(height, lipgloss.Height(wrapped)))
+	vp.SetContent(wrapped)
+	m.errorModal = &errorModal{text: text, viewport: vp}
+}
+
+// handleErrorModalKey handles keys while the error modal is open
+func (m *Model) handleErrorModalKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case keys.Matches(msg, actionBack), keys.Matches(msg, actionErrorDetails):
+		m.errorModal = nil
+		return m, nil
+	case keys.Matches(msg, actionCopy):
+		if err := copyToClipboard(m.settings.ClipboardCommand, m.errorModal.text); err != nil {
+			return m, showToast(ToastError, "Failed to copy error: %v", err)
+		}
+		return m, showToast(ToastSuccess, "Error copied to clipboard")
+	}
+
+	var cmd tea.Cmd
+	m.errorModal.viewport, cmd = m.errorModal.viewport.Update(msg)
+	return m, cmd
+}
+
+// overlayErrorModal draws the error modal in the middle of the view
+func (m *Model) overlayErrorModal(view string) string {
+	if m.errorModal == nil {
+		return view
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(theme.Error).
+		Bold(true)
+	helpStyle := lipgloss.NewStyle().
+		Foreground(theme.Muted).
+		Italic(true)
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Error).
+		Foreground(theme.Text).
+		Padding(1, 2)
+
+	vp := m.errorModal.viewport
+	help := fmt.Sprintf("%s • %s • %s: close", fixedHelp("scroll", "up", "down"), keys.Help(actionCopy), keys.Label(actionBack))
+	if !vp.AtTop() || !vp.AtBottom() {
+		help = fmt.Sprintf("%3.f%% • %s", vp.ScrollPercent()*100, help)
+	}
+	body := fmt.Sprintf("%s\n\n%s\n\n%s", titleStyle.Render("Error details"), vp.View(), helpStyle.Render(help))
+	modal := boxStyle.Render(body)
+
+	left := max(0, (m.width-lipgloss.Width(modal))/2)
+	top := max(0, (lipgloss.Height(view)-lipgloss.Height(modal))/2)
+	return placeOverlay(view, modal, left, top)
+}
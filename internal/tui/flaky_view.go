@@ -0,0 +1,444 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"flyby/internal/concourse"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// flakyConcurrency bounds the number of build histories fetched at once
+const flakyConcurrency = 4
+
+// flakyWindows are the numbers of latest builds per job the report can cover
+var flakyWindows = []int{10, 25, 50, 100}
+
+// flakyJob is the build history of one job, scored for flakiness
+type flakyJob struct {
+	name     string
+	outcomes []string // statuses of the finished builds, oldest first
+	failures int      // failed or errored builds
+	flaps    int      // times the outcome changed between passing and failing
+	err      error
+}
+
+// newFlakyJob scores a job's builds, which fly lists newest first
+func newFlakyJob(name string, builds []concourse.Build, err error) flakyJob {
+	job := flakyJob{name: name, err: err}
+	previous := ""
+	for i := len(builds) - 1; i >= 0; i-- {
+		status := builds[i].Status
+		switch status {
+		case "succeeded":
+		case "failed", "errored":
+			job.failures++
+		case "aborted":
+			// Aborted builds neither pass nor fail
+			job.outcomes = append(job.outcomes, status)
+			continue
+		default:
+			// Still running
+			continue
+		}
+		if previous != "" && (previous == "succeeded") != (status == "succeeded") {
+			job.flaps++
+		}
+		previous = status
+		job.outcomes = append(job.outcomes, status)
+	}
+	return job
+}
+
+// judged returns how many builds passed or failed
+func (j flakyJob) judged() int {
+	judged := 0
+	for _, outcome := range j.outcomes {
+		if outcome != "aborted" {
+			judged++
+		}
+	}
+	return judged
+}
+
+// failureRate returns the share of the judged builds that failed
+func (j flakyJob) failureRate() float64 {
+	if judged := j.judged(); judged > 0 {
+		return float64(j.failures) / float64(judged)
+	}
+	return 0
+}
+
+// flapRate returns the share of consecutive builds whose outcome flipped
+func (j flakyJob) flapRate() float64 {
+	if judged := j.judged(); judged > 1 {
+		return float64(j.flaps) / float64(judged-1)
+	}
+	return 0
+}
+
+// FlakyViewModel represents the report ranking a pipeline's jobs by how
+// often their builds fail and flip between passing and failing
+type FlakyViewModel struct {
+	client       *concourse.Client
+	pipeline     string
+	window       int        // latest builds per job
+	scan         int        // tells results of the current scan from older ones
+	total        int        // jobs being scanned
+	jobs         []flakyJob // most flaky first
+	selected     int
+	scrollOffset int
+	maxVisible   int
+	loading      bool // listing the jobs
+	err          error
+	clicks       clickTracker
+	loader       loader
+}
+
+// FlakyJobsMsg represents the listed jobs of a pipeline to scan
+type FlakyJobsMsg struct {
+	Scan  int
+	Jobs  []concourse.Job
+	Error error
+}
+
+// FlakyHistoryMsg represents the fetched build history of one job
+type FlakyHistoryMsg struct {
+	Scan   int
+	Job    string
+	Builds []concourse.Build
+	Error  error
+}
+
+// NewFlakyViewModel creates a new flaky job report model
+func NewFlakyViewModel() FlakyViewModel {
+	return FlakyViewModel{
+		window:     flakyWindows[1],
+		maxVisible: 10,
+		loader:     newLoader(),
+	}
+}
+
+// LoadReport lists the jobs of a pipeline, to then scan their histories
+func (m *FlakyViewModel) LoadReport(client *concourse.Client, pipeline string) tea.Cmd {
+	if pipeline != m.pipeline {
+		m.selected = 0
+		m.scrollOffset = 0
+	}
+	m.client = client
+	m.pipeline = pipeline
+	m.scan++
+	m.loading = true
+	m.err = nil
+	m.jobs = nil
+	m.total = 0
+
+	scan := m.scan
+	return func() tea.Msg {
+		jobs, err := client.GetJobs(pipeline)
+		return FlakyJobsMsg{Scan: scan, Jobs: jobs, Error: err}
+	}
+}
+
+// HandleFlakyJobs starts fetching the build history of every listed job, a
+// few at a time
+func (m FlakyViewModel) HandleFlakyJobs(msg FlakyJobsMsg) (FlakyViewModel, tea.Cmd) {
+	if msg.Scan != m.scan {
+		return m, nil
+	}
+	m.loading = false
+	m.err = msg.Error
+	if msg.Error != nil {
+		return m, nil
+	}
+
+	m.total = len(msg.Jobs)
+	semaphore := make(chan struct{}, flakyConcurrency)
+	client, pipeline, window, scan := m.client, m.pipeline, m.window, m.scan
+	var cmds []tea.Cmd
+	for _, job := range msg.Jobs {
+		name := job.Name
+		cmds = append(cmds, func() tea.Msg {
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+			builds, err := client.GetBuilds(pipeline, name, window)
+			return FlakyHistoryMsg{Scan: scan, Job: name, Builds: builds, Error: err}
+		})
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// canceled returns true when the scan was cut short by leaving the view
+func (m FlakyViewModel) canceled() bool {
+	if concourse.IsCanceled(m.err) {
+		return true
+	}
+	for _, job := range m.jobs {
+		if concourse.IsCanceled(job.err) {
+			return true
+		}
+	}
+	return false
+}
+
+// HandleFlakyHistory scores a fetched history and ranks it with the others
+func (m FlakyViewModel) HandleFlakyHistory(msg FlakyHistoryMsg) FlakyViewModel {
+	if msg.Scan != m.scan {
+		return m
+	}
+	selected := ""
+	if m.selected < len(m.jobs) {
+		selected = m.jobs[m.selected].name
+	}
+
+	m.jobs = append(m.jobs, newFlakyJob(msg.Job, msg.Builds, msg.Error))
+	sort.SliceStable(m.jobs, func(i, j int) bool {
+		a, b := m.jobs[i], m.jobs[j]
+		if a.flapRate() != b.flapRate() {
+			return a.flapRate() > b.flapRate()
+		}
+		if a.failureRate() != b.failureRate() {
+			return a.failureRate() > b.failureRate()
+		}
+		return a.name < b.name
+	})
+
+	// Keep the selection on the same job as results come in
+	for i, job := range m.jobs {
+		if job.name == selected {
+			m.selected = i
+		}
+	}
+	return m
+}
+
+// scanning reports whether build histories are still being fetched
+func (m FlakyViewModel) scanning() bool {
+	return !m.loading && m.err == nil && len(m.jobs) < m.total
+}
+
+// Update handles messages for the flaky job report
+func (m FlakyViewModel) Update(msg tea.KeyMsg) (FlakyViewModel, tea.Cmd) {
+	switch {
+	case keys.Matches(msg, actionUp):
+		if m.selected > 0 {
+			m.selected--
+			if m.selected < m.scrollOffset {
+				m.scrollOffset = m.selected
+			}
+		}
+	case keys.Matches(msg, actionDown):
+		if m.selected < len(m.jobs)-1 {
+			m.selected++
+			if m.selected >= m.scrollOffset+m.maxVisible {
+				m.scrollOffset = m.selected - m.maxVisible + 1
+			}
+		}
+	case keys.Matches(msg, actionSelect), keys.Matches(msg, actionBuilds):
+		if m.selected < len(m.jobs) {
+			job, pipeline := m.jobs[m.selected].name, m.pipeline
+			return m, func() tea.Msg {
+				return SwitchViewMsg{View: ViewBuilds, Job: job, Pipeline: pipeline}
+			}
+		}
+	case keys.Matches(msg, actionWindow):
+		for i, window := range flakyWindows {
+			if window == m.window {
+				m.window = flakyWindows[(i+1)%len(flakyWindows)]
+				break
+			}
+		}
+		if m.client != nil {
+			cmd := m.LoadReport(m.client, m.pipeline)
+			return m, cmd
+		}
+	case keys.Matches(msg, actionRefresh):
+		if m.client != nil {
+			cmd := m.LoadReport(m.client, m.pipeline)
+			return m, cmd
+		}
+	}
+	return m, nil
+}
+
+// visibleRange returns the range of jobs shown for the given height
+func (m FlakyViewModel) visibleRange(height int) (int, int) {
+	maxVisible := m.maxVisible
+	if height-12 > 0 { // Account for title, progress, header, indicators and help
+		maxVisible = height - 12
+	}
+
+	start := m.scrollOffset
+	if m.selected >= start+maxVisible {
+		start = m.selected - maxVisible + 1
+	}
+	if m.selected < start {
+		start = m.selected
+	}
+	return start, min(start+maxVisible, len(m.jobs))
+}
+
+// flakyListTop is the line of the first job: title, margin, blank line,
+// progress or summary, blank line and the column headings
+const flakyListTop = 6
+
+// Mouse handles clicks and scrolling over the report
+func (m FlakyViewModel) Mouse(msg tea.MouseMsg, height int) (FlakyViewModel, tea.Cmd) {
+	if m.loading || m.err != nil {
+		return m, nil
+	}
+
+	start, end := m.visibleRange(height)
+	rows := listRows{top: flakyListTop, height: 1, first: start, end: end}
+	if start > 0 {
+		rows.top++ // "more above" indicator
+	}
+
+	switch intent, index := m.clicks.interpret(msg, rows); intent {
+	case mouseScrollUp:
+		return m.Update(keyMsgFor(actionUp))
+	case mouseScrollDown:
+		return m.Update(keyMsgFor(actionDown))
+	case mouseClick:
+		m.selected = index
+	case mouseDoubleClick:
+		m.selected = index
+		return m.Update(keyMsgFor(actionBuilds))
+	}
+	return m, nil
+}
+
+// renderOutcomes renders a job's latest outcomes as a strip of colored dots,
+// oldest first, keeping the latest ones when they don't all fit
+func renderOutcomes(outcomes []string, width int) string {
+	if len(outcomes) > width {
+		outcomes = outcomes[len(outcomes)-width:]
+	}
+	var strip strings.Builder
+	for _, outcome := range outcomes {
+		dot, color := "●", theme.Success
+		switch outcome {
+		case "failed", "errored":
+			color = theme.Error
+		case "aborted":
+			dot, color = "○", theme.Warning
+		}
+		strip.WriteString(lipgloss.NewStyle().Foreground(color).Render(dot))
+	}
+	return strip.String()
+}
+
+// renderProgress renders how far the scan has come as a bar
+func (m FlakyViewModel) renderProgress() string {
+	const barWidth = 20
+	filled := 0
+	if m.total > 0 {
+		filled = len(m.jobs) * barWidth / m.total
+	}
+	full, empty := "█", "░"
+	if asciiOnly {
+		full, empty = "#", "."
+	}
+	bar := lipgloss.NewStyle().Foreground(theme.Primary).Render(strings.Repeat(full, filled)) +
+		lipgloss.NewStyle().Foreground(theme.Muted).Render(strings.Repeat(empty, barWidth-filled))
+	return m.loader.View(fmt.Sprintf("Scanning build history %s %d/%d jobs", bar, len(m.jobs), m.total))
+}
+
+// View renders the flaky job report
+func (m FlakyViewModel) View(width, height int) string {
+	titleStyle := lipgloss.NewStyle().
+		Foreground(theme.Primary).
+		Bold(true).
+		MarginBottom(1)
+
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(theme.Muted)
+
+	itemStyle := lipgloss.NewStyle().
+		PaddingLeft(2)
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(theme.Primary).
+		Bold(true).
+		PaddingLeft(1).
+		Border(lipgloss.NormalBorder(), false, false, false, true).
+		BorderForeground(theme.Primary)
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(theme.Muted).
+		Italic(true).
+		MarginTop(1)
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render("Flaky Jobs - " + m.pipeline))
+	content.WriteString("\n\n")
+
+	if m.loading {
+		content.WriteString(m.loader.View("Loading jobs...") + "\n")
+		return content.String()
+	}
+
+	if m.err != nil {
+		errorStyle := lipgloss.NewStyle().Foreground(theme.Error)
+		content.WriteString(errorStyle.Render("Error: " + errorSummary(m.err)))
+		content.WriteString("\n")
+		return content.String()
+	}
+
+	if m.scanning() {
+		content.WriteString(m.renderProgress())
+	} else {
+		content.WriteString(mutedStyle.Render(fmt.Sprintf("Latest %d builds of %d jobs, most flaky first", m.window, m.total)))
+	}
+	content.WriteString("\n\n")
+
+	if m.total == 0 {
+		content.WriteString("Pipeline has no jobs.\n")
+	} else if len(m.jobs) > 0 {
+		nameWidth := len("Job")
+		for _, job := range m.jobs {
+			nameWidth = max(nameWidth, len(job.name))
+		}
+		stripWidth := max(width-nameWidth-26, 10)
+
+		content.WriteString(itemStyle.Render(mutedStyle.Render(fmt.Sprintf("  %-*s  %6s  %6s  %s", nameWidth, "Job", "Flaps", "Fails", "Builds"))))
+		content.WriteString("\n")
+
+		start, end := m.visibleRange(height)
+		if start > 0 {
+			content.WriteString(itemStyle.Render(fmt.Sprintf("  ↑ (%d more above)", start)))
+			content.WriteString("\n")
+		}
+		for i := start; i < end; i++ {
+			job := m.jobs[i]
+			var line string
+			switch {
+			case job.err != nil:
+				line = fmt.Sprintf("%-*s  ", nameWidth, job.name) + lipgloss.NewStyle().Foreground(theme.Error).Render(errorSummary(job.err))
+			case job.judged() == 0:
+				line = fmt.Sprintf("%-*s  %6s  %6s  ", nameWidth, job.name, "-", "-") + mutedStyle.Render("no finished builds")
+			default:
+				line = fmt.Sprintf("%-*s  %5.0f%%  %5.0f%%  ", nameWidth, job.name, job.flapRate()*100, job.failureRate()*100) +
+					renderOutcomes(job.outcomes, stripWidth)
+			}
+			if i == m.selected {
+				content.WriteString(selectedStyle.Render("> " + line))
+			} else {
+				content.WriteString(itemStyle.Render("  " + line))
+			}
+			content.WriteString("\n")
+		}
+		if end < len(m.jobs) {
+			content.WriteString(itemStyle.Render(fmt.Sprintf("  ↓ (%d more below)", len(m.jobs)-end)))
+			content.WriteString("\n")
+		}
+	}
+
+	help := keys.HelpLine(viewKeys[ViewFlaky]...)
+	content.WriteString(helpStyle.Render(help))
+
+	return content.String()
+}
@@ -0,0 +1,159 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"flyby/internal/config"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// backupPickerRows is how many backups the backup picker shows at once
+const backupPickerRows = 10
+
+// backupPicker lists the backups of the flyrc, newest first, to restore one
+type backupPicker struct {
+	backups  []config.FlyrcBackup
+	selected int
+}
+
+// FlyrcRestoredMsg represents the result of restoring a backup of the flyrc
+type FlyrcRestoredMsg struct {
+	Backup config.FlyrcBackup
+	Error  error
+}
+
+// openBackupPicker lists the backups of the flyrc, or says why there are none
+func (m TargetsViewModel) openBackupPicker() (TargetsViewModel, tea.Cmd) {
+	path := m.configManager.GetConfigPath()
+	if path == "" {
+		return m, showToast(ToastInfo, "No flyrc backups in demo mode")
+	}
+	backups, err := m.configManager.Backups()
+	if err != nil {
+		return m, showToast(ToastError, "Failed to list backups of %s: %v", path, err)
+	}
+	if len(backups) == 0 {
+		return m, showToast(ToastInfo, "No backups of %s yet, FlyBy makes one before each change it saves", path)
+	}
+	m.backupPicker = &backupPicker{backups: backups}
+	return m, nil
+}
+
+// updateBackupPicker handles keys while the backup picker is open
+func (m TargetsViewModel) updateBackupPicker(msg tea.KeyMsg) (TargetsViewModel, tea.Cmd) {
+	picker := *m.backupPicker
+	switch {
+	case keys.Matches(msg, actionBack):
+		m.backupPicker = nil
+		return m, nil
+	case keys.Matches(msg, actionUp):
+		if picker.selected > 0 {
+			picker.selected--
+		}
+	case keys.Matches(msg, actionDown):
+		if picker.selected < len(picker.backups)-1 {
+			picker.selected++
+		}
+	case keys.Matches(msg, actionSelect):
+		m.backupPicker = nil
+		return m, m.confirmRestore(picker.backups[picker.selected])
+	}
+	m.backupPicker = &picker
+	return m, nil
+}
+
+// confirmRestore asks the user before replacing the flyrc with a backup
+func (m TargetsViewModel) confirmRestore(backup config.FlyrcBackup) tea.Cmd {
+	configManager := m.configManager
+	message := fmt.Sprintf("Replace %s with the backup from %s? The current file is backed up first.",
+		configManager.GetConfigPath(), backup.Time.Format("2006-01-02 15:04:05"))
+	return confirmAction("Restore flyrc", message, func() tea.Msg {
+		return FlyrcRestoredMsg{Backup: backup, Error: configManager.RestoreBackup(backup)}
+	})
+}
+
+// handleFlyrcRestored loads the restored flyrc, reporting the targets it
+// brought back or dropped
+func (m *Model) handleFlyrcRestored(msg FlyrcRestoredMsg) tea.Cmd {
+	path := m.configManager.GetConfigPath()
+	if msg.Error != nil {
+		return showToast(ToastError, "Failed to restore %s: %v", path, msg.Error)
+	}
+	return tea.Batch(
+		showToast(ToastSuccess, "Restored %s from the backup of %s", path, msg.Backup.Time.Format("2006-01-02 15:04:05")),
+		m.loadChangedFlyrc(),
+	)
+}
+
+// backupChanges returns the targets restoring a backup would add to and
+// remove from the flyrc
+func (m TargetsViewModel) backupChanges(backup config.FlyrcBackup) (restored, dropped []string) {
+	inBackup := make(map[string]bool)
+	for _, name := range backup.Targets {
+		inBackup[name] = true
+		if _, exists := m.configManager.GetTarget(name); !exists {
+			restored = append(restored, name)
+		}
+	}
+	for _, name := range m.configManager.GetTargetNames() {
+		if !inBackup[name] {
+			dropped = append(dropped, name)
+		}
+	}
+	return restored, dropped
+}
+
+// viewBackupPicker renders the backup picker below the targets list
+func (m TargetsViewModel) viewBackupPicker() string {
+	picker := m.backupPicker
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Primary).
+		Padding(0, 1).
+		MarginTop(1)
+	titleStyle := lipgloss.NewStyle().
+		Foreground(theme.Primary).
+		Bold(true)
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(theme.Primary).
+		Bold(true)
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(theme.Muted)
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render("Restore " + m.configManager.GetConfigPath()))
+	content.WriteString("\n\n")
+
+	start := 0
+	if picker.selected >= backupPickerRows {
+		start = picker.selected - backupPickerRows + 1
+	}
+	end := start + backupPickerRows
+	if end > len(picker.backups) {
+		end = len(picker.backups)
+	}
+	for i := start; i < end; i++ {
+		backup := picker.backups[i]
+		line := fmt.Sprintf("%s  %d targets", backup.Time.Format("2006-01-02 15:04:05"), len(backup.Targets))
+		restored, dropped := m.backupChanges(backup)
+		if len(restored) > 0 {
+			line += mutedStyle.Render(" • brings back " + strings.Join(restored, ", "))
+		}
+		if len(dropped) > 0 {
+			line += mutedStyle.Render(" • drops " + strings.Join(dropped, ", "))
+		}
+		if i == picker.selected {
+			content.WriteString(selectedStyle.Render("> " + line))
+		} else {
+			content.WriteString("  " + line)
+		}
+		content.WriteString("\n")
+	}
+	if len(picker.backups) > backupPickerRows {
+		content.WriteString(mutedStyle.Render(fmt.Sprintf("%d of %d backups", picker.selected+1, len(picker.backups))))
+	}
+	return boxStyle.Render(strings.TrimRight(content.String(), "\n"))
+}
@@ -0,0 +1,70 @@
+package tui
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// flyrcPollInterval is how often FlyBy checks whether another program, like
+// fly login in another terminal, changed the flyrc
+const flyrcPollInterval = 2 * time.Second
+
+// FlyrcPollMsg triggers a check for changes to the flyrc
+type FlyrcPollMsg struct{}
+
+// watchFlyrc schedules the next check for changes to the flyrc
+func watchFlyrc() tea.Cmd {
+	return tea.Tick(flyrcPollInterval, func(time.Time) tea.Msg {
+		return FlyrcPollMsg{}
+	})
+}
+
+// reloadFlyrc picks up the targets and tokens another program wrote to the
+// flyrc, then keeps watching it
+func (m *Model) reloadFlyrc() tea.Cmd {
+	if !m.configManager.Changed() {
+		return watchFlyrc()
+	}
+	return tea.Batch(watchFlyrc(), m.loadChangedFlyrc())
+}
+
+// loadChangedFlyrc loads the flyrc after it changed on disk, reporting the
+// targets added to and removed from it
+func (m *Model) loadChangedFlyrc() tea.Cmd {
+	before := m.configManager.GetTargets()
+	if err := m.configManager.LoadConfig(); err != nil {
+		// fly may be halfway through writing it, the next check retries
+		return nil
+	}
+
+	var added, removed []string
+	after := m.configManager.GetTargets()
+	for _, name := range m.configManager.GetTargetNames() {
+		previous, existed := before[name]
+		if !existed {
+			added = append(added, name)
+		} else if previous.GetTokenValue() != after[name].GetTokenValue() {
+			// Logged in again, maybe as someone else
+			delete(m.userInfo, name)
+		}
+	}
+	for name := range before {
+		if _, exists := after[name]; !exists {
+			removed = append(removed, name)
+		}
+	}
+	sort.Strings(removed)
+	m.targetsView.reloadTargets()
+
+	cmds := []tea.Cmd{m.targetsView.CheckHealth(false), m.loadUserInfo()}
+	if len(added) > 0 {
+		cmds = append(cmds, showToast(ToastInfo, "New in %s: %s", m.configManager.GetConfigPath(), strings.Join(added, ", ")))
+	}
+	if len(removed) > 0 {
+		cmds = append(cmds, showToast(ToastInfo, "Removed from %s: %s", m.configManager.GetConfigPath(), strings.Join(removed, ", ")))
+	}
+	return tea.Batch(cmds...)
+}
@@ -0,0 +1,79 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// plainTheme renders everything without colors, for NO_COLOR and --plain
+var plainTheme = Theme{
+	Name:     "plain",
+	Primary:  lipgloss.NoColor{},
+	Muted:    lipgloss.NoColor{},
+	Error:    lipgloss.NoColor{},
+	Success:  lipgloss.NoColor{},
+	Warning:  lipgloss.NoColor{},
+	Info:     lipgloss.NoColor{},
+	HeaderBg: lipgloss.NoColor{},
+	HeaderFg: lipgloss.NoColor{},
+	FooterBg: lipgloss.NoColor{},
+	Text:     lipgloss.NoColor{},
+}
+
+// asciiOnly replaces emoji and unicode glyphs in the rendered output
+var asciiOnly bool
+
+// asciiGlyphs maps the unicode glyphs used by the views to ASCII equivalents.
+// The views are already laid out when the glyphs are replaced, so each
+// replacement takes as many cells as its glyph: one, or two for emoji.
+var asciiGlyphs = strings.NewReplacer(
+	"✅", "ok",
+	"✓", "+",
+	"❌", "xx",
+	"✗", "x",
+	"🔄", "..",
+	"⏳", "..",
+	"⏭", ">",
+	"📝", ">_",
+	"🔐", "#!",
+	"ℹ", "i",
+	"…", "~",
+	"★", "*",
+	"●", "*",
+	"○", "o",
+	"└", "`",
+	"█", "_",
+	"•", "|",
+	"›", ">",
+	"▾", "v",
+	"▸", ">",
+	"↑", "^",
+	"↓", "v",
+	"←", "<",
+	"→", ">",
+	"╭", "+",
+	"╮", "+",
+	"╰", "+",
+	"╯", "+",
+	"─", "-",
+	"│", "|",
+)
+
+// DisableColors switches to the colorless theme and keeps it there
+func DisableColors() {
+	theme = plainTheme
+}
+
+// SetASCIIOnly enables or disables ASCII-only rendering
+func SetASCIIOnly(enabled bool) {
+	asciiOnly = enabled
+}
+
+// renderGlyphs applies ASCII-only mode to rendered output
+func renderGlyphs(view string) string {
+	if !asciiOnly {
+		return view
+	}
+	return asciiGlyphs.Replace(view)
+}
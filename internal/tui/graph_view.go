@@ -0,0 +1,326 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"flyby/internal/concourse"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// graphTop is the line the graph starts on: title, margin and a blank line
+const graphTop = 3
+
+// graphChrome is how many lines around the graph the view takes: the title
+// above, the selected job, legend and help below
+const graphChrome = graphTop + 5
+
+// GraphViewModel represents a pipeline drawn as a graph of its jobs, linked
+// by the passed constraints of their inputs
+type GraphViewModel struct {
+	client   *concourse.Client
+	pipeline string
+	graph    pipelineGraph
+	nodes    []graphNode              // jobs in graph order
+	jobs     map[string]concourse.Job // by name, for their latest builds
+	selected int
+	loading  bool
+	err      error
+	clicks   clickTracker
+	loader   loader
+}
+
+// GraphLoadedMsg represents a loaded pipeline config and the pipeline's jobs
+type GraphLoadedMsg struct {
+	Pipeline string
+	Config   concourse.PipelineConfig
+	Jobs     []concourse.Job
+	Error    error
+}
+
+// NewGraphViewModel creates a new graph view model
+func NewGraphViewModel() GraphViewModel {
+	return GraphViewModel{
+		loader: newLoader(),
+	}
+}
+
+// LoadGraph loads the config and jobs of a pipeline
+func (m *GraphViewModel) LoadGraph(client *concourse.Client, pipeline string) tea.Cmd {
+	if pipeline != m.pipeline {
+		m.selected = 0
+	}
+	m.client = client
+	m.pipeline = pipeline
+	m.loading = true
+	m.err = nil
+	return func() tea.Msg {
+		config, err := client.GetPipelineConfig(pipeline)
+		if err != nil {
+			return GraphLoadedMsg{Pipeline: pipeline, Error: err}
+		}
+		jobs, err := client.GetJobs(pipeline)
+		return GraphLoadedMsg{Pipeline: pipeline, Config: config, Jobs: jobs, Error: err}
+	}
+}
+
+// HandleGraphLoaded lays out a loaded pipeline
+func (m GraphViewModel) HandleGraphLoaded(msg GraphLoadedMsg) GraphViewModel {
+	if msg.Pipeline != m.pipeline {
+		return m
+	}
+	m.loading = false
+	m.err = msg.Error
+	if msg.Error != nil {
+		return m
+	}
+
+	selected := m.selectedJob()
+	m.graph = newPipelineGraph(msg.Config)
+	m.nodes = m.graph.jobs()
+	m.jobs = make(map[string]concourse.Job)
+	for _, job := range msg.Jobs {
+		m.jobs[job.Name] = job
+	}
+	// Keep the selection on the same job across refreshes
+	m.selected = 0
+	for i, node := range m.nodes {
+		if node.job == selected {
+			m.selected = i
+		}
+	}
+	return m
+}
+
+// selectedJob returns the name of the selected job, if any
+func (m GraphViewModel) selectedJob() string {
+	if m.selected < len(m.nodes) {
+		return m.nodes[m.selected].job
+	}
+	return ""
+}
+
+// Update handles messages for the graph view
+func (m GraphViewModel) Update(msg tea.KeyMsg) (GraphViewModel, tea.Cmd) {
+	switch {
+	case keys.Matches(msg, actionUp):
+		if m.selected > 0 {
+			m.selected--
+		}
+	case keys.Matches(msg, actionDown):
+		if m.selected < len(m.nodes)-1 {
+			m.selected++
+		}
+	case keys.Matches(msg, actionSelect), keys.Matches(msg, actionBuilds):
+		return m, m.openBuilds()
+	case keys.Matches(msg, actionRefresh):
+		if m.client != nil {
+			cmd := m.LoadGraph(m.client, m.pipeline)
+			return m, cmd
+		}
+	}
+	return m, nil
+}
+
+// openBuilds switches to the builds of the selected job
+func (m GraphViewModel) openBuilds() tea.Cmd {
+	job := m.selectedJob()
+	if job == "" || m.loading {
+		return nil
+	}
+	pipeline := m.pipeline
+	return func() tea.Msg {
+		return SwitchViewMsg{View: ViewBuilds, Job: job, Pipeline: pipeline}
+	}
+}
+
+// offset returns how far the graph is scrolled so the selected job stays in
+// a window of the given size
+func (m GraphViewModel) offset(width, height int) (int, int) {
+	if m.selected >= len(m.nodes) {
+		return 0, 0
+	}
+	node := m.nodes[m.selected]
+	x, y := 0, 0
+	if right := m.graph.columnX(node.column) + m.graph.widths[node.column]; right > width {
+		x = right - width
+	}
+	if bottom := node.row*graphRowStep + 1; bottom > height {
+		y = bottom - height
+	}
+	return x, y
+}
+
+// Mouse handles clicks on jobs and scrolling over the graph
+func (m GraphViewModel) Mouse(msg tea.MouseMsg, width, height int) (GraphViewModel, tea.Cmd) {
+	if m.loading || m.err != nil {
+		return m, nil
+	}
+
+	grid := m.graph.draw(asciiOnly)
+	window := max(height-graphChrome, 1)
+	xOffset, yOffset := m.offset(width, window)
+	rows := listRows{top: graphTop, height: 1, first: yOffset, end: min(yOffset+window, len(grid))}
+
+	switch intent, line := m.clicks.interpret(msg, rows); intent {
+	case mouseScrollUp:
+		return m.Update(keyMsgFor(actionUp))
+	case mouseScrollDown:
+		return m.Update(keyMsgFor(actionDown))
+	case mouseClick, mouseDoubleClick:
+		x := msg.X + xOffset
+		if x >= len(grid[line]) || grid[line][x].job == "" {
+			return m, nil
+		}
+		job := grid[line][x].job
+		if intent == mouseDoubleClick && job == m.selectedJob() {
+			return m, m.openBuilds()
+		}
+		for i, node := range m.nodes {
+			if node.job == job {
+				m.selected = i
+			}
+		}
+	}
+	return m, nil
+}
+
+// jobColor returns the color of a job on the graph: its latest build's
+// status, or the info color while a build is running
+func jobColor(job concourse.Job) lipgloss.TerminalColor {
+	if job.NextBuild.ID != 0 {
+		return theme.Info
+	}
+	switch job.FinishedBuild.Status {
+	case "succeeded":
+		return theme.Success
+	case "failed", "errored":
+		return theme.Error
+	case "aborted":
+		return theme.Warning
+	}
+	return theme.Muted
+}
+
+// renderGrid renders the visible part of the graph, coloring each job
+func (m GraphViewModel) renderGrid(width, height int) string {
+	grid := m.graph.draw(asciiOnly)
+	xOffset, yOffset := m.offset(width, height)
+	edgeStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+	selected := m.selectedJob()
+
+	var lines []string
+	for y := yOffset; y < min(yOffset+height, len(grid)); y++ {
+		var line strings.Builder
+		row := grid[y][min(xOffset, len(grid[y])):]
+		row = row[:min(width, len(row))]
+		// Render runs of cells belonging to the same job, or to none, at once
+		for start := 0; start < len(row); {
+			end := start + 1
+			for end < len(row) && row[end].job == row[start].job {
+				end++
+			}
+			var text strings.Builder
+			for _, cell := range row[start:end] {
+				text.WriteRune(cell.char)
+			}
+			style := edgeStyle
+			if job := row[start].job; job != "" {
+				style = lipgloss.NewStyle().Foreground(jobColor(m.jobs[job]))
+				if job == selected {
+					style = style.Bold(true).Reverse(true)
+				}
+			}
+			line.WriteString(style.Render(text.String()))
+			start = end
+		}
+		lines = append(lines, line.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderSelected describes the selected job's latest builds
+func (m GraphViewModel) renderSelected() string {
+	name := m.selectedJob()
+	job := m.jobs[name]
+	text := lipgloss.NewStyle().Foreground(jobColor(job)).Bold(true).Render(name)
+
+	mutedStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+	if build := job.FinishedBuild; build.ID != 0 {
+		text += fmt.Sprintf(" • build #%s %s", build.Name, build.Status)
+		if ended := build.GetEndTime(); !ended.IsZero() {
+			text += mutedStyle.Render(" " + formatTimeAgo(ended))
+		}
+	} else {
+		text += mutedStyle.Render(" • no builds yet")
+	}
+	if job.NextBuild.ID != 0 {
+		text += fmt.Sprintf(" • #%s %s", job.NextBuild.Name, job.NextBuild.Status)
+	}
+	return text
+}
+
+// renderLegend explains the colors of the jobs
+func renderLegend() string {
+	entries := []struct {
+		label string
+		color lipgloss.TerminalColor
+	}{
+		{"succeeded", theme.Success},
+		{"failed", theme.Error},
+		{"aborted", theme.Warning},
+		{"running", theme.Info},
+		{"no builds", theme.Muted},
+	}
+	var parts []string
+	for _, entry := range entries {
+		parts = append(parts, lipgloss.NewStyle().Foreground(entry.color).Render("● "+entry.label))
+	}
+	return strings.Join(parts, "  ")
+}
+
+// View renders the graph view
+func (m GraphViewModel) View(width, height int) string {
+	titleStyle := lipgloss.NewStyle().
+		Foreground(theme.Primary).
+		Bold(true).
+		MarginBottom(1)
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(theme.Muted).
+		Italic(true).
+		MarginTop(1)
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render("Graph - " + m.pipeline))
+	content.WriteString("\n\n")
+
+	if m.loading {
+		content.WriteString(m.loader.View("Loading pipeline...") + "\n")
+		return content.String()
+	}
+
+	if m.err != nil {
+		errorStyle := lipgloss.NewStyle().Foreground(theme.Error)
+		content.WriteString(errorStyle.Render("Error: " + errorSummary(m.err)))
+		content.WriteString("\n")
+		return content.String()
+	}
+
+	if len(m.nodes) == 0 {
+		content.WriteString("Pipeline has no jobs.\n")
+	} else {
+		content.WriteString(m.renderGrid(width, max(height-graphChrome, 1)))
+		content.WriteString("\n\n")
+		content.WriteString(m.renderSelected())
+		content.WriteString("\n")
+		content.WriteString(renderLegend())
+		content.WriteString("\n")
+	}
+
+	content.WriteString(helpStyle.Render(keys.HelpLine(viewKeys[ViewGraph]...)))
+
+	return content.String()
+}
@@ -0,0 +1,96 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// targetGroups holds the user's named groups of targets from the FlyBy
+// config file, e.g. one per environment
+var targetGroups map[string][]string
+
+// activeGroup is the group the views listing several targets are scoped to,
+// "" for all targets
+var activeGroup string
+
+// ApplyTargetGroups checks the user's target groups and makes active the
+// one last switched to, unless it is gone from the config file
+func ApplyTargetGroups(groups map[string][]string, active string) error {
+	for name, targets := range groups {
+		if name == "" {
+			return fmt.Errorf("target groups need a name")
+		}
+		if len(targets) == 0 {
+			return fmt.Errorf("target group %q has no targets", name)
+		}
+	}
+	targetGroups = groups
+	activeGroup = ""
+	if _, ok := groups[active]; ok {
+		activeGroup = active
+	}
+	return nil
+}
+
+// targetGroupNames returns the names of the target groups, sorted
+func targetGroupNames() []string {
+	names := make([]string, 0, len(targetGroups))
+	for name := range targetGroups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// inActiveGroup returns true if the target belongs to the active group, or
+// when no group is active
+func inActiveGroup(target string) bool {
+	if activeGroup == "" {
+		return true
+	}
+	for _, name := range targetGroups[activeGroup] {
+		if name == target {
+			return true
+		}
+	}
+	return false
+}
+
+// switchTargetGroup makes the next group active, then all targets after
+// the last one, and returns to the targets of the new group
+func (m *Model) switchTargetGroup() tea.Cmd {
+	names := targetGroupNames()
+	if len(names) == 0 {
+		return showToast(ToastError, "No target groups configured, add them under groups in %s", m.settings.GetPath())
+	}
+
+	next := names[0]
+	for i, name := range names {
+		if name == activeGroup {
+			next = ""
+			if i+1 < len(names) {
+				next = names[i+1]
+			}
+		}
+	}
+	activeGroup = next
+	var toast tea.Cmd
+	if err := m.stateStore.SetGroup(activeGroup); err != nil {
+		toast = showToast(ToastError, "Failed to remember the target group: %v", err)
+	} else if activeGroup == "" {
+		toast = showToast(ToastInfo, "Showing all targets")
+	} else {
+		toast = showToast(ToastInfo, "Showing group %s: %s", activeGroup, strings.Join(targetGroups[activeGroup], ", "))
+	}
+
+	// Marks and lists of the previous group don't carry over
+	m.targetsView.marked = make(map[string]bool)
+	m.targetsView.loadTargets()
+	m.navStack = []navEntry{{View: ViewMain}}
+	return tea.Batch(toast, func() tea.Msg {
+		return SwitchViewMsg{View: ViewTargets, Replace: true}
+	})
+}
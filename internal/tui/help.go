@@ -0,0 +1,160 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// viewTitles names each view in the help overlay
+var viewTitles = map[ViewType]string{
+	ViewMain:        "Main Menu",
+	ViewTargets:     "Targets",
+	ViewPipelines:   "Pipelines",
+	ViewJobs:        "Jobs",
+	ViewResources:   "Resources",
+	ViewBuilds:      "Builds",
+	ViewAddTarget:   "Add Target",
+	ViewAuth:        "Authentication",
+	ViewSync:        "Sync fly",
+	ViewWatchlist:   "Watchlist",
+	ViewHistory:     "Command History",
+	ViewTeams:       "Teams",
+	ViewActiveUsers: "Active Users",
+	ViewGraph:       "Pipeline Graph",
+	ViewFlaky:       "Flaky Jobs",
+	ViewBuildLog:    "Build Log",
+	ViewDashboard:   "Dashboard",
+	ViewConfig:      "Pipeline Config",
+	ViewSearch:      "Search",
+	ViewAggregate:   "Pipelines Across Targets",
+}
+
+// extraHelpKeys lists actions left off a view's crowded help line that the
+// help overlay should still show
+var extraHelpKeys = map[ViewType][]keyAction{
+	ViewTargets:   {actionCopy, actionYank},
+	ViewBuildLog:  {actionNextMatch, actionPrevMatch, actionClear},
+	ViewPipelines: {actionEditConfig, actionOpen, actionYank, actionGrowPane, actionShrinkPane, actionTogglePane},
+	ViewJobs:      {actionSkipPending, actionNextGroup, actionPrevGroup, actionOpen, actionYank},
+}
+
+// globalHelpKeys lists the actions handled by the app in every view
+var globalHelpKeys = []keyAction{actionGlobalSearch, actionTargetGroup, actionHistory, actionErrorDetails, actionTheme, actionHelp, actionQuit}
+
+// helpEntry is a single key and what it does
+type helpEntry struct {
+	keys string
+	help string
+}
+
+// helpSection is a titled group of entries in the help overlay
+type helpSection struct {
+	title   string
+	entries []helpEntry
+}
+
+// actionEntries returns help entries for the actions, folding up/down into
+// one navigate entry
+func actionEntries(actions []keyAction) []helpEntry {
+	var entries []helpEntry
+	for i := 0; i < len(actions); i++ {
+		if actions[i] == actionUp && i+1 < len(actions) && actions[i+1] == actionDown {
+			entries = append(entries, helpEntry{keys.Label(actionUp) + ", " + keys.Label(actionDown), "navigate"})
+			i++
+			continue
+		}
+		entries = append(entries, helpEntry{keys.Label(actions[i]), keys[actions[i]].Help})
+	}
+	return entries
+}
+
+// helpSections returns the key bindings available in the current view
+func (m *Model) helpSections() []helpSection {
+	viewActions := append(append([]keyAction{}, viewKeys[m.currentView]...), extraHelpKeys[m.currentView]...)
+	sections := []helpSection{{title: viewTitles[m.currentView], entries: actionEntries(viewActions)}}
+
+	for _, action := range viewActions {
+		if action == actionSearch {
+			sections = append(sections, helpSection{title: "Search", entries: []helpEntry{
+				{"Enter", "finish search"},
+				{"Esc", "cancel search"},
+				{"Backspace", "delete character"},
+				{"Ctrl+U", "clear"},
+			}})
+			break
+		}
+	}
+
+	global := actionEntries(globalHelpKeys)
+	if len(m.breadcrumbLevels()) > 0 {
+		global = append(global, helpEntry{keys.Label(actionJumpLevel), keys[actionJumpLevel].Help})
+	}
+	global = append(global, helpEntry{"ctrl+c", "quit"})
+	return append(sections, helpSection{title: "Global", entries: global})
+}
+
+// renderHelpSections renders the help sections as aligned key columns
+func (m *Model) renderHelpSections() string {
+	headingStyle := lipgloss.NewStyle().
+		Foreground(theme.Primary).
+		Bold(true)
+	keyStyle := lipgloss.NewStyle().
+		Foreground(theme.Info).
+		Bold(true)
+
+	sections := m.helpSections()
+	keyWidth := 0
+	for _, section := range sections {
+		for _, entry := range section.entries {
+			keyWidth = max(keyWidth, lipgloss.Width(entry.keys))
+		}
+	}
+
+	var content strings.Builder
+	for i, section := range sections {
+		if i > 0 {
+			content.WriteString("\n")
+		}
+		content.WriteString(headingStyle.Render(section.title))
+		content.WriteString("\n")
+		for _, entry := range section.entries {
+			padding := strings.Repeat(" ", keyWidth-lipgloss.Width(entry.keys))
+			content.WriteString(fmt.Sprintf("  %s%s  %s\n", keyStyle.Render(entry.keys), padding, entry.help))
+		}
+	}
+	return strings.TrimSuffix(content.String(), "\n")
+}
+
+// openHelp shows the help overlay for the current view
+func (m *Model) openHelp() {
+	vp := viewport.New(m.width, max(1, m.contentHeight()-3))
+	vp.SetContent(m.renderHelpSections())
+	m.help = &vp
+}
+
+// handleHelpKey handles keys while the help overlay is open
+func (m *Model) handleHelpKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case keys.Matches(msg, actionHelp), keys.Matches(msg, actionBack), keys.Matches(msg, actionQuit):
+		m.help = nil
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	*m.help, cmd = m.help.Update(msg)
+	return m, cmd
+}
+
+// renderHelp renders the help overlay in place of the current view
+func (m *Model) renderHelp() string {
+	titleStyle := lipgloss.NewStyle().
+		Foreground(theme.Primary).
+		Bold(true).
+		MarginBottom(1)
+
+	return titleStyle.Render("Keyboard Shortcuts") + "\n\n" + m.help.View()
+}
@@ -0,0 +1,233 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"flyby/internal/concourse"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// historyListTop is the first line of the history rows, below the title and
+// the command count
+const historyListTop = 4
+
+// HistoryViewModel represents the fly command history view
+type HistoryViewModel struct {
+	records          []concourse.CommandRecord // newest first
+	selected         int
+	scrollOffset     int
+	maxVisible       int
+	clipboardCommand string
+	clicks           clickTracker
+}
+
+// NewHistoryViewModel creates a new history view model
+func NewHistoryViewModel(clipboardCommand string) HistoryViewModel {
+	return HistoryViewModel{
+		maxVisible:       10,
+		clipboardCommand: clipboardCommand,
+	}
+}
+
+// Load reads the latest command history, newest first
+func (m *HistoryViewModel) Load() {
+	history := concourse.History()
+	m.records = make([]concourse.CommandRecord, len(history))
+	for i, record := range history {
+		m.records[len(history)-1-i] = record
+	}
+	m.selected = 0
+	m.scrollOffset = 0
+}
+
+// Update handles messages for the history view
+func (m HistoryViewModel) Update(msg tea.KeyMsg) (HistoryViewModel, tea.Cmd) {
+	switch {
+	case keys.Matches(msg, actionUp):
+		if m.selected > 0 {
+			m.selected--
+			if m.selected < m.scrollOffset {
+				m.scrollOffset = m.selected
+			}
+		}
+	case keys.Matches(msg, actionDown):
+		if m.selected < len(m.records)-1 {
+			m.selected++
+			if m.selected >= m.scrollOffset+m.maxVisible {
+				m.scrollOffset = m.selected - m.maxVisible + 1
+			}
+		}
+	case keys.Matches(msg, actionCopy):
+		if len(m.records) > 0 {
+			command := m.records[m.selected].Command()
+			if err := copyToClipboard(m.clipboardCommand, command); err != nil {
+				return m, showToast(ToastError, "Failed to copy command: %v", err)
+			}
+			return m, showToast(ToastSuccess, "Copied: %s", command)
+		}
+	case keys.Matches(msg, actionPager):
+		if len(m.records) > 0 {
+			return m, openPager(m.text())
+		}
+	case keys.Matches(msg, actionRefresh):
+		m.Load()
+	}
+
+	return m, nil
+}
+
+// text returns the whole history as plain text, newest first
+func (m HistoryViewModel) text() string {
+	var text strings.Builder
+	for _, record := range m.records {
+		status := "✓"
+		if !record.Succeeded() {
+			status = "✗"
+		}
+		fmt.Fprintf(&text, "%s %s %6s  %s\n", record.Started.Format("2006-01-02 15:04:05"), status,
+			formatCommandDuration(record), record.Command())
+	}
+	return text.String()
+}
+
+// visibleRange returns the range of commands shown for the given height
+func (m HistoryViewModel) visibleRange(height int) (int, int) {
+	maxVisible := m.maxVisible
+	if height-18 > 0 { // Account for title, count, indicators, command details and help
+		maxVisible = height - 18
+	}
+
+	start := m.scrollOffset
+	if m.selected >= start+maxVisible {
+		start = m.selected - maxVisible + 1
+	}
+	if m.selected < start {
+		start = m.selected
+	}
+	return start, min(start+maxVisible, len(m.records))
+}
+
+// Mouse handles clicks and scrolling over the history list
+func (m HistoryViewModel) Mouse(msg tea.MouseMsg, height int) (HistoryViewModel, tea.Cmd) {
+	start, end := m.visibleRange(height)
+	rows := listRows{top: historyListTop, height: 1, first: start, end: end}
+	if start > 0 {
+		rows.top++ // "more above" indicator
+	}
+
+	switch intent, index := m.clicks.interpret(msg, rows); intent {
+	case mouseScrollUp:
+		return m.Update(keyMsgFor(actionUp))
+	case mouseScrollDown:
+		return m.Update(keyMsgFor(actionDown))
+	case mouseClick, mouseDoubleClick:
+		m.selected = index
+	}
+	return m, nil
+}
+
+// View renders the history view
+func (m HistoryViewModel) View(width, height int) string {
+	titleStyle := lipgloss.NewStyle().
+		Foreground(theme.Primary).
+		Bold(true).
+		MarginBottom(1)
+
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(theme.Muted)
+
+	itemStyle := lipgloss.NewStyle().
+		PaddingLeft(2)
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(theme.Primary).
+		Bold(true).
+		PaddingLeft(1).
+		Border(lipgloss.NormalBorder(), false, false, false, true).
+		BorderForeground(theme.Primary)
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render("Command History"))
+	content.WriteString("\n\n")
+
+	if len(m.records) == 0 {
+		content.WriteString("No fly commands have run yet.\n")
+	} else {
+		content.WriteString(mutedStyle.Render(fmt.Sprintf("%d commands this session, newest first", len(m.records))))
+		content.WriteString("\n")
+
+		start, end := m.visibleRange(height)
+		if start > 0 {
+			content.WriteString(itemStyle.Render(fmt.Sprintf("  ↑ (%d more above)", start)))
+			content.WriteString("\n")
+		}
+
+		for i := start; i < end; i++ {
+			record := m.records[i]
+			status := lipgloss.NewStyle().Foreground(theme.Success).Render("✓")
+			if !record.Succeeded() {
+				status = lipgloss.NewStyle().Foreground(theme.Error).Render("✗")
+			}
+			line := fmt.Sprintf("%s %s %6s  %s", record.Started.Format("15:04:05"), status,
+				formatCommandDuration(record), record.Command())
+			line = ansi.Truncate(line, max(width-6, 20), "…")
+
+			if i == m.selected {
+				content.WriteString(selectedStyle.Render("> " + line))
+			} else {
+				content.WriteString(itemStyle.Render("  " + line))
+			}
+			content.WriteString("\n")
+		}
+
+		if end < len(m.records) {
+			content.WriteString(itemStyle.Render(fmt.Sprintf("  ↓ (%d more below)", len(m.records)-end)))
+			content.WriteString("\n")
+		}
+
+		// Show selected command info
+		infoStyle := lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(theme.Muted).
+			Padding(0, 1).
+			MarginTop(1).
+			Width(min(width-2, 100))
+
+		record := m.records[m.selected]
+		exitStatus := fmt.Sprintf("%d", record.ExitCode)
+		if record.ExitCode < 0 {
+			exitStatus = "failed to start"
+		}
+		target := record.Target
+		if target == "" {
+			target = "(none)"
+		}
+		info := fmt.Sprintf("Command: %s\nTarget: %s\nStarted: %s\nDuration: %s\nExit status: %s",
+			record.Command(), target, record.Started.Format("2006-01-02 15:04:05"),
+			formatCommandDuration(record), exitStatus)
+		content.WriteString(infoStyle.Render(info))
+	}
+
+	// Help text
+	helpStyle := lipgloss.NewStyle().
+		Foreground(theme.Muted).
+		Italic(true).
+		MarginTop(1)
+	content.WriteString("\n")
+	content.WriteString(helpStyle.Render(keys.HelpLine(viewKeys[ViewHistory]...)))
+
+	return content.String()
+}
+
+// formatCommandDuration returns how long a command took, e.g. "850ms" or "2.3s"
+func formatCommandDuration(record concourse.CommandRecord) string {
+	if record.Duration < time.Second {
+		return fmt.Sprintf("%dms", record.Duration.Milliseconds())
+	}
+	return fmt.Sprintf("%.1fs", record.Duration.Seconds())
+}
@@ -0,0 +1,106 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"flyby/internal/concourse"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// hookTimeout is how long a hook may run before it is killed
+const hookTimeout = time.Minute
+
+// runHook runs a hook from the FlyBy config file in the background, with the
+// event and its details in FLYBY_* environment variables, e.g. FLYBY_JOB for
+// "job". Only failures are reported.
+func runHook(event, command string, details map[string]string) tea.Cmd {
+	if strings.TrimSpace(command) == "" {
+		return nil
+	}
+	return func() tea.Msg {
+		environ, err := concourse.Environ()
+		if err != nil {
+			return ToastMsg{Level: ToastError, Text: fmt.Sprintf("Hook %s failed: %v", event, err)}
+		}
+		environ = append(environ, "FLYBY_EVENT="+event)
+		for name, value := range details {
+			environ = append(environ, "FLYBY_"+strings.ToUpper(name)+"="+value)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), hookTimeout)
+		defer cancel()
+		cmd := shellCommand(ctx, command)
+		cmd.Env = environ
+		output, err := cmd.CombinedOutput()
+		if ctx.Err() == context.DeadlineExceeded {
+			return ToastMsg{Level: ToastError, Text: fmt.Sprintf("Hook %s timed out after %s", event, hookTimeout)}
+		}
+		if err != nil {
+			lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+			if last := lines[len(lines)-1]; last != "" {
+				err = fmt.Errorf("%s", last)
+			}
+			return ToastMsg{Level: ToastError, Text: fmt.Sprintf("Hook %s failed: %v", event, err)}
+		}
+		return nil
+	}
+}
+
+// hookTeam returns the team of a target for hooks, or "" when it's unknown
+func (m *Model) hookTeam(target string) string {
+	if t, exists := m.configManager.GetTarget(target); exists {
+		return t.Team
+	}
+	return ""
+}
+
+// jobTriggeredHook runs the job_triggered hook for a job triggered from FlyBy
+func (m *Model) jobTriggeredHook(target, pipeline, job, output string) tea.Cmd {
+	return runHook("job_triggered", m.settings.Hooks.JobTriggered, map[string]string{
+		"target":   target,
+		"team":     m.hookTeam(target),
+		"pipeline": pipeline,
+		"job":      job,
+		"build":    startedBuild(output),
+	})
+}
+
+// buildFinishedHooks runs the build_finished hook for each watched build that
+// finished since the last poll
+func (m *Model) buildFinishedHooks(finished []finishedBuild) tea.Cmd {
+	var cmds []tea.Cmd
+	for _, build := range finished {
+		if !build.Watched {
+			continue
+		}
+		cmds = append(cmds, runHook("build_finished", m.settings.Hooks.BuildFinished, map[string]string{
+			"target":   build.Target,
+			"team":     m.hookTeam(build.Target),
+			"pipeline": build.Pipeline,
+			"job":      build.Job,
+			"build":    build.Build,
+			"status":   build.Status,
+		}))
+	}
+	return tea.Batch(cmds...)
+}
+
+// resourceCheckFailedHook runs the resource_check_failed hook for a failed
+// resource check
+func (m *Model) resourceCheckFailedHook(msg ResourceCheckMsg) tea.Cmd {
+	output := msg.Output
+	if msg.Error != nil && strings.TrimSpace(output) == "" {
+		output = msg.Error.Error()
+	}
+	return runHook("resource_check_failed", m.settings.Hooks.ResourceCheckFailed, map[string]string{
+		"target":   msg.Target,
+		"team":     m.hookTeam(msg.Target),
+		"pipeline": msg.Pipeline,
+		"resource": strings.TrimPrefix(msg.Resource, msg.Pipeline+"/"),
+		"output":   strings.TrimSpace(output),
+	})
+}
@@ -0,0 +1,145 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"flyby/internal/concourse"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// jobLinks is the inspector of the jobs feeding into a job and the jobs
+// consuming its outputs, opened over the jobs view
+type jobLinks struct {
+	job        string
+	upstream   []concourse.JobLink
+	downstream []concourse.JobLink
+	selected   int // index into upstream, then downstream
+}
+
+// newJobLinks inspects a job of a pipeline config
+func newJobLinks(config concourse.PipelineConfig, job string) *jobLinks {
+	return &jobLinks{
+		job:        job,
+		upstream:   config.Upstream(job),
+		downstream: config.Downstream(job),
+	}
+}
+
+// all returns the upstream links followed by the downstream ones
+func (l *jobLinks) all() []concourse.JobLink {
+	return append(append([]concourse.JobLink(nil), l.upstream...), l.downstream...)
+}
+
+// openLinks opens the inspector on the selected job
+func (m JobsViewModel) openLinks() (JobsViewModel, tea.Cmd) {
+	if len(m.filteredJobs) == 0 {
+		return m, nil
+	}
+	if len(m.config.Jobs) == 0 {
+		return m, showToast(ToastError, "The config of %s couldn't be read", m.pipeline)
+	}
+	m.links = newJobLinks(m.config, m.filteredJobs[m.selected].Name)
+	return m, nil
+}
+
+// updateLinks handles keys while the inspector is open
+func (m JobsViewModel) updateLinks(msg tea.KeyMsg) (JobsViewModel, tea.Cmd) {
+	links := m.links.all()
+	switch {
+	case keys.Matches(msg, actionBack), keys.Matches(msg, actionLinks):
+		m.links = nil
+	case keys.Matches(msg, actionUp):
+		if m.links.selected > 0 {
+			m.links.selected--
+		}
+	case keys.Matches(msg, actionDown):
+		if m.links.selected < len(links)-1 {
+			m.links.selected++
+		}
+	case keys.Matches(msg, actionSelect):
+		if len(links) == 0 {
+			return m, nil
+		}
+		// Follow the link, inspecting the job it leads to in turn
+		job := links[m.links.selected].Job
+		if !m.jumpToJob(job) {
+			return m, showToast(ToastError, "Job %s isn't in the pipeline anymore", job)
+		}
+		m.links = newJobLinks(m.config, job)
+	}
+	return m, nil
+}
+
+// jumpToJob selects a job, clearing the search and group tab if they hide it
+func (m *JobsViewModel) jumpToJob(name string) bool {
+	index := func() int {
+		for i, job := range m.filteredJobs {
+			if job.Name == name {
+				return i
+			}
+		}
+		return -1
+	}
+	i := index()
+	if i < 0 {
+		m.searchQuery = ""
+		m.group = ""
+		m.filterJobs()
+		if i = index(); i < 0 {
+			return false
+		}
+	}
+	m.selected = i
+	m.batchResults = nil
+	return true
+}
+
+// renderLinks renders the inspector in place of the selected job's info
+func (m JobsViewModel) renderLinks() string {
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Primary).
+		Padding(0, 1).
+		MarginTop(1)
+	headingStyle := lipgloss.NewStyle().Bold(true)
+	mutedStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+	selectedStyle := lipgloss.NewStyle().Foreground(theme.Primary).Bold(true)
+
+	statuses := make(map[string]string)
+	for _, job := range m.jobs {
+		statuses[job.Name] = job.FinishedBuild.Status
+	}
+
+	var lines []string
+	index := 0
+	section := func(heading, empty string, links []concourse.JobLink) {
+		lines = append(lines, headingStyle.Render(heading))
+		if len(links) == 0 {
+			lines = append(lines, mutedStyle.Render("  "+empty))
+		}
+		for _, link := range links {
+			via := "via " + link.Resource + " (put/get)"
+			if link.Passed {
+				via = "via " + link.Resource + " (passed)"
+			}
+			line := link.Job
+			if status := statuses[link.Job]; status != "" {
+				line += " [" + strings.ToUpper(status) + "]"
+			}
+			if index == m.links.selected {
+				lines = append(lines, selectedStyle.Render("> "+line)+mutedStyle.Render("  "+via))
+			} else {
+				lines = append(lines, "  "+line+mutedStyle.Render("  "+via))
+			}
+			index++
+		}
+	}
+
+	section(fmt.Sprintf("Upstream of %s", m.links.job), "no jobs feed into it", m.links.upstream)
+	lines = append(lines, "")
+	section("Downstream", "no jobs consume its outputs", m.links.downstream)
+	return boxStyle.Render(strings.Join(lines, "\n"))
+}
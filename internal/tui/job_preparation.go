@@ -0,0 +1,99 @@
+package tui
+
+import (
+	"strings"
+	"time"
+
+	"flyby/internal/concourse"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// jobPreparationTTL is how long what a pending build waits on is reused
+// before loading it again
+const jobPreparationTTL = 10 * time.Second
+
+// jobPreparation is what the next build of a job waits on
+type jobPreparation struct {
+	blockers []string
+	loading  bool
+	err      error
+	checked  time.Time
+}
+
+// JobPreparationMsg carries what the next build of a job waits on
+type JobPreparationMsg struct {
+	Build       int
+	Preparation concourse.BuildPreparation
+	Error       error
+}
+
+// LoadPreparation loads what the selected job's next build waits on unless
+// it is known already
+func (m *JobsViewModel) LoadPreparation() tea.Cmd {
+	if m.client == nil || m.loading || m.selected >= len(m.filteredJobs) {
+		return nil
+	}
+	build := m.filteredJobs[m.selected].NextBuild
+	if build.ID == 0 || build.Status == "started" {
+		return nil
+	}
+	if preparation, ok := m.preparations[build.ID]; ok && (preparation.loading || time.Since(preparation.checked) < jobPreparationTTL) {
+		return nil
+	}
+	if m.preparations == nil {
+		m.preparations = make(map[int]jobPreparation)
+	}
+	// Keep showing the previous reasons until the new ones arrive
+	preparation := m.preparations[build.ID]
+	preparation.loading = true
+	m.preparations[build.ID] = preparation
+
+	client := m.client
+	return func() tea.Msg {
+		result, err := client.GetBuildPreparation(build.ID)
+		return JobPreparationMsg{Build: build.ID, Preparation: result, Error: err}
+	}
+}
+
+// HandleJobPreparation stores what a pending build waits on
+func (m JobsViewModel) HandleJobPreparation(msg JobPreparationMsg) JobsViewModel {
+	if _, ok := m.preparations[msg.Build]; !ok {
+		// The jobs were reloaded meanwhile
+		return m
+	}
+	if concourse.IsCanceled(msg.Error) {
+		// Loaded again once the jobs show
+		delete(m.preparations, msg.Build)
+		return m
+	}
+	m.preparations[msg.Build] = jobPreparation{
+		blockers: msg.Preparation.Blockers(),
+		err:      msg.Error,
+		checked:  time.Now(),
+	}
+	return m
+}
+
+// renderPreparation renders why the next build of a job hasn't started for
+// its info box
+func (m JobsViewModel) renderPreparation(build concourse.Build) string {
+	mutedStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+	if build.Status == "started" {
+		return "Waiting on: " + mutedStyle.Render("nothing, the build is running")
+	}
+	preparation, ok := m.preparations[build.ID]
+	switch {
+	case !ok:
+		return ""
+	case preparation.checked.IsZero():
+		return "Waiting on: " + mutedStyle.Render("loading...")
+	case preparation.err != nil:
+		return "Waiting on: " + lipgloss.NewStyle().Foreground(theme.Error).Render("failed to load: "+errorSummary(preparation.err))
+	case len(preparation.blockers) == 0:
+		return "Waiting on: " + mutedStyle.Render("nothing, the build is about to be scheduled")
+	}
+	warningStyle := lipgloss.NewStyle().Foreground(theme.Warning)
+	return "Waiting on:\n" + warningStyle.Render("  • "+strings.Join(preparation.blockers, "\n  • "))
+}
@@ -2,78 +2,174 @@ package tui
 
 import (
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	"flyby/internal/concourse"
+	"flyby/internal/state"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 )
 
+// jobSort is the order the jobs are listed in
+type jobSort int
+
+const (
+	jobSortPipeline jobSort = iota // as the pipeline config lists them
+	jobSortName
+	jobSortStatus // failing first
+	jobSortActivity // latest build first
+)
+
+// jobSortLabels describes each order for the view
+var jobSortLabels = map[jobSort]string{
+	jobSortPipeline: "pipeline order",
+	jobSortName:     "name",
+	jobSortStatus:   "last build status",
+	jobSortActivity: "last build time",
+}
+
 // JobsViewModel represents the jobs view
 type JobsViewModel struct {
 	client         *concourse.Client
+	stateStore     *state.Store
 	jobs           []concourse.Job
 	filteredJobs   []concourse.Job
 	selected       int
+	scrollOffset   int
+	maxVisible     int
 	loading        bool
+	refreshing     bool // showing the jobs of an earlier visit while they reload
+	selectNext     string // job to select once the jobs load, e.g. from a restored session
 	err            error
 	pipeline       string
 	triggeringJob  string
-	triggerResult  string
-	triggerError   error
 	searchQuery    string
 	searchMode     bool
+	marked         map[string]bool
+	skipPending    bool
+	batchRunning   int
+	batchResults   []BatchTriggerResult
+	watchErr       error
+	config         concourse.PipelineConfig
+	groups         []concourse.PipelineGroup
+	group          string // name of the group tab shown, "" for all jobs
+	sort           jobSort
+	links          *jobLinks // upstream/downstream inspector, nil when closed
+	started        map[string]string // builds triggered from here by pipeline/job, until the next reload
+	preparations   map[int]jobPreparation // what next builds wait on by build ID
+	clicks         clickTracker
+	loader         loader
 }
 
 // NewJobsViewModel creates a new jobs view model
-func NewJobsViewModel() JobsViewModel {
+func NewJobsViewModel(stateStore *state.Store) JobsViewModel {
 	return JobsViewModel{
+		stateStore:   stateStore,
 		selected:     0,
+		maxVisible:   10,
 		loading:      false,
 		searchQuery:  "",
 		searchMode:   false,
+		marked:       make(map[string]bool),
+		started:      make(map[string]string),
+		skipPending:  true,
+		loader:       newLoader(),
 	}
 }
 
 // JobsLoadedMsg represents loaded jobs
 type JobsLoadedMsg struct {
 	Jobs     []concourse.Job
+	Config   concourse.PipelineConfig
 	Error    error
 	Pipeline string
 }
 
 // TriggerJobMsg represents a job trigger result
 type TriggerJobMsg struct {
+	Target   string
+	Pipeline string
+	Job      string // pipeline/job
+	Output   string
+	Error    error
+	Success  bool
+}
+
+// TriggerJobRequestMsg represents a request to trigger a job
+type TriggerJobRequestMsg struct {
+	Pipeline string
+	Job      string
+}
+
+// BatchTriggerRequestMsg represents a request to trigger several jobs at once
+type BatchTriggerRequestMsg struct {
+	Pipeline    string
+	Jobs        []concourse.Job
+	SkipPending bool
+}
+
+// BatchTriggerResult represents the outcome of triggering one job of a batch
+type BatchTriggerResult struct {
 	Job     string
 	Output  string
 	Error   error
 	Success bool
+	Skipped bool
 }
 
-// TriggerJobRequestMsg represents a request to trigger a job
-type TriggerJobRequestMsg struct {
+// BatchTriggerMsg represents the aggregated result of a batch trigger
+type BatchTriggerMsg struct {
+	Target   string
 	Pipeline string
-	Job      string
+	Results  []BatchTriggerResult
 }
 
 // LoadJobs loads jobs from Concourse
 func (m JobsViewModel) LoadJobs(client *concourse.Client, pipeline string) tea.Cmd {
 	return func() tea.Msg {
 		jobs, err := client.GetJobs(pipeline)
-		return JobsLoadedMsg{Jobs: jobs, Error: err, Pipeline: pipeline}
+		if err != nil {
+			return JobsLoadedMsg{Error: err, Pipeline: pipeline}
+		}
+		// The config only organizes the jobs, show them ungrouped if it can't be read
+		config, _ := client.GetPipelineConfig(pipeline)
+		return JobsLoadedMsg{Jobs: jobs, Config: config, Pipeline: pipeline}
+	}
+}
+
+// Revalidate reloads the jobs while still showing the ones loaded on an
+// earlier visit, or returns nil when there are none to show
+func (m *JobsViewModel) Revalidate() tea.Cmd {
+	if m.client == nil || m.pipeline == "" || m.loading || m.err != nil {
+		return nil
 	}
+	m.refreshing = true
+	return m.LoadJobs(m.client, m.pipeline)
 }
 
-// filterJobs filters jobs based on the current search query
+// filterJobs filters jobs based on the current group tab and search query
 func (m *JobsViewModel) filterJobs() {
+	jobs := m.jobs
+	if group, ok := m.currentGroup(); ok {
+		jobs = nil
+		for _, job := range m.jobs {
+			if group.Contains(job.Name) {
+				jobs = append(jobs, job)
+			}
+		}
+	}
+	
 	if m.searchQuery == "" {
-		m.filteredJobs = make([]concourse.Job, len(m.jobs))
-		copy(m.filteredJobs, m.jobs)
+		m.filteredJobs = make([]concourse.Job, len(jobs))
+		copy(m.filteredJobs, jobs)
 	} else {
 		m.filteredJobs = nil
 		query := strings.ToLower(m.searchQuery)
-		for _, job := range m.jobs {
+		for _, job := range jobs {
 			if strings.Contains(strings.ToLower(job.Name), query) ||
 			   strings.Contains(strings.ToLower(job.PipelineName), query) ||
 			   strings.Contains(strings.ToLower(job.TeamName), query) {
@@ -82,17 +178,131 @@ func (m *JobsViewModel) filterJobs() {
 		}
 	}
 	
+	m.sortJobs()
+	
 	// Reset selection and scroll if it's out of bounds
 	if m.selected >= len(m.filteredJobs) {
 		m.selected = 0
+		m.scrollOffset = 0
 	}
 	if m.selected < 0 && len(m.filteredJobs) > 0 {
 		m.selected = 0
+		m.scrollOffset = 0
+	}
+}
+
+// sortJobs orders the filtered jobs by the chosen sort, by name within the
+// same status or time
+func (m *JobsViewModel) sortJobs() {
+	switch m.sort {
+	case jobSortName:
+		sort.SliceStable(m.filteredJobs, func(i, j int) bool {
+			return m.filteredJobs[i].Name < m.filteredJobs[j].Name
+		})
+	case jobSortStatus:
+		sort.SliceStable(m.filteredJobs, func(i, j int) bool {
+			a, b := jobStatusRank(m.filteredJobs[i]), jobStatusRank(m.filteredJobs[j])
+			if a != b {
+				return a < b
+			}
+			return m.filteredJobs[i].Name < m.filteredJobs[j].Name
+		})
+	case jobSortActivity:
+		sort.SliceStable(m.filteredJobs, func(i, j int) bool {
+			a, b := jobActivity(m.filteredJobs[i]), jobActivity(m.filteredJobs[j])
+			if !a.Equal(b) {
+				return a.After(b)
+			}
+			return m.filteredJobs[i].Name < m.filteredJobs[j].Name
+		})
+	}
+}
+
+// jobStatusRank ranks a job by its last build for sorting, failing first and
+// jobs that never ran last
+func jobStatusRank(job concourse.Job) int {
+	switch job.FinishedBuild.Status {
+	case "failed":
+		return 0
+	case "errored":
+		return 1
+	case "aborted":
+		return 2
+	case "succeeded":
+		return 3
 	}
+	return 4
+}
+
+// jobActivity returns when a job last did something: its running build
+// started, or its last build finished
+func jobActivity(job concourse.Job) time.Time {
+	if start := job.NextBuild.GetStartTime(); !start.IsZero() {
+		return start
+	}
+	if end := job.FinishedBuild.GetEndTime(); !end.IsZero() {
+		return end
+	}
+	return job.FinishedBuild.GetStartTime()
+}
+
+// cycleSort switches to the next order, keeping the selected job selected
+func (m *JobsViewModel) cycleSort() {
+	selected := ""
+	if m.selected < len(m.filteredJobs) {
+		selected = m.filteredJobs[m.selected].Name
+	}
+	m.sort = (m.sort + 1) % jobSort(len(jobSortLabels))
+	m.filterJobs()
+	for i, job := range m.filteredJobs {
+		if job.Name == selected {
+			m.selected = i
+		}
+	}
+}
+
+// currentGroup returns the group whose tab is shown, if it isn't the all jobs tab
+func (m JobsViewModel) currentGroup() (concourse.PipelineGroup, bool) {
+	for _, group := range m.groups {
+		if group.Name == m.group {
+			return group, true
+		}
+	}
+	return concourse.PipelineGroup{}, false
+}
+
+// cycleGroup moves to the next or previous group tab, the all jobs tab
+// coming first
+func (m *JobsViewModel) cycleGroup(step int) {
+	if len(m.groups) == 0 {
+		return
+	}
+	index := 0
+	for i, group := range m.groups {
+		if group.Name == m.group {
+			index = i + 1
+		}
+	}
+	index = (index + step + len(m.groups) + 1) % (len(m.groups) + 1)
+	m.group = ""
+	if index > 0 {
+		m.group = m.groups[index-1].Name
+	}
+	m.selected = 0
+	m.scrollOffset = 0
+	m.filterJobs()
 }
 
 // Update handles messages for the jobs view
 func (m JobsViewModel) Update(msg tea.KeyMsg) (JobsViewModel, tea.Cmd) {
+	m, cmd := m.handleKey(msg)
+	preparation := m.LoadPreparation()
+	return m, tea.Batch(cmd, preparation)
+}
+
+// handleKey handles a key press, Update then loads what the next build of
+// whichever job ends up selected waits on
+func (m JobsViewModel) handleKey(msg tea.KeyMsg) (JobsViewModel, tea.Cmd) {
 	// Handle search mode
 	if m.searchMode {
 		switch msg.String() {
@@ -119,51 +329,196 @@ func (m JobsViewModel) Update(msg tea.KeyMsg) (JobsViewModel, tea.Cmd) {
 		return m, nil
 	}
 	
+	if m.links != nil {
+		return m.updateLinks(msg)
+	}
+	
 	// Handle normal navigation mode
-	switch msg.String() {
-	case "f5":
+	switch {
+	case keys.Matches(msg, actionRefresh):
 		// Refresh jobs
 		if m.client != nil && m.pipeline != "" {
 			m.loading = true
 			return m, m.LoadJobs(m.client, m.pipeline)
 		}
-	case "up", "k":
+	case keys.Matches(msg, actionUp):
 		if m.selected > 0 {
 			m.selected--
+			// Adjust scroll if needed
+			if m.selected < m.scrollOffset {
+				m.scrollOffset = m.selected
+			}
 		}
-		// Clear trigger results when navigating
-		m.triggerResult = ""
-		m.triggerError = nil
-	case "down", "j":
+		// Clear batch results when navigating
+		m.batchResults = nil
+	case keys.Matches(msg, actionDown):
 		if m.selected < len(m.filteredJobs)-1 {
 			m.selected++
+			// Adjust scroll if needed
+			if m.selected >= m.scrollOffset+m.maxVisible {
+				m.scrollOffset = m.selected - m.maxVisible + 1
+			}
 		}
-		// Clear trigger results when navigating
-		m.triggerResult = ""
-		m.triggerError = nil
-	case "enter", "t":
+		// Clear batch results when navigating
+		m.batchResults = nil
+	case keys.Matches(msg, actionTrigger):
 		if len(m.filteredJobs) > 0 {
 			return m, m.triggerJob()
 		}
-	case "x", "clear":
+	case keys.Matches(msg, actionMark):
+		// Mark or unmark the selected job for batch triggering
+		if len(m.filteredJobs) > 0 {
+			name := m.filteredJobs[m.selected].Name
+			if m.marked[name] {
+				delete(m.marked, name)
+			} else {
+				m.marked[name] = true
+			}
+		}
+	case keys.Matches(msg, actionMarkAll):
+		// Mark all visible jobs, or clear marks if they are all marked already
+		allMarked := len(m.filteredJobs) > 0
+		for _, job := range m.filteredJobs {
+			if !m.marked[job.Name] {
+				allMarked = false
+				break
+			}
+		}
+		for _, job := range m.filteredJobs {
+			if allMarked {
+				delete(m.marked, job.Name)
+			} else {
+				m.marked[job.Name] = true
+			}
+		}
+	case keys.Matches(msg, actionTriggerMarked):
+		if len(m.marked) > 0 && m.batchRunning == 0 {
+			return m, m.triggerMarkedJobs()
+		}
+	case keys.Matches(msg, actionSkipPending):
+		// Toggle whether jobs with a pending build are skipped by batch triggers
+		m.skipPending = !m.skipPending
+	case keys.Matches(msg, actionWatch):
+		if len(m.filteredJobs) > 0 && m.stateStore != nil && m.client != nil {
+			_, m.watchErr = m.stateStore.ToggleWatch(m.watchItem(m.filteredJobs[m.selected]))
+		}
+	case keys.Matches(msg, actionClear):
 		// Clear trigger results
-		m.triggerResult = ""
-		m.triggerError = nil
 		m.triggeringJob = ""
-	case "b":
+		m.batchResults = nil
+	case keys.Matches(msg, actionBuilds):
 		if len(m.filteredJobs) > 0 {
 			job := m.filteredJobs[m.selected]
 			return m, func() tea.Msg {
-				return SwitchViewMsg{View: ViewBuilds, Job: job.Name, Pipeline: job.PipelineName}
+				return SwitchViewMsg{View: ViewBuilds, Job: job.Name, Pipeline: job.PipelineRef()}
 			}
 		}
-	case "/", "s":
+	case keys.Matches(msg, actionLog):
+		return m, m.openLog()
+	case keys.Matches(msg, actionGraph):
+		if m.pipeline != "" {
+			pipeline := m.pipeline
+			return m, func() tea.Msg {
+				return SwitchViewMsg{View: ViewGraph, Pipeline: pipeline}
+			}
+		}
+	case keys.Matches(msg, actionLinks):
+		return m.openLinks()
+	case keys.Matches(msg, actionFlaky):
+		if m.pipeline != "" {
+			pipeline := m.pipeline
+			return m, func() tea.Msg {
+				return SwitchViewMsg{View: ViewFlaky, Pipeline: pipeline}
+			}
+		}
+	case keys.Matches(msg, actionNextGroup):
+		m.cycleGroup(1)
+	case keys.Matches(msg, actionPrevGroup):
+		m.cycleGroup(-1)
+	case keys.Matches(msg, actionSort):
+		m.cycleSort()
+	case keys.Matches(msg, actionSearch):
 		m.searchMode = true
 	}
 	
 	return m, nil
 }
 
+// visibleRange returns the range of jobs shown for the given height, keeping
+// the selected job in view
+func (m JobsViewModel) visibleRange(height int) (int, int) {
+	maxVisible := m.maxVisible
+	reserved := 24 // Account for title, search box, indicators, job info and help
+	if len(m.groups) > 0 {
+		reserved += 2 // and the group tabs
+	}
+	if height-reserved > 0 {
+		maxVisible = max((height-reserved)/2, 1)
+	}
+	
+	start := m.scrollOffset
+	if m.selected >= start+maxVisible {
+		start = m.selected - maxVisible + 1
+	}
+	if m.selected < start {
+		start = m.selected
+	}
+	return start, min(start+maxVisible, len(m.filteredJobs))
+}
+
+// Mouse handles clicks and scrolling over the jobs list
+func (m JobsViewModel) Mouse(msg tea.MouseMsg, height int) (JobsViewModel, tea.Cmd) {
+	if m.searchMode || m.loading {
+		return m, nil
+	}
+	
+	start, end := m.visibleRange(height)
+	rows := listRows{top: searchListTop, height: 2, first: start, end: end}
+	if len(m.groups) > 0 {
+		rows.top += 2 // group tabs
+	}
+	if start > 0 {
+		rows.top += 2 // "more above" indicator
+	}
+	switch intent, index := m.clicks.interpret(msg, rows); intent {
+	case mouseScrollUp:
+		return m.Update(keyMsgFor(actionUp))
+	case mouseScrollDown:
+		return m.Update(keyMsgFor(actionDown))
+	case mouseClick:
+		m.selected = index
+	case mouseDoubleClick:
+		// Opening a job shows its builds rather than triggering it
+		m.selected = index
+		return m.Update(keyMsgFor(actionBuilds))
+	}
+	cmd := m.LoadPreparation()
+	return m, cmd
+}
+
+// openLog switches to the log of the build last triggered from here for the
+// selected job, or else of its running or latest build
+func (m JobsViewModel) openLog() tea.Cmd {
+	if len(m.filteredJobs) == 0 {
+		return nil
+	}
+	
+	job := m.filteredJobs[m.selected]
+	build := m.started[job.PipelineRef()+"/"+job.Name]
+	if build == "" {
+		build = job.NextBuild.Name
+	}
+	if build == "" {
+		build = job.FinishedBuild.Name
+	}
+	if build == "" {
+		return showToast(ToastInfo, "%s has no builds yet", job.Name)
+	}
+	return func() tea.Msg {
+		return SwitchViewMsg{View: ViewBuildLog, Pipeline: job.PipelineRef(), Job: job.Name, Build: build}
+	}
+}
+
 // triggerJob triggers the selected job
 func (m JobsViewModel) triggerJob() tea.Cmd {
 	if len(m.filteredJobs) == 0 {
@@ -173,56 +528,145 @@ func (m JobsViewModel) triggerJob() tea.Cmd {
 	job := m.filteredJobs[m.selected]
 	return func() tea.Msg {
 		return TriggerJobRequestMsg{
-			Pipeline: job.PipelineName,
+			Pipeline: job.PipelineRef(),
 			Job:      job.Name,
 		}
 	}
 }
 
+// watchItem returns the watchlist entry for a job on the current target
+func (m JobsViewModel) watchItem(job concourse.Job) state.WatchItem {
+	return state.WatchItem{Target: m.client.GetTarget(), Pipeline: job.PipelineRef(), Job: job.Name}
+}
+
+// triggerMarkedJobs requests a batch trigger of all marked jobs
+func (m JobsViewModel) triggerMarkedJobs() tea.Cmd {
+	var jobs []concourse.Job
+	for _, job := range m.jobs {
+		if m.marked[job.Name] {
+			jobs = append(jobs, job)
+		}
+	}
+	if len(jobs) == 0 {
+		return nil
+	}
+
+	pipeline := m.pipeline
+	skipPending := m.skipPending
+	return func() tea.Msg {
+		return BatchTriggerRequestMsg{
+			Pipeline:    pipeline,
+			Jobs:        jobs,
+			SkipPending: skipPending,
+		}
+	}
+}
+
 // HandleJobsLoaded handles the jobs loaded message
-func (m JobsViewModel) HandleJobsLoaded(msg JobsLoadedMsg) JobsViewModel {
+func (m JobsViewModel) HandleJobsLoaded(msg JobsLoadedMsg) (JobsViewModel, tea.Cmd) {
+	if msg.Pipeline != m.pipeline {
+		// Marks only make sense within the pipeline they were made in
+		m.marked = make(map[string]bool)
+		m.batchResults = nil
+		m.group = ""
+	}
 	m.jobs = msg.Jobs
+	m.started = make(map[string]string) // the list shows them now
+	m.preparations = make(map[int]jobPreparation)
+	m.config = msg.Config
+	m.groups = msg.Config.Groups
+	m.links = nil
 	m.err = msg.Error
 	m.pipeline = msg.Pipeline
 	m.loading = false
-	m.selected = 0
-	m.filterJobs() // Filter the loaded jobs
-	return m
+	if !m.refreshing || msg.Error != nil {
+		m.selected = 0
+		m.scrollOffset = 0
+		m.filterJobs() // Filter the loaded jobs
+		for i, job := range m.filteredJobs {
+			if m.selectNext != "" && job.Name == m.selectNext {
+				m.selected = i
+			}
+		}
+		if m.selected >= m.scrollOffset+m.maxVisible {
+			m.scrollOffset = m.selected - m.maxVisible + 1
+		}
+		if msg.Error == nil {
+			m.selectNext = ""
+		}
+	} else {
+		// Stay on the job selected before refreshing
+		selected := ""
+		if m.selected < len(m.filteredJobs) {
+			selected = m.filteredJobs[m.selected].Name
+		}
+		m.filterJobs()
+		for i, job := range m.filteredJobs {
+			if job.Name == selected {
+				m.selected = i
+			}
+		}
+		if m.selected < m.scrollOffset {
+			m.scrollOffset = m.selected
+		} else if m.selected >= m.scrollOffset+m.maxVisible {
+			m.scrollOffset = m.selected - m.maxVisible + 1
+		}
+	}
+	m.refreshing = false
+	cmd := m.LoadPreparation()
+	return m, cmd
 }
 
 // HandleTriggerJob handles the job trigger result message
-func (m JobsViewModel) HandleTriggerJob(msg TriggerJobMsg) JobsViewModel {
+func (m JobsViewModel) HandleTriggerJob(msg TriggerJobMsg) (JobsViewModel, tea.Cmd) {
 	m.triggeringJob = ""
 	
 	if msg.Error != nil {
 		// Actual command execution error
-		m.triggerError = msg.Error
-		m.triggerResult = ""
-	} else if msg.Success {
-		// Job trigger succeeded
-		m.triggerResult = msg.Output
-		m.triggerError = nil
-	} else {
+		return m, showToast(ToastError, "Failed to trigger %s: %v", msg.Job, msg.Error)
+	} else if !msg.Success {
 		// Job trigger failed (but fly command ran)
-		m.triggerResult = ""
-		m.triggerError = fmt.Errorf("Job trigger failed: %s", msg.Output)
+		return m, showToast(ToastError, "Job trigger failed: %s", msg.Output)
 	}
 	
+	if build := startedBuild(msg.Output); build != "" {
+		m.started[msg.Job] = build
+		return m, showToast(ToastSuccess, "Triggered %s #%s, press %s to follow its log", msg.Job, build, keys.Label(actionLog))
+	}
+	return m, showToast(ToastSuccess, "Triggered %s\n%s", msg.Job, msg.Output)
+}
+
+// StartBatchTrigger starts triggering a batch of jobs
+func (m JobsViewModel) StartBatchTrigger(count int) JobsViewModel {
+	m.batchRunning = count
+	m.batchResults = nil
+	return m
+}
+
+// HandleBatchTrigger handles the aggregated batch trigger result message
+func (m JobsViewModel) HandleBatchTrigger(msg BatchTriggerMsg) JobsViewModel {
+	m.batchRunning = 0
+	m.batchResults = msg.Results
+
+	// Unmark jobs that were triggered so a repeated batch doesn't retrigger them
+	for _, result := range msg.Results {
+		if result.Success {
+			delete(m.marked, strings.TrimPrefix(result.Job, m.pipeline+"/"))
+		}
+	}
 	return m
 }
 
 // StartJobTrigger starts triggering a job
 func (m JobsViewModel) StartJobTrigger(jobName string) JobsViewModel {
 	m.triggeringJob = jobName
-	m.triggerResult = ""
-	m.triggerError = nil
 	return m
 }
 
 // View renders the jobs view
 func (m JobsViewModel) View(width, height int, target string) string {
 	titleStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("205")).
+		Foreground(theme.Primary).
 		Bold(true).
 		MarginBottom(1)
 	
@@ -231,37 +675,45 @@ func (m JobsViewModel) View(width, height int, target string) string {
 		MarginBottom(1)
 		
 	selectedStyle := itemStyle.Copy().
-		Foreground(lipgloss.Color("205")).
+		Foreground(theme.Primary).
 		Bold(true).
 		PaddingLeft(1).
 		Border(lipgloss.NormalBorder(), false, false, false, true).
-		BorderForeground(lipgloss.Color("205"))
+		BorderForeground(theme.Primary)
 	
 	searchStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("240")).
+		BorderForeground(theme.Muted).
 		Padding(0, 1).
 		MarginBottom(1)
 	
 	searchActiveStyle := searchStyle.Copy().
-		BorderForeground(lipgloss.Color("205"))
+		BorderForeground(theme.Primary)
 	
 	var content strings.Builder
 	title := "Jobs"
 	if m.pipeline != "" {
 		title = fmt.Sprintf("Jobs - %s", m.pipeline)
 	}
+	if m.refreshing && !m.loading {
+		title += staleNote
+	}
 	content.WriteString(titleStyle.Render(title))
 	content.WriteString("\n\n")
 	
 	if m.loading {
-		content.WriteString("Loading jobs...\n")
+		content.WriteString(m.loader.View("Loading jobs...") + "\n")
 		return content.String()
 	}
 	
+	if len(m.groups) > 0 && m.err == nil {
+		content.WriteString(m.renderGroupTabs(width))
+		content.WriteString("\n\n")
+	}
+	
 	if m.err != nil {
-		errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
-		content.WriteString(errorStyle.Render(fmt.Sprintf("Error: %v", m.err)))
+		errorStyle := lipgloss.NewStyle().Foreground(theme.Error)
+		content.WriteString(errorStyle.Render("Error: " + errorSummary(m.err)))
 		content.WriteString("\n")
 		return content.String()
 	}
@@ -269,16 +721,21 @@ func (m JobsViewModel) View(width, height int, target string) string {
 	// Add search box
 	searchPrompt := "Search: "
 	searchText := m.searchQuery
+	var searchBox string
 	if m.searchMode {
 		searchText += "█" // cursor
-		content.WriteString(searchActiveStyle.Render(searchPrompt + searchText))
+		searchBox = searchActiveStyle.Render(searchPrompt + searchText)
 	} else {
 		if m.searchQuery != "" {
-			content.WriteString(searchStyle.Render(searchPrompt + searchText))
+			searchBox = searchStyle.Render(searchPrompt + searchText)
 		} else {
-			content.WriteString(searchStyle.Render(searchPrompt + "(/,s to search)"))
+			searchBox = searchStyle.Render(searchPrompt + "(/,s to search)")
 		}
 	}
+	sortLabel := lipgloss.NewStyle().Foreground(theme.Muted).
+		Render(fmt.Sprintf("  sorted by %s (%s to change)", jobSortLabels[m.sort], keys.Label(actionSort)))
+	// Next to the search text, below the box's top border
+	content.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, searchBox, "\n"+sortLabel))
 	content.WriteString("\n\n")
 	
 	if len(m.filteredJobs) == 0 {
@@ -290,14 +747,33 @@ func (m JobsViewModel) View(width, height int, target string) string {
 		return content.String()
 	}
 	
-	// Show jobs list
-	for i, job := range m.filteredJobs {
+	start, end := m.visibleRange(height)
+	
+	// Add scroll indicator at top
+	if start > 0 {
+		content.WriteString(itemStyle.Render(fmt.Sprintf("  ↑ (%d more above)", start)))
+		content.WriteString("\n")
+	}
+	
+	// Show visible jobs only
+	for i := start; i < end; i++ {
+		job := m.filteredJobs[i]
 		status := ""
 		if job.FinishedBuild.Status != "" {
 			status = fmt.Sprintf(" [%s]", strings.ToUpper(job.FinishedBuild.Status))
 		}
 		
-		line := fmt.Sprintf("%s%s", job.Name, status)
+		line := fmt.Sprintf("%s%s%s", job.Name, status, jobIndicators(job))
+		if m.stateStore != nil && m.client != nil && m.stateStore.IsWatched(m.watchItem(job)) {
+			line += " [WATCHED]"
+		}
+		if len(m.marked) > 0 {
+			if m.marked[job.Name] {
+				line = "[x] " + line
+			} else {
+				line = "[ ] " + line
+			}
+		}
 		
 		if i == m.selected {
 			content.WriteString(selectedStyle.Render("> " + line))
@@ -307,18 +783,39 @@ func (m JobsViewModel) View(width, height int, target string) string {
 		content.WriteString("\n")
 	}
 	
-	// Show selected job info
-	if len(m.filteredJobs) > 0 {
+	// Add scroll indicator at bottom
+	if end < len(m.filteredJobs) {
+		content.WriteString(itemStyle.Render(fmt.Sprintf("  ↓ (%d more below)", len(m.filteredJobs)-end)))
+		content.WriteString("\n")
+	}
+	
+	// Show the selected job's links, or its info
+	if m.links != nil {
+		content.WriteString("\n")
+		content.WriteString(m.renderLinks())
+	} else if len(m.filteredJobs) > 0 {
 		content.WriteString("\n")
 		infoStyle := lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("240")).
+			BorderForeground(theme.Muted).
 			Padding(1).
 			MarginTop(1)
 		
 		job := m.filteredJobs[m.selected]
 		info := fmt.Sprintf("Job: %s\nPipeline: %s\nTeam: %s", 
 			job.Name, job.PipelineName, job.TeamName)
+		if job.Paused {
+			info += "\nPaused: yes, new builds won't start until it is unpaused"
+		}
+		var groups []string
+		for _, group := range m.groups {
+			if group.Contains(job.Name) {
+				groups = append(groups, group.Name)
+			}
+		}
+		if len(groups) > 0 {
+			info += fmt.Sprintf("\nGroups: %s", strings.Join(groups, ", "))
+		}
 		
 		if job.FinishedBuild.Status != "" {
 			info += fmt.Sprintf("\nLast Build: #%d (%s)", job.FinishedBuild.ID, job.FinishedBuild.Status)
@@ -326,70 +823,147 @@ func (m JobsViewModel) View(width, height int, target string) string {
 		
 		if job.NextBuild.ID != 0 {
 			info += fmt.Sprintf("\nNext Build: #%d", job.NextBuild.ID)
+			if preparation := m.renderPreparation(job.NextBuild); preparation != "" {
+				info += "\n" + preparation
+			}
 		}
 		
 		content.WriteString(infoStyle.Render(info))
 	}
 	
+	// Show batch trigger progress and results
+	if m.batchRunning > 0 {
+		content.WriteString("\n\n")
+		statusStyle := lipgloss.NewStyle().
+			Foreground(theme.Warning).
+			Bold(true)
+		content.WriteString(m.loader.View(statusStyle.Render(fmt.Sprintf("Triggering %d marked jobs...", m.batchRunning))))
+		content.WriteString("\n")
+	} else if len(m.batchResults) > 0 {
+		content.WriteString("\n")
+		content.WriteString(m.renderBatchResults())
+		content.WriteString("\n")
+	}
+
 	// Show triggering status
 	if m.triggeringJob != "" {
-		content.WriteString("\n")
+		content.WriteString("\n\n")
 		statusStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("226")).
-			Bold(true).
-			MarginTop(1)
-		content.WriteString(statusStyle.Render(fmt.Sprintf("🔄 Triggering job: %s", m.triggeringJob)))
+			Foreground(theme.Warning).
+			Bold(true)
+		content.WriteString(m.loader.View(statusStyle.Render(fmt.Sprintf("Triggering job: %s", m.triggeringJob))))
 		content.WriteString("\n")
 		content.WriteString(fmt.Sprintf("Command: fly -t %s trigger-job -j %s", target, m.triggeringJob))
-	} else if m.triggerResult != "" || m.triggerError != nil {
+	}
+
+	if m.watchErr != nil {
 		content.WriteString("\n")
-		
-		if m.triggerError != nil {
-			errorStyle := lipgloss.NewStyle().
-				Foreground(lipgloss.Color("196")).
-				Bold(true).
-				MarginTop(1)
-			content.WriteString(errorStyle.Render("❌ Job trigger failed:"))
-			content.WriteString("\n")
-			
-			errorDetailStyle := lipgloss.NewStyle().
-				Border(lipgloss.RoundedBorder()).
-				BorderForeground(lipgloss.Color("196")).
-				Padding(1).
-				MarginTop(1)
-			content.WriteString(errorDetailStyle.Render("Error:\n" + m.triggerError.Error()))
-		}
-		
-		if m.triggerResult != "" {
-			successStyle := lipgloss.NewStyle().
-				Foreground(lipgloss.Color("46")).
-				Bold(true).
-				MarginTop(1)
-			content.WriteString(successStyle.Render("✅ Job triggered successfully:"))
-			content.WriteString("\n")
-			
-			resultStyle := lipgloss.NewStyle().
-				Border(lipgloss.RoundedBorder()).
-				BorderForeground(lipgloss.Color("46")).
-				Padding(1).
-				MarginTop(1)
-			content.WriteString(resultStyle.Render("Output:\n" + m.triggerResult))
-		}
+		errorStyle := lipgloss.NewStyle().Foreground(theme.Error).MarginTop(1)
+		content.WriteString(errorStyle.Render(fmt.Sprintf("Failed to save watchlist: %v", m.watchErr)))
 	}
 
 	// Help text
 	helpStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("240")).
+		Foreground(theme.Muted).
 		Italic(true).
 		MarginTop(1)
 	
 	var help string
 	if m.searchMode {
 		help = "Enter: finish search • Esc: cancel search • Ctrl+U: clear"
+	} else if m.links != nil {
+		help = fmt.Sprintf("%s: navigate • %s: jump to job • %s/%s: close", keys.Label(actionUp), keys.Label(actionSelect), keys.Label(actionLinks), keys.Label(actionBack))
 	} else {
-		help = "↑/↓: navigate • Enter/t: trigger • b: builds • /,s: search • x: clear • F5: refresh • Esc: back"
+		help = keys.HelpLine(viewKeys[ViewJobs]...)
+		if len(m.marked) > 0 {
+			pending := "skipping jobs with a pending build"
+			if !m.skipPending {
+				pending = "triggering jobs with a pending build too"
+			}
+			help = fmt.Sprintf("%d marked, %s (%s to toggle)\n%s", len(m.marked), pending, keys.Label(actionSkipPending), help)
+		}
 	}
 	content.WriteString(helpStyle.Render(help))
 
 	return content.String()
-}
\ No newline at end of file
+}
+
+// jobIndicators renders whether a job is paused and whether it has a build
+// pending or running, which the last build's status doesn't tell
+func jobIndicators(job concourse.Job) string {
+	var indicators string
+	if job.Paused {
+		indicators += lipgloss.NewStyle().Foreground(theme.Warning).Bold(true).Render(" [PAUSED]")
+	}
+	switch job.NextBuild.Status {
+	case "pending":
+		indicators += lipgloss.NewStyle().Foreground(theme.Muted).Bold(true).Render(" [PENDING]")
+	case "started":
+		indicators += lipgloss.NewStyle().Foreground(theme.Info).Bold(true).Render(" [RUNNING]")
+	}
+	return indicators
+}
+
+// renderGroupTabs renders the pipeline's groups as a tab bar, like the web UI
+// shows them, with an all jobs tab first
+func (m JobsViewModel) renderGroupTabs(width int) string {
+	tabStyle := lipgloss.NewStyle().
+		Foreground(theme.Muted).
+		Padding(0, 1)
+	activeTabStyle := tabStyle.Copy().
+		Foreground(theme.Primary).
+		Bold(true).
+		Underline(true)
+	
+	names := []string{"all"}
+	for _, group := range m.groups {
+		names = append(names, group.Name)
+	}
+	
+	var tabs []string
+	for i, name := range names {
+		if (i == 0 && m.group == "") || (i > 0 && name == m.group) {
+			tabs = append(tabs, activeTabStyle.Render(name))
+		} else {
+			tabs = append(tabs, tabStyle.Render(name))
+		}
+	}
+	bar := strings.Join(tabs, "│")
+	hint := lipgloss.NewStyle().Foreground(theme.Muted).Italic(true).Render("  " + keys.Label(actionNextGroup) + ": next group")
+	return ansi.Truncate(bar+hint, max(width-2, 20), "…")
+}
+
+// renderBatchResults renders the aggregated outcome of the last batch trigger
+func (m JobsViewModel) renderBatchResults() string {
+	var triggered, skipped, failed int
+	var lines []string
+	for _, result := range m.batchResults {
+		switch {
+		case result.Skipped:
+			skipped++
+			lines = append(lines, fmt.Sprintf("⏭  %s: skipped (build already pending)", result.Job))
+		case result.Error != nil:
+			failed++
+			lines = append(lines, fmt.Sprintf("❌ %s: %v", result.Job, result.Error))
+		case !result.Success:
+			failed++
+			lines = append(lines, fmt.Sprintf("❌ %s: %s", result.Job, result.Output))
+		default:
+			triggered++
+			lines = append(lines, fmt.Sprintf("✅ %s: %s", result.Job, result.Output))
+		}
+	}
+
+	borderColor := theme.Success
+	if failed > 0 {
+		borderColor = theme.Error
+	}
+	resultStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(borderColor).
+		Padding(1).
+		MarginTop(1)
+
+	summary := fmt.Sprintf("Batch trigger: %d triggered, %d skipped, %d failed", triggered, skipped, failed)
+	return resultStyle.Render(summary + "\n\n" + strings.Join(lines, "\n"))
+}
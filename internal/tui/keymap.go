@@ -0,0 +1,351 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// keyAction identifies something a key can be bound to
+type keyAction string
+
+const (
+	actionUp            keyAction = "up"
+	actionDown          keyAction = "down"
+	actionSelect        keyAction = "select"
+	actionBack          keyAction = "back"
+	actionQuit          keyAction = "quit"
+	actionRefresh       keyAction = "refresh"
+	actionSearch        keyAction = "search"
+	actionClear         keyAction = "clear"
+	actionAdd           keyAction = "add"
+	actionDelete        keyAction = "delete"
+	actionDetails       keyAction = "details"
+	actionJobs          keyAction = "jobs"
+	actionResources     keyAction = "resources"
+	actionPause         keyAction = "pause"
+	actionFavorite      keyAction = "favorite"
+	actionWatch         keyAction = "watch"
+	actionTrigger       keyAction = "trigger"
+	actionMark          keyAction = "mark"
+	actionMarkAll       keyAction = "mark_all"
+	actionTriggerMarked keyAction = "trigger_marked"
+	actionSkipPending   keyAction = "toggle_skip_pending"
+	actionBuilds        keyAction = "builds"
+	actionCheck         keyAction = "check"
+	actionRerun         keyAction = "rerun"
+	actionLogin         keyAction = "login"
+	actionCancel        keyAction = "cancel"
+	actionTheme         keyAction = "cycle_theme"
+	actionConfirm       keyAction = "confirm"
+	actionDestroy       keyAction = "destroy"
+	actionAbort         keyAction = "abort"
+	actionErrorDetails  keyAction = "error_details"
+	actionCopy          keyAction = "copy"
+	actionHelp          keyAction = "help"
+	actionHistory       keyAction = "history"
+	actionSync          keyAction = "sync"
+	actionPasswordLogin keyAction = "password_login"
+	actionEdit          keyAction = "edit"
+	actionDuplicate     keyAction = "duplicate"
+	actionSwitchTeam    keyAction = "switch_team"
+	actionAllTeams      keyAction = "all_teams"
+	actionTeams         keyAction = "teams"
+	actionNewTeam       keyAction = "new_team"
+	actionSetTeam       keyAction = "set_team"
+	actionActiveUsers   keyAction = "active_users"
+	actionNextGroup     keyAction = "next_group"
+	actionPrevGroup     keyAction = "prev_group"
+	actionGraph         keyAction = "graph"
+	actionLinks         keyAction = "links"
+	actionFlaky         keyAction = "flaky"
+	actionWindow        keyAction = "window"
+	actionLog           keyAction = "log"
+	actionSort          keyAction = "sort"
+	actionArchived      keyAction = "archived"
+	actionFilter        keyAction = "filter"
+	actionDashboard     keyAction = "dashboard"
+	actionFavoritesOnly keyAction = "favorites_only"
+	actionGrowPane      keyAction = "grow_pane"
+	actionShrinkPane    keyAction = "shrink_pane"
+	actionTogglePane    keyAction = "toggle_pane"
+	actionConfig        keyAction = "config"
+	actionOpen          keyAction = "open"
+	actionYank          keyAction = "yank"
+	actionEditConfig    keyAction = "edit_config"
+	actionPager         keyAction = "pager"
+	actionLoginTerminal keyAction = "login_terminal"
+	actionTokenLogin    keyAction = "token_login"
+	actionGlobalSearch  keyAction = "global_search"
+	actionAggregate     keyAction = "aggregate"
+	actionTargetGroup   keyAction = "target_group"
+	actionRestoreFlyrc  keyAction = "restore_flyrc"
+	actionLogout        keyAction = "logout"
+	actionNextMatch     keyAction = "next_match"
+	actionPrevMatch     keyAction = "prev_match"
+	actionNextStep      keyAction = "next_step"
+	actionPrevStep      keyAction = "prev_step"
+	actionToggleStep    keyAction = "toggle_step"
+	actionToggleSteps   keyAction = "toggle_steps"
+	actionTimestamps    keyAction = "timestamps"
+	actionSaveLog       keyAction = "save_log"
+	actionJumpLevel     keyAction = "jump_level"
+)
+
+// keyBinding is the set of keys bound to an action, plus its help description
+type keyBinding struct {
+	Keys []string
+	Help string
+}
+
+// keyMap is the central registry of key bindings used by every view
+type keyMap map[keyAction]keyBinding
+
+// keys holds the active key bindings, customizable via the FlyBy config file
+var keys = defaultKeyMap()
+
+// defaultKeyMap returns FlyBy's built-in key bindings
+func defaultKeyMap() keyMap {
+	return keyMap{
+		actionUp:            {Keys: []string{"up", "k"}, Help: "navigate"},
+		actionDown:          {Keys: []string{"down", "j"}, Help: "navigate"},
+		actionSelect:        {Keys: []string{"enter"}, Help: "select"},
+		actionBack:          {Keys: []string{"esc"}, Help: "back"},
+		actionQuit:          {Keys: []string{"q"}, Help: "quit"},
+		actionRefresh:       {Keys: []string{"f5"}, Help: "refresh"},
+		actionSearch:        {Keys: []string{"/", "s"}, Help: "search"},
+		actionClear:         {Keys: []string{"x"}, Help: "clear"},
+		actionAdd:           {Keys: []string{"a"}, Help: "add target"},
+		actionDelete:        {Keys: []string{"d"}, Help: "delete"},
+		actionDetails:       {Keys: []string{"i"}, Help: "toggle details"},
+		actionJobs:          {Keys: []string{"enter", "j"}, Help: "jobs"},
+		actionResources:     {Keys: []string{"r"}, Help: "resources"},
+		actionPause:         {Keys: []string{"p"}, Help: "pause/unpause"},
+		actionFavorite:      {Keys: []string{"f"}, Help: "favorite"},
+		actionWatch:         {Keys: []string{"w"}, Help: "watch"},
+		actionTrigger:       {Keys: []string{"enter", "t"}, Help: "trigger"},
+		actionMark:          {Keys: []string{" "}, Help: "mark"},
+		actionMarkAll:       {Keys: []string{"a"}, Help: "mark all"},
+		actionTriggerMarked: {Keys: []string{"T"}, Help: "trigger marked"},
+		actionSkipPending:   {Keys: []string{"p"}, Help: "skip pending"},
+		actionBuilds:        {Keys: []string{"b"}, Help: "builds"},
+		actionCheck:         {Keys: []string{"enter", "c"}, Help: "check"},
+		actionRerun:         {Keys: []string{"enter"}, Help: "rerun build"},
+		actionLogin:         {Keys: []string{"enter", "y"}, Help: "login"},
+		actionCancel:        {Keys: []string{"n"}, Help: "cancel"},
+		actionTheme:         {Keys: []string{"ctrl+t"}, Help: "theme"},
+		actionConfirm:       {Keys: []string{"y"}, Help: "confirm"},
+		actionDestroy:       {Keys: []string{"D"}, Help: "destroy"},
+		actionAbort:         {Keys: []string{"A"}, Help: "abort build"},
+		actionErrorDetails:  {Keys: []string{"E"}, Help: "error details"},
+		actionCopy:          {Keys: []string{"c"}, Help: "copy"},
+		actionHelp:          {Keys: []string{"?"}, Help: "help"},
+		actionHistory:       {Keys: []string{"H"}, Help: "command history"},
+		actionSync:          {Keys: []string{"enter", "y"}, Help: "sync fly"},
+		actionPasswordLogin: {Keys: []string{"u"}, Help: "username/password login"},
+		actionEdit:          {Keys: []string{"e"}, Help: "edit target"},
+		actionDuplicate:     {Keys: []string{"D"}, Help: "duplicate target"},
+		actionSwitchTeam:    {Keys: []string{"t"}, Help: "switch team"},
+		actionAllTeams:      {Keys: []string{"T"}, Help: "all teams"},
+		actionTeams:         {Keys: []string{"m"}, Help: "manage teams"},
+		actionNewTeam:       {Keys: []string{"a"}, Help: "new team"},
+		actionSetTeam:       {Keys: []string{"e"}, Help: "set auth"},
+		actionActiveUsers:   {Keys: []string{"U"}, Help: "active users"},
+		actionNextGroup:     {Keys: []string{"tab", "]"}, Help: "next group"},
+		actionPrevGroup:     {Keys: []string{"shift+tab", "["}, Help: "previous group"},
+		actionGraph:         {Keys: []string{"g"}, Help: "graph"},
+		actionLinks:         {Keys: []string{"l"}, Help: "upstream/downstream"},
+		actionFlaky:         {Keys: []string{"F"}, Help: "flaky jobs"},
+		actionWindow:        {Keys: []string{"w"}, Help: "window"},
+		actionLog:           {Keys: []string{"v"}, Help: "build log"},
+		actionSort:          {Keys: []string{"O"}, Help: "sort"},
+		actionArchived:      {Keys: []string{"A"}, Help: "archived"},
+		actionFilter:        {Keys: []string{"P"}, Help: "paused/failing only"},
+		actionDashboard:     {Keys: []string{"d"}, Help: "dashboard"},
+		actionFavoritesOnly: {Keys: []string{"f"}, Help: "favorites only"},
+		actionGrowPane:      {Keys: []string{">"}, Help: "grow pane"},
+		actionShrinkPane:    {Keys: []string{"<"}, Help: "shrink pane"},
+		actionTogglePane:    {Keys: []string{"|"}, Help: "collapse pane"},
+		actionConfig:        {Keys: []string{"c"}, Help: "config"},
+		actionOpen:          {Keys: []string{"o"}, Help: "open in browser"},
+		actionYank:          {Keys: []string{"y"}, Help: "copy fly command"},
+		actionEditConfig:    {Keys: []string{"e"}, Help: "edit config"},
+		actionPager:         {Keys: []string{"p"}, Help: "open in pager"},
+		actionLoginTerminal: {Keys: []string{"w"}, Help: "login in new window"},
+		actionTokenLogin:    {Keys: []string{"t"}, Help: "paste token"},
+		actionGlobalSearch:  {Keys: []string{"ctrl+f"}, Help: "search everything"},
+		actionAggregate:     {Keys: []string{"P"}, Help: "pipelines of marked targets"},
+		actionTargetGroup:   {Keys: []string{"ctrl+g"}, Help: "switch target group"},
+		actionRestoreFlyrc:  {Keys: []string{"R"}, Help: "restore flyrc backup"},
+		actionLogout:        {Keys: []string{"L"}, Help: "fly logout"},
+		actionNextMatch:     {Keys: []string{"n"}, Help: "next match"},
+		actionPrevMatch:     {Keys: []string{"N"}, Help: "previous match"},
+		actionNextStep:      {Keys: []string{"tab"}, Help: "next step"},
+		actionPrevStep:      {Keys: []string{"shift+tab"}, Help: "previous step"},
+		actionToggleStep:    {Keys: []string{"enter"}, Help: "collapse/expand step"},
+		actionToggleSteps:   {Keys: []string{"z"}, Help: "collapse/expand all steps"},
+		actionTimestamps:    {Keys: []string{"t"}, Help: "timestamps"},
+		actionSaveLog:       {Keys: []string{"S"}, Help: "save to file"},
+		actionJumpLevel:     {Keys: []string{"1", "2", "3", "4"}, Help: "jump to breadcrumb"},
+	}
+}
+
+// viewKeys lists the actions shown in each view's own help line
+var viewKeys = map[ViewType][]keyAction{
+	ViewMain:        {actionUp, actionDown, actionSelect},
+	ViewTargets:     {actionUp, actionDown, actionSelect, actionMark, actionAggregate, actionAdd, actionEdit, actionDuplicate, actionSwitchTeam, actionTeams, actionActiveUsers, actionLogout, actionDelete, actionRestoreFlyrc, actionDetails, actionSearch, actionRefresh, actionBack},
+	ViewPipelines:   {actionUp, actionDown, actionJobs, actionResources, actionGraph, actionConfig, actionFlaky, actionDashboard, actionPause, actionFavorite, actionWatch, actionDestroy, actionAllTeams, actionArchived, actionFilter, actionSearch, actionRefresh, actionBack},
+	ViewJobs:        {actionUp, actionDown, actionTrigger, actionMark, actionMarkAll, actionTriggerMarked, actionWatch, actionBuilds, actionLog, actionGraph, actionLinks, actionFlaky, actionSort, actionSearch, actionClear, actionRefresh, actionBack},
+	ViewResources:   {actionUp, actionDown, actionCheck, actionOpen, actionYank, actionSearch, actionClear, actionRefresh, actionBack},
+	ViewBuilds:      {actionUp, actionDown, actionRerun, actionLog, actionAbort, actionSort, actionOpen, actionYank, actionRefresh, actionBack},
+	ViewAuth:        {actionLogin, actionLoginTerminal, actionTokenLogin, actionPasswordLogin, actionCancel},
+	ViewSync:        {actionSync, actionCancel},
+	ViewWatchlist:   {actionUp, actionDown, actionSelect, actionDelete, actionRefresh, actionBack},
+	ViewHistory:     {actionUp, actionDown, actionCopy, actionPager, actionRefresh, actionBack},
+	ViewTeams:       {actionUp, actionDown, actionNewTeam, actionSetTeam, actionDestroy, actionRefresh, actionBack},
+	ViewActiveUsers: {actionUp, actionDown, actionSearch, actionClear, actionRefresh, actionBack},
+	ViewGraph:       {actionUp, actionDown, actionBuilds, actionRefresh, actionBack},
+	ViewFlaky:       {actionUp, actionDown, actionBuilds, actionWindow, actionRefresh, actionBack},
+	ViewBuildLog:    {actionUp, actionDown, actionNextStep, actionPrevStep, actionToggleStep, actionToggleSteps, actionTimestamps, actionSearch, actionPager, actionSaveLog, actionYank, actionRefresh, actionBack},
+	ViewDashboard:   {actionUp, actionDown, actionSelect, actionFavoritesOnly, actionRefresh, actionBack},
+	ViewConfig:      {actionUp, actionDown, actionEditConfig, actionPager, actionYank, actionRefresh, actionBack},
+	ViewAggregate:   {actionUp, actionDown, actionJobs, actionResources, actionSearch, actionRefresh, actionBack},
+}
+
+// footerKeys lists the actions shown in the application footer for each view
+var footerKeys = map[ViewType][]keyAction{
+	ViewMain:        {actionUp, actionDown, actionSelect, actionTheme, actionHelp, actionQuit},
+	ViewTargets:     {actionUp, actionDown, actionSelect, actionAdd, actionEdit, actionDelete, actionBack, actionQuit},
+	ViewPipelines:   {actionUp, actionDown, actionJobs, actionResources, actionPause, actionFavorite, actionWatch, actionRefresh, actionBack, actionQuit},
+	ViewJobs:        {actionUp, actionDown, actionTrigger, actionMark, actionTriggerMarked, actionWatch, actionBuilds, actionRefresh, actionBack, actionQuit},
+	ViewResources:   {actionUp, actionDown, actionCheck, actionRefresh, actionBack, actionQuit},
+	ViewBuilds:      {actionUp, actionDown, actionRerun, actionLog, actionRefresh, actionBack, actionQuit},
+	ViewAuth:        {actionLogin, actionPasswordLogin, actionCancel, actionBack, actionQuit},
+	ViewSync:        {actionSync, actionCancel, actionBack, actionQuit},
+	ViewWatchlist:   {actionUp, actionDown, actionSelect, actionDelete, actionRefresh, actionBack, actionQuit},
+	ViewHistory:     {actionUp, actionDown, actionCopy, actionRefresh, actionBack, actionQuit},
+	ViewTeams:       {actionUp, actionDown, actionNewTeam, actionSetTeam, actionDestroy, actionRefresh, actionBack, actionQuit},
+	ViewActiveUsers: {actionUp, actionDown, actionSearch, actionRefresh, actionBack, actionQuit},
+	ViewGraph:       {actionUp, actionDown, actionBuilds, actionRefresh, actionBack, actionQuit},
+	ViewFlaky:       {actionUp, actionDown, actionBuilds, actionWindow, actionRefresh, actionBack, actionQuit},
+	ViewBuildLog:    {actionUp, actionDown, actionRefresh, actionBack, actionQuit},
+	ViewDashboard:   {actionUp, actionDown, actionSelect, actionFavoritesOnly, actionRefresh, actionBack, actionQuit},
+	ViewConfig:      {actionUp, actionDown, actionRefresh, actionBack, actionQuit},
+	ViewAggregate:   {actionUp, actionDown, actionJobs, actionResources, actionRefresh, actionBack, actionQuit},
+}
+
+// Matches reports whether the key message is bound to the action
+func (k keyMap) Matches(msg tea.KeyMsg, action keyAction) bool {
+	pressed := msg.String()
+	for _, key := range k[action].Keys {
+		if key == pressed {
+			return true
+		}
+	}
+	return false
+}
+
+// Index returns which of the action's keys was pressed, or -1 for none of
+// them, for actions whose keys each pick something like a breadcrumb level
+func (k keyMap) Index(msg tea.KeyMsg, action keyAction) int {
+	pressed := msg.String()
+	for i, key := range k[action].Keys {
+		if key == pressed {
+			return i
+		}
+	}
+	return -1
+}
+
+// fixedHelp returns a footer entry for keys that can't be remapped, like the
+// enter and esc of a form whose letters are typed into its fields
+func fixedHelp(help string, fixed ...string) string {
+	labels := make([]string, len(fixed))
+	for i, key := range fixed {
+		labels[i] = keyLabel(key)
+	}
+	return strings.Join(labels, "/") + ": " + help
+}
+
+// keyLabel returns the display form of a single key
+func keyLabel(key string) string {
+	switch key {
+	case "up":
+		return "↑"
+	case "down":
+		return "↓"
+	case "left":
+		return "←"
+	case "right":
+		return "→"
+	case " ":
+		return "space"
+	case "enter", "esc", "tab":
+		return strings.ToUpper(key[:1]) + key[1:]
+	}
+	if len(key) > 1 && key[0] == 'f' && key[1] >= '0' && key[1] <= '9' {
+		return strings.ToUpper(key)
+	}
+	return key
+}
+
+// Label returns the display form of all keys bound to the action, e.g. "Enter/t"
+func (k keyMap) Label(action keyAction) string {
+	var labels []string
+	separator := "/"
+	for _, key := range k[action].Keys {
+		if key == "/" {
+			separator = ","
+		}
+		labels = append(labels, keyLabel(key))
+	}
+	return strings.Join(labels, separator)
+}
+
+// Help returns the "keys: description" help entry for the action
+func (k keyMap) Help(action keyAction) string {
+	return fmt.Sprintf("%s: %s", k.Label(action), k[action].Help)
+}
+
+// HelpLine renders help entries for the actions, folding up/down into one
+// navigate entry
+func (k keyMap) HelpLine(actions ...keyAction) string {
+	var entries []string
+	for i := 0; i < len(actions); i++ {
+		if actions[i] == actionUp && i+1 < len(actions) && actions[i+1] == actionDown {
+			entries = append(entries, fmt.Sprintf("%s/%s: navigate",
+				keyLabel(k[actionUp].Keys[0]), keyLabel(k[actionDown].Keys[0])))
+			i++
+			continue
+		}
+		entries = append(entries, k.Help(actions[i]))
+	}
+	return strings.Join(entries, " • ")
+}
+
+// ApplyKeyBindings replaces default bindings with the ones from the FlyBy
+// config file, keyed by action name
+func ApplyKeyBindings(overrides map[string][]string) error {
+	var unknown []string
+	for name, bound := range overrides {
+		action := keyAction(name)
+		binding, exists := keys[action]
+		if !exists {
+			unknown = append(unknown, name)
+			continue
+		}
+		if len(bound) == 0 {
+			return fmt.Errorf("key binding for %q has no keys", name)
+		}
+		binding.Keys = bound
+		keys[action] = binding
+	}
+
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("unknown key binding actions: %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package tui
+
+import (
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// loader animates a spinner while a view waits on fly
+type loader struct {
+	spinner spinner.Model
+	ticking bool
+}
+
+// newLoader creates a stopped loader
+func newLoader() loader {
+	frames := spinner.Dot
+	if asciiOnly {
+		frames = spinner.Line
+	}
+	return loader{spinner: spinner.New(spinner.WithSpinner(frames))}
+}
+
+// Spin starts the spinner when the view is busy and it is not already running
+func (l *loader) Spin(active bool) tea.Cmd {
+	if !active || l.ticking {
+		return nil
+	}
+	l.ticking = true
+	return l.spinner.Tick
+}
+
+// Update advances the spinner on its own ticks, and lets it stop once the
+// view is no longer busy
+func (l *loader) Update(msg spinner.TickMsg, active bool) tea.Cmd {
+	if msg.ID != l.spinner.ID() {
+		return nil
+	}
+	if !active {
+		l.ticking = false
+		return nil
+	}
+
+	var cmd tea.Cmd
+	l.spinner, cmd = l.spinner.Update(msg)
+	return cmd
+}
+
+// View renders the spinner followed by a message
+func (l loader) View(message string) string {
+	return lipgloss.NewStyle().Foreground(theme.Primary).Render(l.spinner.View()) + " " + message
+}
+
+// staleNote is appended to the title of a view that shows the data of an
+// earlier visit while it reloads
+const staleNote = " • stale (refreshing…)"
+
+// busyLoader pairs a view's loader with whether that view is waiting on fly
+type busyLoader struct {
+	loader *loader
+	active bool
+}
+
+// busyViews returns the loaders of every view that can wait on fly
+func (m *Model) busyViews() []busyLoader {
+	return []busyLoader{
+		{&m.pipelinesView.loader, m.pipelinesView.state == pipelinesStateLoading || m.pipelinesView.filter == pipelineFilterFailing && m.pipelinesView.checking() > 0},
+		{&m.jobsView.loader, m.jobsView.loading || m.jobsView.triggeringJob != "" || m.jobsView.batchRunning > 0},
+		{&m.resourcesView.loader, m.resourcesView.state == resourcesStateLoading || m.resourcesView.checkingResource != ""},
+		{&m.buildsView.loader, m.buildsView.state == buildsStateLoading || m.buildsView.state == buildsStateRerunning},
+		{&m.watchlistView.loader, m.watchlistView.loading},
+		{&m.authView.loader, m.authView.authenticating},
+		{&m.syncView.loader, m.syncView.syncing},
+		{&m.addTargetView.loader, m.addTargetView.saving},
+		{&m.teamsView.loader, m.teamsView.loading || m.teamsView.busy != ""},
+		{&m.activeUsersView.loader, m.activeUsersView.loading},
+		{&m.graphView.loader, m.graphView.loading},
+		{&m.flakyView.loader, m.flakyView.loading || m.flakyView.scanning()},
+		{&m.buildLogView.loader, m.buildLogView.running},
+		{&m.dashboardView.loader, m.dashboardView.loading || m.dashboardView.pending() > 0},
+		{&m.configView.loader, m.configView.loading},
+		{&m.searchView.loader, m.searchView.loading() || m.searchView.pending > 0},
+		{&m.aggregateView.loader, m.aggregateView.listing > 0},
+	}
+}
+
+// spinLoaders starts the spinner of every view that became busy
+func (m *Model) spinLoaders() tea.Cmd {
+	var cmds []tea.Cmd
+	for _, view := range m.busyViews() {
+		cmds = append(cmds, view.loader.Spin(view.active))
+	}
+	return tea.Batch(cmds...)
+}
+
+// updateLoaders routes a spinner tick to the loader it belongs to
+func (m *Model) updateLoaders(msg spinner.TickMsg) tea.Cmd {
+	var cmds []tea.Cmd
+	for _, view := range m.busyViews() {
+		cmds = append(cmds, view.loader.Update(msg, view.active))
+	}
+	return tea.Batch(cmds...)
+}
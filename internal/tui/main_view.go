@@ -1,23 +1,31 @@
 package tui
 
 import (
+	"fmt"
 	"strings"
 
+	"flyby/internal/state"
+
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
 // MainViewModel represents the main menu
 type MainViewModel struct {
-	choices  []string
-	selected int
+	stateStore *state.Store
+	choices    []string
+	selected   int
+	clicks     clickTracker
 }
 
 // NewMainViewModel creates a new main view model
-func NewMainViewModel() MainViewModel {
+func NewMainViewModel(stateStore *state.Store) MainViewModel {
 	return MainViewModel{
+		stateStore: stateStore,
 		choices: []string{
 			"Manage Targets",
+			"Watchlist",
+			"Command History",
 			"Exit",
 		},
 		selected: 0,
@@ -26,30 +34,91 @@ func NewMainViewModel() MainViewModel {
 
 // Update handles messages for the main view
 func (m MainViewModel) Update(msg tea.KeyMsg) (MainViewModel, tea.Cmd) {
-	switch msg.String() {
-	case "up", "k":
+	switch {
+	case keys.Matches(msg, actionUp):
 		if m.selected > 0 {
 			m.selected--
 		}
-	case "down", "j":
-		if m.selected < len(m.choices)-1 {
+	case keys.Matches(msg, actionDown):
+		if m.selected < len(m.choices)+len(m.recent())-1 {
 			m.selected++
 		}
-	case "enter":
+	case keys.Matches(msg, actionSelect):
 		return m, m.handleSelection()
 	}
 	
 	return m, nil
 }
 
+// Mouse handles clicks and scrolling over the menu and recent items
+func (m MainViewModel) Mouse(msg tea.MouseMsg) (MainViewModel, tea.Cmd) {
+	// Choices start below the title, its margin and the prompt; recent items
+	// follow after a blank line, the section title and another blank line
+	rows := listRows{top: 5, height: 2, end: len(m.choices)}
+	if recent := len(m.recent()); recent > 0 && msg.Y >= rows.top+2*len(m.choices) {
+		rows = listRows{top: 5 + 2*len(m.choices) + 3, height: 2, first: len(m.choices), end: len(m.choices) + recent}
+	}
+
+	switch intent, index := m.clicks.interpret(msg, rows); intent {
+	case mouseScrollUp:
+		return m.Update(keyMsgFor(actionUp))
+	case mouseScrollDown:
+		return m.Update(keyMsgFor(actionDown))
+	case mouseClick:
+		m.selected = index
+	case mouseDoubleClick:
+		m.selected = index
+		return m.Update(keyMsgFor(actionSelect))
+	}
+	return m, nil
+}
+
+// recent returns the recently visited items shown below the menu
+func (m MainViewModel) recent() []state.RecentItem {
+	if m.stateStore == nil {
+		return nil
+	}
+	return m.stateStore.GetRecent()
+}
+
 // handleSelection handles menu selection
 func (m MainViewModel) handleSelection() tea.Cmd {
-	switch m.selected {
-	case 0: // Manage Targets
+	if m.selected >= len(m.choices) {
+		recent := m.recent()
+		index := m.selected - len(m.choices)
+		if index >= len(recent) {
+			return nil
+		}
+		item := recent[index]
+		if item.Build != "" {
+			return func() tea.Msg {
+				return SwitchViewMsg{View: ViewBuildLog, Target: item.Target, Pipeline: item.Pipeline, Job: item.Job, Build: item.Build}
+			}
+		}
+		if item.Job != "" {
+			return func() tea.Msg {
+				return SwitchViewMsg{View: ViewBuilds, Target: item.Target, Pipeline: item.Pipeline, Job: item.Job}
+			}
+		}
+		return func() tea.Msg {
+			return SwitchViewMsg{View: ViewJobs, Target: item.Target, Pipeline: item.Pipeline}
+		}
+	}
+	
+	switch m.choices[m.selected] {
+	case "Manage Targets":
 		return func() tea.Msg {
 			return SwitchViewMsg{View: ViewTargets}
 		}
-	case 1: // Exit
+	case "Watchlist":
+		return func() tea.Msg {
+			return SwitchViewMsg{View: ViewWatchlist}
+		}
+	case "Command History":
+		return func() tea.Msg {
+			return SwitchViewMsg{View: ViewHistory}
+		}
+	case "Exit":
 		return tea.Quit
 	}
 	return nil
@@ -58,7 +127,7 @@ func (m MainViewModel) handleSelection() tea.Cmd {
 // View renders the main view
 func (m MainViewModel) View(width, height int) string {
 	titleStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("205")).
+		Foreground(theme.Primary).
 		Bold(true).
 		MarginBottom(2)
 	
@@ -67,11 +136,11 @@ func (m MainViewModel) View(width, height int) string {
 		MarginBottom(1)
 		
 	selectedStyle := itemStyle.Copy().
-		Foreground(lipgloss.Color("205")).
+		Foreground(theme.Primary).
 		Bold(true).
 		PaddingLeft(1).
 		Border(lipgloss.NormalBorder(), false, false, false, true).
-		BorderForeground(lipgloss.Color("205"))
+		BorderForeground(theme.Primary)
 	
 	var content strings.Builder
 	content.WriteString(titleStyle.Render("Welcome to FlyBy"))
@@ -87,5 +156,34 @@ func (m MainViewModel) View(width, height int) string {
 		content.WriteString("\n")
 	}
 	
+	// Show recently visited pipelines, jobs and builds for quick access
+	recent := m.recent()
+	if len(recent) > 0 {
+		sectionStyle := lipgloss.NewStyle().
+			Foreground(theme.Muted).
+			Bold(true).
+			MarginTop(1)
+		content.WriteString(sectionStyle.Render("Recent"))
+		content.WriteString("\n\n")
+		
+		for i, item := range recent {
+			line := fmt.Sprintf("%s › %s", item.Target, item.Pipeline)
+			if item.Job != "" {
+				line += " › " + item.Job
+			}
+			if item.Build != "" {
+				line += " #" + item.Build
+			}
+			line += lipgloss.NewStyle().Foreground(theme.Muted).Render("  " + formatBuildTimeAgo(item.GetVisited()))
+			
+			if len(m.choices)+i == m.selected {
+				content.WriteString(selectedStyle.Render("> " + line))
+			} else {
+				content.WriteString(itemStyle.Render("  " + line))
+			}
+			content.WriteString("\n")
+		}
+	}
+	
 	return content.String()
 }
\ No newline at end of file
@@ -0,0 +1,155 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// doubleClickInterval is the longest gap between two clicks on the same row
+// that still counts as a double-click
+const doubleClickInterval = 400 * time.Millisecond
+
+// searchListTop is the line on which the first row of a list below a title
+// and search box is drawn: title, margin, blank line, search box (3 lines),
+// margin and another blank line
+const searchListTop = 8
+
+// mouseIntent is what a mouse event over a list asks for
+type mouseIntent int
+
+const (
+	mouseNone mouseIntent = iota
+	mouseScrollUp
+	mouseScrollDown
+	mouseClick
+	mouseDoubleClick
+)
+
+// listRows describes where a view draws the rows of its list
+type listRows struct {
+	top    int // line of the first visible row
+	height int // lines per row
+	first  int // index of the first visible row
+	end    int // index after the last visible row
+}
+
+// clickTracker remembers the last clicked row to detect double-clicks
+type clickTracker struct {
+	index int
+	at    time.Time
+}
+
+// interpret maps a mouse event to an intent and the index of the row under
+// the pointer
+func (c *clickTracker) interpret(msg tea.MouseMsg, rows listRows) (mouseIntent, int) {
+	if msg.Action != tea.MouseActionPress {
+		return mouseNone, 0
+	}
+
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		return mouseScrollUp, 0
+	case tea.MouseButtonWheelDown:
+		return mouseScrollDown, 0
+	case tea.MouseButtonLeft:
+	default:
+		return mouseNone, 0
+	}
+
+	if msg.Y < rows.top || rows.height < 1 {
+		return mouseNone, 0
+	}
+	index := rows.first + (msg.Y-rows.top)/rows.height
+	if index >= rows.end {
+		return mouseNone, 0
+	}
+
+	now := time.Now()
+	double := index == c.index && now.Sub(c.at) <= doubleClickInterval
+	if double {
+		// A third click starts over instead of opening again
+		c.at = time.Time{}
+		return mouseDoubleClick, index
+	}
+	c.index, c.at = index, now
+	return mouseClick, index
+}
+
+// keyMsgFor builds the key message for the first key bound to an action, so
+// mouse input can reuse the keyboard handling of each view
+func keyMsgFor(action keyAction) tea.KeyMsg {
+	bound := keys[action].Keys
+	if len(bound) == 0 {
+		return tea.KeyMsg{}
+	}
+
+	switch key := bound[0]; key {
+	case "up":
+		return tea.KeyMsg{Type: tea.KeyUp}
+	case "down":
+		return tea.KeyMsg{Type: tea.KeyDown}
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	case " ":
+		return tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+	case "f5":
+		return tea.KeyMsg{Type: tea.KeyF5}
+	default:
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+	}
+}
+
+// handleMouse routes a mouse event to the current view, relative to the top
+// of the view's content
+func (m *Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if m.capturesInput() {
+		return m, nil
+	}
+
+	msg.Y -= lipgloss.Height(m.renderHeader())
+	if breadcrumbs := m.renderBreadcrumbs(); breadcrumbs != "" {
+		msg.Y -= lipgloss.Height(breadcrumbs)
+	}
+	height := m.contentHeight()
+
+	var cmd tea.Cmd
+	switch m.currentView {
+	case ViewMain:
+		m.mainView, cmd = m.mainView.Mouse(msg)
+	case ViewTargets:
+		m.targetsView, cmd = m.targetsView.Mouse(msg, height)
+	case ViewPipelines:
+		m.pipelinesView, cmd = m.pipelinesView.Mouse(msg)
+	case ViewJobs:
+		m.jobsView, cmd = m.jobsView.Mouse(msg, height)
+	case ViewResources:
+		m.resourcesView, cmd = m.resourcesView.Mouse(msg, height)
+	case ViewBuilds:
+		m.buildsView, cmd = m.buildsView.Mouse(msg, height)
+	case ViewWatchlist:
+		m.watchlistView, cmd = m.watchlistView.Mouse(msg, height)
+	case ViewHistory:
+		m.historyView, cmd = m.historyView.Mouse(msg, height)
+	case ViewActiveUsers:
+		m.activeUsersView, cmd = m.activeUsersView.Mouse(msg, height)
+	case ViewGraph:
+		m.graphView, cmd = m.graphView.Mouse(msg, m.width, height)
+	case ViewFlaky:
+		m.flakyView, cmd = m.flakyView.Mouse(msg, height)
+	case ViewBuildLog:
+		m.buildLogView, cmd = m.buildLogView.Mouse(msg, height)
+	case ViewDashboard:
+		m.dashboardView, cmd = m.dashboardView.Mouse(msg, height)
+	case ViewConfig:
+		m.configView, cmd = m.configView.Mouse(msg, height)
+	case ViewSearch:
+		m.searchView, cmd = m.searchView.Mouse(msg, height)
+	case ViewAggregate:
+		m.aggregateView, cmd = m.aggregateView.Mouse(msg, height)
+	}
+	return m, cmd
+}
@@ -0,0 +1,310 @@
+package tui
+
+import (
+	"flyby/internal/concourse"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// navEntry records a view together with the context it was showing
+type navEntry struct {
+	View     ViewType
+	Target   string
+	Pipeline string
+	Job      string
+	Build    string
+}
+
+// NavigateBackMsg is a message for returning to the previous view
+type NavigateBackMsg struct {
+	// From limits the navigation to when that view is still showing, which
+	// protects delayed messages from popping a view the user already left.
+	// The zero value (ViewMain) means unconditional, since main is the root.
+	From ViewType
+}
+
+// navigateBack returns a command that navigates back to the previous view
+func navigateBack() tea.Cmd {
+	return func() tea.Msg {
+		return NavigateBackMsg{}
+	}
+}
+
+// currentEntry returns the navigation entry for the current view and context
+func (m *Model) currentEntry() navEntry {
+	return navEntry{
+		View:     m.currentView,
+		Target:   m.currentTarget,
+		Pipeline: m.currentPipeline,
+		Job:      m.currentJob,
+		Build:    m.currentBuild,
+	}
+}
+
+// pushNav records the current view so it can be returned to later
+func (m *Model) pushNav() {
+	entry := m.currentEntry()
+	if len(m.navStack) > 0 && m.navStack[len(m.navStack)-1] == entry {
+		return
+	}
+	m.navStack = append(m.navStack, entry)
+}
+
+// popNav returns to the most recently recorded view, reloading it if its data
+// belongs to a different context than the one being restored
+func (m *Model) popNav() tea.Cmd {
+	if len(m.navStack) == 0 {
+		return nil
+	}
+
+	entry := m.navStack[len(m.navStack)-1]
+	m.navStack = m.navStack[:len(m.navStack)-1]
+	return m.restoreEntry(entry)
+}
+
+// restoreEntry switches to a recorded view and context
+func (m *Model) restoreEntry(entry navEntry) tea.Cmd {
+	// What the view being left still waits for is of no use anymore
+	concourse.CancelReads()
+	m.buildLogView.Stop()
+	m.currentView = entry.View
+	if entry.Target != m.currentTarget {
+		m.currentTarget = entry.Target
+		if entry.Target != "" {
+			m.client = concourse.NewClient(entry.Target)
+		}
+	}
+	m.currentPipeline = entry.Pipeline
+	m.currentJob = entry.Job
+	m.currentBuild = entry.Build
+
+	if m.viewIsStale() {
+		return m.handleViewSwitch()
+	}
+	return m.revalidateView()
+}
+
+// viewIsStale reports whether the current view's data was loaded for another
+// context, or failed to load because the view was left meanwhile
+func (m *Model) viewIsStale() bool {
+	targetDiffers := func(client *concourse.Client) bool {
+		return client == nil || client.GetTarget() != m.currentTarget
+	}
+	canceled := concourse.IsCanceled
+
+	switch m.currentView {
+	case ViewPipelines:
+		target, _ := m.configManager.GetTarget(m.currentTarget)
+		return targetDiffers(m.pipelinesView.client) || m.pipelinesView.team != target.Team || canceled(m.pipelinesView.err)
+	case ViewJobs:
+		return targetDiffers(m.jobsView.client) || m.jobsView.pipeline != m.currentPipeline || canceled(m.jobsView.err)
+	case ViewResources:
+		return targetDiffers(m.resourcesView.client) || m.resourcesView.pipeline != m.currentPipeline || canceled(m.resourcesView.err)
+	case ViewBuilds:
+		return targetDiffers(m.buildsView.client) || m.buildsView.pipeline != m.currentPipeline || m.buildsView.job != m.currentJob ||
+			canceled(m.buildsView.err)
+	case ViewWatchlist:
+		// The auto-refresh loop stops while the watchlist is hidden
+		return true
+	case ViewTeams:
+		return targetDiffers(m.teamsView.client) || canceled(m.teamsView.err)
+	case ViewActiveUsers:
+		return targetDiffers(m.activeUsersView.client) || canceled(m.activeUsersView.err)
+	case ViewGraph:
+		return targetDiffers(m.graphView.client) || m.graphView.pipeline != m.currentPipeline || canceled(m.graphView.err)
+	case ViewFlaky:
+		return targetDiffers(m.flakyView.client) || m.flakyView.pipeline != m.currentPipeline || m.flakyView.canceled()
+	case ViewBuildLog:
+		return targetDiffers(m.buildLogView.client) || m.buildLogView.stream == nil || m.buildLogView.pipeline != m.currentPipeline ||
+			m.buildLogView.job != m.currentJob || m.buildLogView.build != m.currentBuild
+	case ViewDashboard:
+		return targetDiffers(m.dashboardView.client) || m.dashboardView.canceled()
+	case ViewConfig:
+		return targetDiffers(m.configView.client) || m.configView.pipeline != m.currentPipeline || canceled(m.configView.err)
+	case ViewSearch:
+		return m.searchView.home != m.currentTarget || m.searchView.stale()
+	case ViewAggregate:
+		return m.aggregateView.canceled()
+	}
+	return false
+}
+
+// revalidateView reloads the current view in the background while it keeps
+// showing what it loaded on an earlier visit to the same context, or returns
+// nil when the view has nothing to show meanwhile or reloads another way
+func (m *Model) revalidateView() tea.Cmd {
+	if m.viewIsStale() {
+		return nil
+	}
+	switch m.currentView {
+	case ViewTargets:
+		// Checks those never checked or whose check was cut short
+		return m.targetsView.CheckHealth(false)
+	case ViewPipelines:
+		return m.pipelinesView.Revalidate()
+	case ViewJobs:
+		return m.jobsView.Revalidate()
+	case ViewResources:
+		return m.resourcesView.Revalidate()
+	case ViewBuilds:
+		return m.buildsView.Revalidate()
+	}
+	return nil
+}
+
+// capturesInput reports whether the current view is collecting free-form text,
+// in which case keys like q and esc belong to the view rather than the app
+func (m *Model) capturesInput() bool {
+	switch m.currentView {
+	case ViewTargets:
+		return m.targetsView.searchMode || m.targetsView.teamPicker != nil || m.targetsView.backupPicker != nil
+	case ViewPipelines:
+		return m.pipelinesView.searchMode
+	case ViewJobs:
+		return m.jobsView.searchMode || m.jobsView.links != nil
+	case ViewResources:
+		return m.resourcesView.searchMode
+	case ViewAddTarget:
+		return !m.addTargetView.saving
+	case ViewAuth:
+		return m.authView.credentials || m.authView.pasting
+	case ViewTeams:
+		return m.teamsView.form != nil || m.teamsView.destroying
+	case ViewActiveUsers:
+		return m.activeUsersView.searchMode
+	case ViewConfig:
+		// An edited config waits for y, n or esc
+		return m.configView.edit != nil
+	case ViewSearch:
+		// Typing edits the query
+		return true
+	case ViewAggregate:
+		return m.aggregateView.searchMode
+	case ViewBuildLog:
+		return m.buildLogView.search.typing || m.buildLogView.export != nil
+	}
+	return false
+}
+
+// interruptForAuth switches to the auth view when err is an authentication error,
+// remembering the interrupted view so a successful login resumes it
+func (m *Model) interruptForAuth(err error) bool {
+	if !concourse.IsAuthError(err) {
+		return false
+	}
+	return m.promptLogin()
+}
+
+// interruptForExpiredToken switches to the auth view when the current target's
+// token has expired, before any fly call fails because of it
+func (m *Model) interruptForExpiredToken() bool {
+	target, exists := m.configManager.GetTarget(m.currentTarget)
+	if !exists || !target.TokenExpired() {
+		return false
+	}
+	return m.promptLogin()
+}
+
+// promptLogin replaces the current view with the auth view for the current
+// target, resuming the view after a successful login
+func (m *Model) promptLogin() bool {
+	if m.currentTarget == "" {
+		return false
+	}
+
+	target, exists := m.configManager.GetTarget(m.currentTarget)
+	if !exists {
+		return false
+	}
+
+	resume := SwitchViewMsg{
+		View:     m.currentView,
+		Target:   m.currentTarget,
+		Pipeline: m.currentPipeline,
+		Job:      m.currentJob,
+		Build:    m.currentBuild,
+		Replace:  true,
+	}
+	m.authView.SetTarget(target, m.client, resume)
+
+	// The auth view takes the interrupted view's place, so esc returns to
+	// wherever the user was before attempting it
+	m.currentView = ViewAuth
+	return true
+}
+
+// interruptForSync switches to the sync prompt when err says fly is out of
+// sync with the target, remembering the interrupted view so it resumes once
+// fly has been synced
+func (m *Model) interruptForSync(err error) bool {
+	if !concourse.IsOutOfSyncError(err) || m.currentTarget == "" {
+		return false
+	}
+
+	resume := SwitchViewMsg{
+		View:     m.currentView,
+		Target:   m.currentTarget,
+		Pipeline: m.currentPipeline,
+		Job:      m.currentJob,
+		Build:    m.currentBuild,
+		Replace:  true,
+	}
+	m.syncView.SetTarget(m.currentTarget, m.client, err, resume)
+
+	// Like the auth view, the prompt takes the interrupted view's place
+	m.currentView = ViewSync
+	return true
+}
+
+// interruptFor switches to the auth or sync view when err calls for either
+func (m *Model) interruptFor(err error) bool {
+	return m.interruptForAuth(err) || m.interruptForSync(err)
+}
+
+// breadcrumbLevels returns the target › pipeline › job › build levels of the
+// current context
+func (m *Model) breadcrumbLevels() []navEntry {
+	if m.currentTarget == "" {
+		return nil
+	}
+
+	levels := []navEntry{{View: ViewPipelines, Target: m.currentTarget}}
+	if m.currentPipeline != "" && m.currentView != ViewPipelines && m.currentView != ViewSearch {
+		levels = append(levels, navEntry{View: ViewJobs, Target: m.currentTarget, Pipeline: m.currentPipeline})
+		if m.currentJob != "" && (m.currentView == ViewBuilds || m.currentView == ViewBuildLog) {
+			levels = append(levels, navEntry{View: ViewBuilds, Target: m.currentTarget, Pipeline: m.currentPipeline, Job: m.currentJob})
+		}
+		if m.currentJob != "" && m.currentBuild != "" && m.currentView == ViewBuildLog {
+			levels = append(levels, navEntry{View: ViewBuildLog, Target: m.currentTarget, Pipeline: m.currentPipeline, Job: m.currentJob, Build: m.currentBuild})
+		}
+	}
+	return levels
+}
+
+// jumpToLevel navigates up the hierarchy to the numbered breadcrumb level,
+// unwinding the navigation stack when that level was visited on the way here
+func (m *Model) jumpToLevel(level int) tea.Cmd {
+	levels := m.breadcrumbLevels()
+	if level < 1 || level > len(levels) {
+		return nil
+	}
+
+	entry := levels[level-1]
+	if entry.View == m.currentView {
+		return nil
+	}
+
+	for i := len(m.navStack) - 1; i >= 0; i-- {
+		visited := m.navStack[i]
+		if visited.View == entry.View && visited.Target == entry.Target &&
+			(entry.View == ViewPipelines || visited.Pipeline == entry.Pipeline) {
+			m.navStack = m.navStack[:i]
+			return m.restoreEntry(visited)
+		}
+	}
+
+	// We arrived here without passing through that level (e.g. from the watchlist)
+	return func() tea.Msg {
+		return SwitchViewMsg{View: entry.View, Target: entry.Target, Pipeline: entry.Pipeline, Job: entry.Job, Build: entry.Build}
+	}
+}
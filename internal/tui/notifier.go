@@ -0,0 +1,314 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"flyby/internal/concourse"
+	"flyby/internal/state"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// notifyPollInterval is how often followed builds and watched jobs are polled
+const notifyPollInterval = 15 * time.Second
+
+// followedBuildsCount is how many of a job's latest builds are searched for
+// a followed build
+const followedBuildsCount = 10
+
+// jobRef identifies a job across targets
+type jobRef struct {
+	Target   string
+	Pipeline string
+	Job      string
+}
+
+// followedBuild is a build that was triggered or rerun from FlyBy
+type followedBuild struct {
+	jobRef
+	Build string
+}
+
+// finishedBuild is a followed or watched build that finished since the last poll
+type finishedBuild struct {
+	followedBuild
+	Status  string
+	Watched bool // false when it was only triggered or rerun from FlyBy
+}
+
+// notifier reports builds finishing in the background: the builds triggered
+// or rerun from FlyBy, and every build of the watched jobs and pipelines
+type notifier struct {
+	enabled  bool
+	desktop  bool // send desktop notifications
+	bell     bool // ring the terminal bell for watched builds and failed followed ones
+	followed map[followedBuild]bool
+	latest   map[jobRef]string // latest finished build of each watched job
+	reported bool              // a failure to notify was already shown
+}
+
+// NotifyTickMsg triggers polling the followed builds and watched jobs
+type NotifyTickMsg struct{}
+
+// NotifyPollMsg carries the builds of the followed jobs and the jobs of the
+// watched pipelines. Jobs and pipelines that failed to load are left out.
+type NotifyPollMsg struct {
+	Builds map[jobRef][]concourse.Build
+	Jobs   map[jobRef][]concourse.Job // by pipeline, Job is empty
+}
+
+// NotificationSentMsg reports whether a desktop notification could be sent
+type NotificationSentMsg struct {
+	Error error
+}
+
+// newNotifier creates a notifier, which stays idle unless it sends desktop
+// notifications, rings the bell or there is a hook for finished builds
+func newNotifier(desktop, bell, hook bool) notifier {
+	return notifier{
+		enabled:  desktop || bell || hook,
+		desktop:  desktop,
+		bell:     bell,
+		followed: make(map[followedBuild]bool),
+	}
+}
+
+// startedBuildPattern matches the build name fly prints after triggering or
+// rerunning a build, e.g. "started web-app/deploy #12"
+var startedBuildPattern = regexp.MustCompile(`#(\S+)\s*$`)
+
+// startedBuild returns the name of the build fly reported as started in
+// output, or "" when there is none
+func startedBuild(output string) string {
+	match := startedBuildPattern.FindStringSubmatch(strings.TrimSpace(output))
+	if match == nil {
+		return ""
+	}
+	return match[1]
+}
+
+// follow starts following the build fly reported as started in output
+func (n *notifier) follow(job jobRef, output string) {
+	if !n.enabled {
+		return
+	}
+	if build := startedBuild(output); build != "" {
+		n.followed[followedBuild{jobRef: job, Build: build}] = true
+	}
+}
+
+// schedule schedules the next poll
+func (n notifier) schedule() tea.Cmd {
+	if !n.enabled {
+		return nil
+	}
+	return tea.Tick(notifyPollInterval, func(time.Time) tea.Msg {
+		return NotifyTickMsg{}
+	})
+}
+
+// poll loads the builds of the followed jobs and the jobs of the watched
+// pipelines
+func (n notifier) poll(items []state.WatchItem) tea.Cmd {
+	jobs := make(map[jobRef]bool)
+	for build := range n.followed {
+		jobs[build.jobRef] = true
+	}
+	pipelines := make(map[jobRef]bool)
+	for _, item := range items {
+		pipelines[jobRef{Target: item.Target, Pipeline: item.Pipeline}] = true
+	}
+
+	return func() tea.Msg {
+		msg := NotifyPollMsg{
+			Builds: make(map[jobRef][]concourse.Build),
+			Jobs:   make(map[jobRef][]concourse.Job),
+		}
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		for job := range jobs {
+			wg.Add(1)
+			go func(job jobRef) {
+				defer wg.Done()
+				builds, err := concourse.NewClient(job.Target).GetBuilds(job.Pipeline, job.Job, followedBuildsCount)
+				if err != nil {
+					return
+				}
+				mu.Lock()
+				defer mu.Unlock()
+				msg.Builds[job] = builds
+			}(job)
+		}
+		for pipeline := range pipelines {
+			wg.Add(1)
+			go func(pipeline jobRef) {
+				defer wg.Done()
+				pipelineJobs, err := concourse.NewClient(pipeline.Target).GetJobs(pipeline.Pipeline)
+				if err != nil {
+					return
+				}
+				mu.Lock()
+				defer mu.Unlock()
+				msg.Jobs[pipeline] = pipelineJobs
+			}(pipeline)
+		}
+		wg.Wait()
+		return msg
+	}
+}
+
+// finished returns the followed and watched builds that finished since the
+// last poll. The first poll of a watched job only notes its latest build.
+func (n *notifier) finished(msg NotifyPollMsg, items []state.WatchItem) []finishedBuild {
+	var finished []finishedBuild
+	seen := make(map[followedBuild]int) // index into finished
+
+	for build := range n.followed {
+		builds, ok := msg.Builds[build.jobRef]
+		if !ok {
+			continue
+		}
+		found := false
+		for _, b := range builds {
+			if b.Name != build.Build {
+				continue
+			}
+			found = true
+			if buildFinished(b.Status) {
+				seen[build] = len(finished)
+				finished = append(finished, finishedBuild{followedBuild: build, Status: b.Status})
+				delete(n.followed, build)
+			}
+		}
+		if !found {
+			// Too many builds ran since, or the job was renamed or removed
+			delete(n.followed, build)
+		}
+	}
+
+	latest := make(map[jobRef]string)
+	for _, item := range items {
+		pipeline := jobRef{Target: item.Target, Pipeline: item.Pipeline}
+		jobs, ok := msg.Jobs[pipeline]
+		if !ok {
+			// Keep what was known until the pipeline loads again
+			for job, build := range n.latest {
+				if job.Target == item.Target && job.Pipeline == item.Pipeline && (item.Job == "" || job.Job == item.Job) {
+					latest[job] = build
+				}
+			}
+			continue
+		}
+		for _, job := range jobs {
+			if item.Job != "" && job.Name != item.Job {
+				continue
+			}
+			ref := jobRef{Target: item.Target, Pipeline: item.Pipeline, Job: job.Name}
+			build := followedBuild{jobRef: ref, Build: job.FinishedBuild.Name}
+			previous, known := n.latest[ref]
+			if i, ok := seen[build]; ok {
+				finished[i].Watched = true
+			} else if known && build.Build != "" && build.Build != previous {
+				seen[build] = len(finished)
+				finished = append(finished, finishedBuild{followedBuild: build, Status: job.FinishedBuild.Status, Watched: true})
+			}
+			latest[ref] = build.Build
+		}
+	}
+	n.latest = latest
+
+	return finished
+}
+
+// buildFinished returns true for the statuses of builds that are over
+func buildFinished(status string) bool {
+	switch status {
+	case "succeeded", "failed", "errored", "aborted":
+		return true
+	}
+	return false
+}
+
+// report notifies of the builds that finished since the last poll
+func (n notifier) report(finished []finishedBuild) tea.Cmd {
+	var cmds []tea.Cmd
+	ring := false
+	for _, build := range finished {
+		if n.desktop {
+			cmds = append(cmds, notifyFinished(build))
+		}
+		if build.Watched || build.Status == "failed" || build.Status == "errored" {
+			ring = n.bell
+		}
+	}
+	if ring {
+		// Once for the whole poll, terminals and tmux only flag the bell anyway
+		cmds = append(cmds, ringBell)
+	}
+	return tea.Batch(cmds...)
+}
+
+// ringBell rings the terminal bell, which tmux and most terminals also flag
+// on background windows
+func ringBell() tea.Msg {
+	os.Stdout.WriteString("\a")
+	return nil
+}
+
+// notifyFinished sends a desktop notification for a finished build
+func notifyFinished(build finishedBuild) tea.Cmd {
+	title := fmt.Sprintf("%s/%s #%s %s", build.Pipeline, build.Job, build.Build, build.Status)
+	body := fmt.Sprintf("Build %s on %s", build.Status, build.Target)
+	return func() tea.Msg {
+		return NotificationSentMsg{Error: sendNotification(title, body)}
+	}
+}
+
+// sendNotification shows a desktop notification with the platform's tool
+func sendNotification(title, body string) error {
+	var name string
+	var args []string
+	switch runtime.GOOS {
+	case "darwin":
+		name = "osascript"
+		args = []string{"-e", fmt.Sprintf("display notification %s with title %s", appleScriptString(body), appleScriptString(title))}
+	case "windows":
+		name = "powershell"
+		args = []string{"-NoProfile", "-Command", fmt.Sprintf(
+			"Add-Type -AssemblyName System.Windows.Forms; "+
+				"$n = New-Object System.Windows.Forms.NotifyIcon; "+
+				"$n.Icon = [System.Drawing.SystemIcons]::Information; $n.Visible = $true; "+
+				"$n.ShowBalloonTip(10000, %s, %s, 'Info'); Start-Sleep -Seconds 10; $n.Dispose()",
+			powerShellString(title), powerShellString(body))}
+	default:
+		name = "notify-send"
+		args = []string{"--app-name", "FlyBy", title, body}
+	}
+
+	if _, err := exec.LookPath(name); err != nil {
+		return fmt.Errorf("%s isn't installed", name)
+	}
+	if runtime.GOOS == "windows" {
+		// The balloon only shows while powershell runs
+		return exec.Command(name, args...).Start()
+	}
+	return exec.Command(name, args...).Run()
+}
+
+// appleScriptString quotes s as an AppleScript string literal
+func appleScriptString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
+
+// powerShellString quotes s as a PowerShell single-quoted string literal
+func powerShellString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
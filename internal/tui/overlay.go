@@ -0,0 +1,41 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// placeOverlay draws fg on top of bg with its top-left corner at column x,
+// row y. Background text to the right of the overlay loses its styling.
+func placeOverlay(bg, fg string, x, y int) string {
+	lines := strings.Split(bg, "\n")
+	for i, fgLine := range strings.Split(fg, "\n") {
+		row := y + i
+		if row < 0 || row >= len(lines) {
+			continue
+		}
+
+		line := lines[row]
+		if pad := x - ansi.StringWidth(line); pad > 0 {
+			line += strings.Repeat(" ", pad)
+		}
+		// Reset any style left open by the cut so it doesn't bleed into the overlay
+		lines[row] = ansi.Truncate(line, x, "") + "\x1b[0m" + fgLine + skipCells(lines[row], x+lipgloss.Width(fgLine))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// skipCells returns the unstyled text of s after its first n cells
+func skipCells(s string, n int) string {
+	plain := ansi.Strip(s)
+	width := 0
+	for i, r := range plain {
+		if width >= n {
+			return plain[i:]
+		}
+		width += ansi.StringWidth(string(r))
+	}
+	return ""
+}
@@ -0,0 +1,37 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// openPager suspends FlyBy and shows text in $PAGER, or else less, which
+// copes with huge outputs far better than a view does
+func openPager(text string) tea.Cmd {
+	// The variable may hold arguments too, e.g. "less -S"
+	args := strings.Fields(os.Getenv("PAGER"))
+	if len(args) == 0 {
+		// -R keeps the colors of build logs
+		args = []string{"less", "-R"}
+		if runtime.GOOS == "windows" {
+			args = []string{"more"}
+		}
+	}
+	if _, err := exec.LookPath(args[0]); err != nil {
+		return showToast(ToastError, "%s isn't installed, set $PAGER to another pager", args[0])
+	}
+
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin = strings.NewReader(text)
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		if err != nil {
+			return ToastMsg{Level: ToastError, Text: fmt.Sprintf("Failed to run %s: %v", args[0], err)}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,72 @@
+package tui
+
+import (
+	"strings"
+
+	"flyby/internal/state"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// splitMinWidth is the narrowest terminal the detail pane is shown beside the
+// list in, narrower ones show it below the list
+const splitMinWidth = 100
+
+// The detail pane grows and shrinks by paneRatioStep percent of the width,
+// between minPaneRatio and maxPaneRatio
+const (
+	paneRatioStep = 10
+	minPaneRatio  = 20
+	maxPaneRatio  = 70
+)
+
+// paneWidth returns the width of the detail pane beside the list, or 0 when
+// it is collapsed or the terminal too narrow to split
+func paneWidth(pane state.Pane, width int) int {
+	if pane.Collapsed || width < splitMinWidth {
+		return 0
+	}
+	return width * pane.Ratio / 100
+}
+
+// resizePane grows, shrinks or collapses the detail pane for the pressed key
+// and remembers its size in the state file. Resizing a collapsed pane shows
+// it again.
+func resizePane(msg tea.KeyMsg, pane *state.Pane, stateStore *state.Store) error {
+	switch {
+	case keys.Matches(msg, actionGrowPane):
+		pane.Ratio = min(pane.Ratio+paneRatioStep, maxPaneRatio)
+		pane.Collapsed = false
+	case keys.Matches(msg, actionShrinkPane):
+		pane.Ratio = max(pane.Ratio-paneRatioStep, minPaneRatio)
+		pane.Collapsed = false
+	case keys.Matches(msg, actionTogglePane):
+		pane.Collapsed = !pane.Collapsed
+	}
+	if stateStore == nil {
+		return nil
+	}
+	return stateStore.SetPane(*pane)
+}
+
+// renderSplit renders the list with the detail pane of the given width beside
+// it, cutting the lines of the list to the room left
+func renderSplit(list, detail string, width, paneWidth int) string {
+	listWidth := width - paneWidth
+	lines := strings.Split(strings.TrimSuffix(list, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = ansi.Truncate(strings.TrimRight(line, " "), listWidth-1, "…")
+	}
+
+	paneStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Muted).
+		Padding(0, 1).
+		Width(paneWidth - 2)
+
+	return lipgloss.JoinHorizontal(lipgloss.Top,
+		lipgloss.NewStyle().Width(listWidth).Render(strings.Join(lines, "\n")),
+		paneStyle.Render(detail)) + "\n"
+}
@@ -0,0 +1,348 @@
+package tui
+
+import (
+	"sort"
+
+	"flyby/internal/concourse"
+)
+
+// Pipeline graphs are drawn left to right: jobs sit in columns by how many
+// jobs come before them, and passed constraints run through the gutters
+// between columns as box-drawing lines.
+const (
+	graphGutter  = 5 // width of the space between two columns
+	graphRowStep = 2 // lines from one row of jobs to the next
+)
+
+// Directions a line leaves a gutter cell in
+const (
+	lineLeft = 1 << iota
+	lineRight
+	lineUp
+	lineDown
+)
+
+// graphNode is a job placed on the graph, or a point an edge passes through
+// when it spans more than one column
+type graphNode struct {
+	job    string // empty for edge points
+	column int
+	row    int
+	inputs []int // nodes in the previous column feeding this one
+}
+
+// graphCell is one character of a drawn graph. Cells of a job's marker and
+// name carry the job, so the view can color them.
+type graphCell struct {
+	char rune
+	job  string
+}
+
+// pipelineGraph lays out the jobs of a pipeline config
+type pipelineGraph struct {
+	nodes   []graphNode
+	columns [][]int // node indices per column, top to bottom
+	widths  []int   // width of each column's labels
+}
+
+// newPipelineGraph lays out the jobs of a pipeline config, each placed right
+// of the jobs its inputs must pass first
+func newPipelineGraph(config concourse.PipelineConfig) pipelineGraph {
+	var g pipelineGraph
+	if len(config.Jobs) == 0 {
+		return g
+	}
+
+	upstream := make(map[string][]string)
+	for _, job := range config.Jobs {
+		upstream[job.Name] = job.Upstream()
+	}
+
+	// Columns by longest path from a job without passed constraints. Jobs in
+	// a cycle, which Concourse refuses but a broken config may still have,
+	// stop the walk where it comes back around.
+	column := make(map[string]int)
+	visiting := make(map[string]bool)
+	var place func(job string) int
+	place = func(job string) int {
+		if c, ok := column[job]; ok {
+			return c
+		}
+		if visiting[job] {
+			return 0
+		}
+		visiting[job] = true
+		c := 0
+		for _, up := range upstream[job] {
+			if _, exists := upstream[up]; exists {
+				c = max(c, place(up)+1)
+			}
+		}
+		visiting[job] = false
+		column[job] = c
+		return c
+	}
+
+	index := make(map[string]int)
+	for _, job := range config.Jobs {
+		c := place(job.Name)
+		for len(g.columns) <= c {
+			g.columns = append(g.columns, nil)
+		}
+		index[job.Name] = len(g.nodes)
+		g.columns[c] = append(g.columns[c], len(g.nodes))
+		g.nodes = append(g.nodes, graphNode{job: job.Name, column: c})
+	}
+
+	// Connect each job to its upstream jobs, passing edges that skip columns
+	// through points in the columns between
+	for _, job := range config.Jobs {
+		to := index[job.Name]
+		for _, up := range upstream[job.Name] {
+			from, exists := index[up]
+			if !exists || g.nodes[from].column >= g.nodes[to].column {
+				continue
+			}
+			for c := g.nodes[from].column + 1; c < g.nodes[to].column; c++ {
+				g.columns[c] = append(g.columns[c], len(g.nodes))
+				g.nodes = append(g.nodes, graphNode{column: c, inputs: []int{from}})
+				from = len(g.nodes) - 1
+			}
+			g.nodes[to].inputs = append(g.nodes[to].inputs, from)
+		}
+	}
+
+	g.order()
+	g.widths = make([]int, len(g.columns))
+	for _, node := range g.nodes {
+		if node.job != "" {
+			g.widths[node.column] = max(g.widths[node.column], len([]rune(node.job))+2)
+		}
+	}
+	return g
+}
+
+// order sorts each column by the average row of the nodes connected to it in
+// the previous column, then the next one, which untangles most crossings
+func (g *pipelineGraph) order() {
+	g.assignRows()
+	outputs := make([][]int, len(g.nodes))
+	for i, node := range g.nodes {
+		for _, input := range node.inputs {
+			outputs[input] = append(outputs[input], i)
+		}
+	}
+
+	sortColumn := func(c int, neighbours func(int) []int) {
+		position := make(map[int]float64)
+		for _, i := range g.columns[c] {
+			linked := neighbours(i)
+			if len(linked) == 0 {
+				position[i] = float64(g.nodes[i].row)
+				continue
+			}
+			sum := 0
+			for _, n := range linked {
+				sum += g.nodes[n].row
+			}
+			position[i] = float64(sum) / float64(len(linked))
+		}
+		sort.SliceStable(g.columns[c], func(a, b int) bool {
+			return position[g.columns[c][a]] < position[g.columns[c][b]]
+		})
+		for row, i := range g.columns[c] {
+			g.nodes[i].row = row
+		}
+	}
+
+	for pass := 0; pass < 4; pass++ {
+		for c := 1; c < len(g.columns); c++ {
+			sortColumn(c, func(i int) []int { return g.nodes[i].inputs })
+		}
+		for c := len(g.columns) - 2; c >= 0; c-- {
+			sortColumn(c, func(i int) []int { return outputs[i] })
+		}
+	}
+}
+
+// assignRows numbers the nodes of each column from the top
+func (g *pipelineGraph) assignRows() {
+	for _, column := range g.columns {
+		for row, i := range column {
+			g.nodes[i].row = row
+		}
+	}
+}
+
+// jobs returns the jobs in the order they appear on the graph: column by
+// column, top to bottom
+func (g pipelineGraph) jobs() []graphNode {
+	var jobs []graphNode
+	for _, column := range g.columns {
+		for _, i := range column {
+			if g.nodes[i].job != "" {
+				jobs = append(jobs, g.nodes[i])
+			}
+		}
+	}
+	return jobs
+}
+
+// columnX returns where a column starts on the drawn graph
+func (g pipelineGraph) columnX(column int) int {
+	x := 0
+	for c := 0; c < column; c++ {
+		x += g.widths[c] + graphGutter
+	}
+	return x
+}
+
+// draw renders the graph into a grid of cells, using ASCII lines when ascii
+// is set as box-drawing glyphs would not line up once replaced
+func (g pipelineGraph) draw(ascii bool) [][]graphCell {
+	if len(g.columns) == 0 {
+		return nil
+	}
+	rows := 0
+	for _, column := range g.columns {
+		rows = max(rows, len(column))
+	}
+	width := g.columnX(len(g.columns)-1) + g.widths[len(g.columns)-1]
+	height := (rows-1)*graphRowStep + 1
+
+	grid := make([][]graphCell, height)
+	for y := range grid {
+		grid[y] = make([]graphCell, width)
+		for x := range grid[y] {
+			grid[y][x] = graphCell{char: ' '}
+		}
+	}
+
+	hasOutputs := make([]bool, len(g.nodes))
+	for _, node := range g.nodes {
+		for _, input := range node.inputs {
+			hasOutputs[input] = true
+		}
+	}
+
+	horizontal, marker := '─', '●'
+	if ascii {
+		horizontal, marker = '-', '*'
+	}
+
+	// Jobs and the points edges pass through
+	for i, node := range g.nodes {
+		x, y := g.columnX(node.column), node.row*graphRowStep
+		label := []rune(nil)
+		if node.job != "" {
+			label = append([]rune{marker, ' '}, []rune(node.job)...)
+		}
+		for dx := 0; dx < g.widths[node.column]; dx++ {
+			cell := graphCell{char: ' '}
+			switch {
+			case dx < len(label):
+				cell = graphCell{char: label[dx], job: node.job}
+			case hasOutputs[i] || node.job == "":
+				cell.char = horizontal
+			}
+			grid[y][x+dx] = cell
+		}
+	}
+
+	// Edges, in the gutter left of the node they lead to
+	for c := 1; c < len(g.columns); c++ {
+		lines := make([][]int, height)
+		for y := range lines {
+			lines[y] = make([]int, graphGutter)
+		}
+		arrows := make(map[int]bool)
+		turn := graphGutter / 2
+		for _, i := range g.columns[c] {
+			to := g.nodes[i].row * graphRowStep
+			for _, input := range g.nodes[i].inputs {
+				from := g.nodes[input].row * graphRowStep
+				for x := 0; x <= turn; x++ {
+					lines[from][x] |= lineLeft
+					if x < turn {
+						lines[from][x] |= lineRight
+					}
+				}
+				for y := min(from, to); y <= max(from, to); y++ {
+					if y > min(from, to) {
+						lines[y][turn] |= lineUp
+					}
+					if y < max(from, to) {
+						lines[y][turn] |= lineDown
+					}
+				}
+				for x := turn; x < graphGutter; x++ {
+					lines[to][x] |= lineRight
+					if x > turn {
+						lines[to][x] |= lineLeft
+					}
+				}
+			}
+			if g.nodes[i].job != "" && len(g.nodes[i].inputs) > 0 {
+				arrows[to] = true
+			}
+		}
+
+		left := g.columnX(c) - graphGutter
+		for y := range lines {
+			for x, directions := range lines[y] {
+				if directions == 0 {
+					continue
+				}
+				char := lineGlyph(directions, ascii)
+				if x == graphGutter-1 && arrows[y] {
+					char = '▶'
+					if ascii {
+						char = '>'
+					}
+				}
+				grid[y][left+x] = graphCell{char: char}
+			}
+		}
+	}
+	return grid
+}
+
+// lineGlyph returns the glyph joining a gutter cell's lines
+func lineGlyph(directions int, ascii bool) rune {
+	horizontal := directions&(lineLeft|lineRight) != 0
+	vertical := directions&(lineUp|lineDown) != 0
+	if ascii {
+		switch {
+		case horizontal && vertical:
+			return '+'
+		case vertical:
+			return '|'
+		default:
+			return '-'
+		}
+	}
+	switch directions {
+	case lineRight | lineDown:
+		return '┌'
+	case lineLeft | lineDown:
+		return '┐'
+	case lineRight | lineUp:
+		return '└'
+	case lineLeft | lineUp:
+		return '┘'
+	case lineUp | lineDown | lineRight:
+		return '├'
+	case lineUp | lineDown | lineLeft:
+		return '┤'
+	case lineLeft | lineRight | lineDown:
+		return '┬'
+	case lineLeft | lineRight | lineUp:
+		return '┴'
+	case lineLeft | lineRight | lineUp | lineDown:
+		return '┼'
+	}
+	if vertical {
+		return '│'
+	}
+	return '─'
+}
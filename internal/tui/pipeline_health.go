@@ -0,0 +1,190 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"flyby/internal/concourse"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// pipelineHealthTTL is how long a pipeline's job summary is reused before
+// loading it again
+const pipelineHealthTTL = time.Minute
+
+// pipelineHealthConcurrency is how many job summaries load at once while
+// only pipelines with failing jobs are shown
+const pipelineHealthConcurrency = 4
+
+// pipelineHealth sums up the latest builds of a pipeline's jobs
+type pipelineHealth struct {
+	succeeded int
+	failed    int // failed or errored
+	aborted   int
+	running   int // jobs with a build in progress, whatever their last one did
+	neverRun  int
+	loading   bool
+	err       error
+	checked   time.Time
+}
+
+// PipelineHealthMsg carries the jobs of a pipeline to sum up
+type PipelineHealthMsg struct {
+	Key   string
+	Jobs  []concourse.Job
+	Error error
+}
+
+// newPipelineHealth counts the jobs of a pipeline by their latest build
+func newPipelineHealth(jobs []concourse.Job) pipelineHealth {
+	health := pipelineHealth{checked: time.Now()}
+	for _, job := range jobs {
+		if job.NextBuild.ID != 0 {
+			health.running++
+			continue
+		}
+		switch job.FinishedBuild.Status {
+		case "succeeded":
+			health.succeeded++
+		case "failed", "errored":
+			health.failed++
+		case "aborted":
+			health.aborted++
+		default:
+			health.neverRun++
+		}
+	}
+	return health
+}
+
+// healthKey identifies a pipeline across teams
+func healthKey(pipeline concourse.Pipeline) string {
+	return pipeline.TeamName + "/" + pipeline.Ref()
+}
+
+// LoadHealth loads the job summary of the selected pipeline, or of every
+// pipeline while only those with failing jobs are shown, unless a fresh one is
+// at hand. Pipelines of other teams are skipped, fly only lists the jobs of
+// the target's team.
+func (m *PipelinesViewModel) LoadHealth() tea.Cmd {
+	if m.client == nil || m.state == pipelinesStateLoading {
+		return nil
+	}
+	if m.filter == pipelineFilterFailing {
+		semaphore := make(chan struct{}, pipelineHealthConcurrency)
+		var cmds []tea.Cmd
+		for _, pipeline := range m.pipelines {
+			if m.listable(pipeline) {
+				cmds = append(cmds, m.loadHealth(pipeline, semaphore))
+			}
+		}
+		return tea.Batch(cmds...)
+	}
+	if m.selected >= len(m.filteredPipelines) || m.otherTeam() != nil {
+		return nil
+	}
+	return m.loadHealth(m.filteredPipelines[m.selected], nil)
+}
+
+// loadHealth loads the job summary of a pipeline unless a fresh one is at
+// hand, waiting for room in semaphore first unless it is nil
+func (m *PipelinesViewModel) loadHealth(pipeline concourse.Pipeline, semaphore chan struct{}) tea.Cmd {
+	key := healthKey(pipeline)
+	if health, ok := m.health[key]; ok && (health.loading || time.Since(health.checked) < pipelineHealthTTL) {
+		return nil
+	}
+	if m.health == nil {
+		m.health = make(map[string]pipelineHealth)
+	}
+	// Keep showing the previous summary until the new one arrives
+	health := m.health[key]
+	health.loading = true
+	m.health[key] = health
+
+	client := m.client
+	return func() tea.Msg {
+		if semaphore != nil {
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+		}
+		jobs, err := client.GetJobs(pipeline.Ref())
+		return PipelineHealthMsg{Key: key, Jobs: jobs, Error: err}
+	}
+}
+
+// HandlePipelineHealth stores the job summary of a pipeline
+func (m PipelinesViewModel) HandlePipelineHealth(msg PipelineHealthMsg) PipelinesViewModel {
+	if _, ok := m.health[msg.Key]; !ok {
+		// The list was reloaded meanwhile
+		return m
+	}
+	if concourse.IsCanceled(msg.Error) {
+		// Loaded again once the pipelines show
+		delete(m.health, msg.Key)
+		return m
+	}
+	health := newPipelineHealth(msg.Jobs)
+	health.err = msg.Error
+	m.health[msg.Key] = health
+	if m.filter == pipelineFilterFailing {
+		m.refilter()
+	}
+	return m
+}
+
+// failing returns true once a pipeline's job summary shows failed jobs
+func (m PipelinesViewModel) failing(pipeline concourse.Pipeline) bool {
+	health, ok := m.health[healthKey(pipeline)]
+	return ok && health.failed > 0
+}
+
+// checking returns how many listed pipelines' job summaries are still
+// loading while only those with failing jobs are shown
+func (m PipelinesViewModel) checking() int {
+	count := 0
+	for _, pipeline := range m.pipelines {
+		if health, ok := m.health[healthKey(pipeline)]; m.listable(pipeline) && (!ok || health.checked.IsZero()) {
+			count++
+		}
+	}
+	return count
+}
+
+// renderHealth renders the job summary of a pipeline for its info box
+func (m PipelinesViewModel) renderHealth(pipeline concourse.Pipeline) string {
+	mutedStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+	health, ok := m.health[healthKey(pipeline)]
+	switch {
+	case !ok:
+		return ""
+	case health.checked.IsZero():
+		return "Jobs: " + mutedStyle.Render("loading...")
+	case health.err != nil:
+		return "Jobs: " + lipgloss.NewStyle().Foreground(theme.Error).Render("failed to load: "+errorSummary(health.err))
+	}
+
+	counts := []struct {
+		count int
+		label string
+		color lipgloss.TerminalColor
+	}{
+		{health.succeeded, "succeeded", theme.Success},
+		{health.failed, "failed", theme.Error},
+		{health.running, "running", theme.Info},
+		{health.aborted, "aborted", theme.Warning},
+		{health.neverRun, "never run", theme.Muted},
+	}
+	var parts []string
+	for _, c := range counts {
+		if c.count > 0 {
+			parts = append(parts, lipgloss.NewStyle().Foreground(c.color).Render(fmt.Sprintf("● %d %s", c.count, c.label)))
+		}
+	}
+	if len(parts) == 0 {
+		return "Jobs: " + mutedStyle.Render("none")
+	}
+	return "Jobs: " + strings.Join(parts, "  ")
+}
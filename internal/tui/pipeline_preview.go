@@ -0,0 +1,107 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"flyby/internal/concourse"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// configPreviewLines is how many lines of the selected pipeline's config the
+// detail pane shows
+const configPreviewLines = 15
+
+// configPreviewTTL is how long a pipeline's config is reused before loading
+// it again
+const configPreviewTTL = time.Minute
+
+// configPreview holds the config of a pipeline for the detail pane
+type configPreview struct {
+	lines   []string
+	loading bool
+	err     error
+	fetched time.Time
+}
+
+// ConfigPreviewMsg carries the config of a pipeline to preview
+type ConfigPreviewMsg struct {
+	Key    string
+	Config string
+	Error  error
+}
+
+// LoadPreview loads the config of the selected pipeline while the detail pane
+// shows beside the list, unless a fresh one is at hand. Pipelines of other
+// teams are skipped, fly only gets the pipelines of the target's team.
+func (m *PipelinesViewModel) LoadPreview() tea.Cmd {
+	if m.client == nil || m.state == pipelinesStateLoading || paneWidth(m.pane, m.width) == 0 ||
+		m.selected >= len(m.filteredPipelines) || m.otherTeam() != nil {
+		return nil
+	}
+	pipeline := m.filteredPipelines[m.selected]
+	key := healthKey(pipeline)
+	if preview, ok := m.previews[key]; ok && (preview.loading || time.Since(preview.fetched) < configPreviewTTL) {
+		return nil
+	}
+	if m.previews == nil {
+		m.previews = make(map[string]configPreview)
+	}
+	// Keep showing the previous config until the new one arrives
+	preview := m.previews[key]
+	preview.loading = true
+	m.previews[key] = preview
+
+	client := m.client
+	return func() tea.Msg {
+		config, err := client.GetPipelineYAML(pipeline.Ref())
+		return ConfigPreviewMsg{Key: key, Config: config, Error: err}
+	}
+}
+
+// HandleConfigPreview stores the config of a pipeline
+func (m PipelinesViewModel) HandleConfigPreview(msg ConfigPreviewMsg) PipelinesViewModel {
+	if _, ok := m.previews[msg.Key]; !ok {
+		// The list was reloaded meanwhile
+		return m
+	}
+	if concourse.IsCanceled(msg.Error) {
+		// Loaded again once the pipelines show
+		delete(m.previews, msg.Key)
+		return m
+	}
+	m.previews[msg.Key] = configPreview{
+		lines:   strings.Split(strings.TrimRight(msg.Config, "\n"), "\n"),
+		err:     msg.Error,
+		fetched: time.Now(),
+	}
+	return m
+}
+
+// renderPreview renders the first lines of a pipeline's config for the
+// detail pane, each cut to width
+func (m PipelinesViewModel) renderPreview(pipeline concourse.Pipeline, width int) string {
+	mutedStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+	preview, ok := m.previews[healthKey(pipeline)]
+	switch {
+	case !ok:
+		return ""
+	case preview.fetched.IsZero():
+		return "Config: " + mutedStyle.Render("loading...")
+	case preview.err != nil:
+		return "Config: " + lipgloss.NewStyle().Foreground(theme.Error).Render("failed to load: "+errorSummary(preview.err))
+	}
+
+	lines := []string{"Config:"}
+	for _, line := range preview.lines[:min(configPreviewLines, len(preview.lines))] {
+		lines = append(lines, mutedStyle.Render(ansi.Truncate(line, width, "…")))
+	}
+	if more := len(preview.lines) - configPreviewLines; more > 0 {
+		lines = append(lines, fmt.Sprintf("… %d more lines, %s for the full config", more, keys.Label(actionConfig)))
+	}
+	return strings.Join(lines, "\n")
+}
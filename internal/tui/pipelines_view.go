@@ -2,9 +2,11 @@ package tui
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"flyby/internal/concourse"
+	"flyby/internal/state"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -17,29 +19,66 @@ const (
 	pipelinesStateList
 )
 
+// pipelineFilter narrows the pipelines list down on top of the search query
+type pipelineFilter int
+
+const (
+	pipelineFilterNone pipelineFilter = iota
+	pipelineFilterPaused
+	pipelineFilterFailing // pipelines whose job summary shows failed jobs
+)
+
+// pipelineFilterLabels describes each filter for the view
+var pipelineFilterLabels = map[pipelineFilter]string{
+	pipelineFilterPaused:  "paused only",
+	pipelineFilterFailing: "with failing jobs only",
+}
+
 // PipelinesViewModel represents the pipelines view
 type PipelinesViewModel struct {
 	client          *concourse.Client
+	stateStore      *state.Store
 	pipelines       []concourse.Pipeline
 	filteredPipelines []concourse.Pipeline
 	selected        int
 	state           pipelinesState
+	refreshing      bool // showing the pipelines of an earlier visit while they reload
+	selectNext      string // pipeline to select once the pipelines load, e.g. from a restored session
 	err             error
 	scrollOffset    int
 	maxVisible      int
 	searchQuery     string
 	searchMode      bool
+	favoriteErr     error
+	clicks          clickTracker
+	loader          loader
+	allTeams        bool   // list the pipelines of every team, not just the target's
+	showArchived    bool   // list archived pipelines too
+	filter          pipelineFilter
+	team            string // the team the target is logged in to
+	health          map[string]pipelineHealth // job summaries by healthKey
+	pane            state.Pane // size of the selected pipeline's detail pane
+	width           int        // of the terminal, for whether the pane shows beside the list
+	previews        map[string]configPreview // configs by healthKey
 }
 
 // NewPipelinesViewModel creates a new pipelines view model
-func NewPipelinesViewModel() PipelinesViewModel {
+func NewPipelinesViewModel(stateStore *state.Store, showArchived bool) PipelinesViewModel {
+	pane := state.Pane{Ratio: state.DefaultPaneRatio}
+	if stateStore != nil {
+		pane = stateStore.GetPane()
+	}
 	return PipelinesViewModel{
+		pane:         pane,
+		stateStore:   stateStore,
+		showArchived: showArchived,
 		selected:     0,
 		state:        pipelinesStateList,
 		scrollOffset: 0,
 		maxVisible:   10,
 		searchQuery:  "",
 		searchMode:   false,
+		loader:       newLoader(),
 	}
 }
 
@@ -49,32 +88,110 @@ type PipelinesLoadedMsg struct {
 	Error     error
 }
 
+// PipelineDestroyedMsg represents the result of destroying a pipeline
+type PipelineDestroyedMsg struct {
+	Pipeline string
+	Error    error
+}
+
 // LoadPipelines loads pipelines from Concourse
 func (m *PipelinesViewModel) LoadPipelines(client *concourse.Client) tea.Cmd {
 	m.client = client
 	m.state = pipelinesStateLoading
+	m.refreshing = false
+	m.health = nil
+	m.previews = nil
+	return m.fetchPipelines()
+}
+
+// Revalidate reloads the pipelines while still showing the ones loaded on an
+// earlier visit, or returns nil when there are none to show
+func (m *PipelinesViewModel) Revalidate() tea.Cmd {
+	if m.client == nil || m.state == pipelinesStateLoading || m.err != nil {
+		return nil
+	}
+	// Job summaries reload on their own once they expire
+	m.refreshing = true
+	return m.fetchPipelines()
+}
+
+// fetchPipelines fetches the pipelines of the client's target
+func (m PipelinesViewModel) fetchPipelines() tea.Cmd {
+	client, allTeams, showArchived := m.client, m.allTeams, m.showArchived
 	return func() tea.Msg {
-		pipelines, err := client.GetPipelines()
+		var pipelines []concourse.Pipeline
+		var err error
+		if allTeams {
+			pipelines, err = client.GetPipelinesAllTeams(showArchived)
+		} else {
+			pipelines, err = client.GetPipelines(showArchived)
+		}
 		return PipelinesLoadedMsg{Pipelines: pipelines, Error: err}
 	}
 }
 
-// filterPipelines filters pipelines based on the current search query
+// otherTeam returns a toast explaining why the selected pipeline can't be
+// acted on when it belongs to another team than the target's, or nil
+func (m PipelinesViewModel) otherTeam() tea.Cmd {
+	pipeline := m.filteredPipelines[m.selected]
+	if !m.allTeams || pipeline.TeamName == "" || pipeline.TeamName == m.team {
+		return nil
+	}
+	return showToast(ToastInfo, "%s belongs to team %s, switch the target to that team (%s in targets) to use it",
+		pipeline.Name, pipeline.TeamName, keys.Label(actionSwitchTeam))
+}
+
+// listable returns false for the pipelines that can't be listed whatever the
+// filter and search: archived ones unless they are shown, and other teams'
+// ones while only those with failing jobs are shown, as their jobs can't be
+// loaded
+func (m PipelinesViewModel) listable(pipeline concourse.Pipeline) bool {
+	if pipeline.Archived && !m.showArchived {
+		// In case fly listed them anyway
+		return false
+	}
+	if m.filter == pipelineFilterFailing && m.allTeams && pipeline.TeamName != "" && pipeline.TeamName != m.team {
+		return false
+	}
+	return true
+}
+
+// filterPipelines filters pipelines based on the quick filter and the
+// current search query, leaving out archived pipelines unless they are shown
 func (m *PipelinesViewModel) filterPipelines() {
-	if m.searchQuery == "" {
-		m.filteredPipelines = make([]concourse.Pipeline, len(m.pipelines))
-		copy(m.filteredPipelines, m.pipelines)
-	} else {
-		m.filteredPipelines = nil
-		query := strings.ToLower(m.searchQuery)
-		for _, pipeline := range m.pipelines {
-			if strings.Contains(strings.ToLower(pipeline.Name), query) ||
+	m.filteredPipelines = nil
+	query := strings.ToLower(m.searchQuery)
+	for _, pipeline := range m.pipelines {
+		if !m.listable(pipeline) {
+			continue
+		}
+		if m.filter == pipelineFilterPaused && !pipeline.Paused ||
+			m.filter == pipelineFilterFailing && !m.failing(pipeline) {
+			continue
+		}
+		if query == "" {
+			m.filteredPipelines = append(m.filteredPipelines, pipeline)
+		} else if strings.Contains(strings.ToLower(pipeline.Name), query) ||
+			   strings.Contains(strings.ToLower(pipeline.InstanceVars.String()), query) ||
 			   strings.Contains(strings.ToLower(pipeline.TeamName), query) {
-				m.filteredPipelines = append(m.filteredPipelines, pipeline)
-			}
+			m.filteredPipelines = append(m.filteredPipelines, pipeline)
 		}
 	}
 	
+	// Starred pipelines always sort to the top, within their team when
+	// showing all teams, the target's own team first
+	sort.SliceStable(m.filteredPipelines, func(i, j int) bool {
+		a, b := m.filteredPipelines[i], m.filteredPipelines[j]
+		if m.allTeams && a.TeamName != b.TeamName {
+			if a.TeamName == m.team || b.TeamName == m.team {
+				return a.TeamName == m.team
+			}
+			return a.TeamName < b.TeamName
+		}
+		return m.isFavorite(a) && !m.isFavorite(b)
+	})
+	m.filteredPipelines = groupInstances(m.filteredPipelines)
+	
 	// Reset selection and scroll if it's out of bounds
 	if m.selected >= len(m.filteredPipelines) {
 		m.selected = 0
@@ -88,6 +205,15 @@ func (m *PipelinesViewModel) filterPipelines() {
 
 // Update handles messages for the pipelines view
 func (m PipelinesViewModel) Update(msg tea.KeyMsg) (PipelinesViewModel, tea.Cmd) {
+	m, cmd := m.handleKey(msg)
+	health := m.LoadHealth()
+	preview := m.LoadPreview()
+	return m, tea.Batch(cmd, health, preview)
+}
+
+// handleKey handles a key press, Update then loads the job summary and the
+// config preview of whichever pipeline ends up selected
+func (m PipelinesViewModel) handleKey(msg tea.KeyMsg) (PipelinesViewModel, tea.Cmd) {
 	// Handle search mode
 	if m.searchMode {
 		switch msg.String() {
@@ -115,14 +241,14 @@ func (m PipelinesViewModel) Update(msg tea.KeyMsg) (PipelinesViewModel, tea.Cmd)
 	}
 	
 	// Handle normal navigation mode
-	switch msg.String() {
-	case "f5":
+	switch {
+	case keys.Matches(msg, actionRefresh):
 		// Refresh pipelines
 		if m.client != nil {
 			m.state = pipelinesStateLoading
 			return m, m.LoadPipelines(m.client)
 		}
-	case "up", "k":
+	case keys.Matches(msg, actionUp):
 		if m.selected > 0 {
 			m.selected--
 			// Adjust scroll if needed
@@ -130,7 +256,21 @@ func (m PipelinesViewModel) Update(msg tea.KeyMsg) (PipelinesViewModel, tea.Cmd)
 				m.scrollOffset = m.selected
 			}
 		}
-	case "down":
+	case keys.Matches(msg, actionJobs):
+		// Checked before down so the default "j" opens jobs here
+		if len(m.filteredPipelines) > 0 {
+			if cmd := m.otherTeam(); cmd != nil {
+				return m, cmd
+			}
+			pipeline := m.filteredPipelines[m.selected]
+			return m, func() tea.Msg {
+				return SwitchViewMsg{
+					View:     ViewJobs,
+					Pipeline: pipeline.Ref(),
+				}
+			}
+		}
+	case keys.Matches(msg, actionDown):
 		if m.selected < len(m.filteredPipelines)-1 {
 			m.selected++
 			// Adjust scroll if needed
@@ -138,39 +278,197 @@ func (m PipelinesViewModel) Update(msg tea.KeyMsg) (PipelinesViewModel, tea.Cmd)
 				m.scrollOffset = m.selected - m.maxVisible + 1
 			}
 		}
-	case "j":
+	case keys.Matches(msg, actionResources):
 		if len(m.filteredPipelines) > 0 {
+			if cmd := m.otherTeam(); cmd != nil {
+				return m, cmd
+			}
 			return m, func() tea.Msg {
-				return SwitchViewMsg{View: ViewJobs}
+				return SwitchViewMsg{View: ViewResources}
 			}
 		}
-	case "r":
+	case keys.Matches(msg, actionGraph):
 		if len(m.filteredPipelines) > 0 {
+			if cmd := m.otherTeam(); cmd != nil {
+				return m, cmd
+			}
+			pipeline := m.filteredPipelines[m.selected]
 			return m, func() tea.Msg {
-				return SwitchViewMsg{View: ViewResources}
+				return SwitchViewMsg{View: ViewGraph, Pipeline: pipeline.Ref()}
 			}
 		}
-	case "p":
+	case keys.Matches(msg, actionFlaky):
 		if len(m.filteredPipelines) > 0 {
-			return m, m.togglePipeline()
+			if cmd := m.otherTeam(); cmd != nil {
+				return m, cmd
+			}
+			pipeline := m.filteredPipelines[m.selected]
+			return m, func() tea.Msg {
+				return SwitchViewMsg{View: ViewFlaky, Pipeline: pipeline.Ref()}
+			}
+		}
+	case keys.Matches(msg, actionConfig):
+		if len(m.filteredPipelines) > 0 {
+			if cmd := m.otherTeam(); cmd != nil {
+				return m, cmd
+			}
+			pipeline := m.filteredPipelines[m.selected]
+			return m, func() tea.Msg {
+				return SwitchViewMsg{View: ViewConfig, Pipeline: pipeline.Ref()}
+			}
 		}
-	case "enter":
+	case keys.Matches(msg, actionEditConfig):
 		if len(m.filteredPipelines) > 0 {
+			if cmd := m.otherTeam(); cmd != nil {
+				return m, cmd
+			}
 			pipeline := m.filteredPipelines[m.selected]
 			return m, func() tea.Msg {
-				return SwitchViewMsg{
-					View:     ViewJobs,
-					Pipeline: pipeline.Name,
-				}
+				return SwitchViewMsg{View: ViewConfig, Pipeline: pipeline.Ref(), Edit: true}
+			}
+		}
+	case keys.Matches(msg, actionDashboard):
+		return m, func() tea.Msg {
+			return SwitchViewMsg{View: ViewDashboard}
+		}
+	case keys.Matches(msg, actionPause):
+		if len(m.filteredPipelines) > 0 {
+			if cmd := m.otherTeam(); cmd != nil {
+				return m, cmd
+			}
+			return m, m.togglePipeline()
+		}
+	case keys.Matches(msg, actionFavorite):
+		if len(m.filteredPipelines) > 0 {
+			m.toggleFavorite()
+		}
+	case keys.Matches(msg, actionWatch):
+		if len(m.filteredPipelines) > 0 && m.stateStore != nil && m.client != nil {
+			if cmd := m.otherTeam(); cmd != nil {
+				return m, cmd
 			}
+			item := state.WatchItem{Target: m.client.GetTarget(), Pipeline: m.filteredPipelines[m.selected].Ref()}
+			_, m.favoriteErr = m.stateStore.ToggleWatch(item)
 		}
-	case "/", "s":
+	case keys.Matches(msg, actionDestroy):
+		if len(m.filteredPipelines) > 0 && m.client != nil {
+			if cmd := m.otherTeam(); cmd != nil {
+				return m, cmd
+			}
+			return m, m.confirmDestroy()
+		}
+	case keys.Matches(msg, actionAllTeams):
+		if m.client != nil {
+			m.allTeams = !m.allTeams
+			return m, m.LoadPipelines(m.client)
+		}
+	case keys.Matches(msg, actionFilter):
+		m.filter = (m.filter + 1) % (pipelineFilterFailing + 1)
+		m.refilter()
+	case keys.Matches(msg, actionArchived):
+		if m.client != nil {
+			m.showArchived = !m.showArchived
+			return m, m.LoadPipelines(m.client)
+		}
+	case keys.Matches(msg, actionSearch):
 		m.searchMode = true
+	case keys.Matches(msg, actionGrowPane), keys.Matches(msg, actionShrinkPane), keys.Matches(msg, actionTogglePane):
+		m.favoriteErr = resizePane(msg, &m.pane, m.stateStore)
 	}
 	
 	return m, nil
 }
 
+// Mouse handles clicks and scrolling over the pipelines list
+func (m PipelinesViewModel) Mouse(msg tea.MouseMsg) (PipelinesViewModel, tea.Cmd) {
+	if m.searchMode || m.state == pipelinesStateLoading {
+		return m, nil
+	}
+	
+	rows := listRows{top: searchListTop, height: 2, end: len(m.filteredPipelines)}
+	switch intent, index := m.clicks.interpret(msg, rows); intent {
+	case mouseScrollUp:
+		return m.Update(keyMsgFor(actionUp))
+	case mouseScrollDown:
+		return m.Update(keyMsgFor(actionDown))
+	case mouseClick:
+		m.selected = index
+	case mouseDoubleClick:
+		m.selected = index
+		return m.Update(keyMsgFor(actionJobs))
+	}
+	health := m.LoadHealth()
+	preview := m.LoadPreview()
+	return m, tea.Batch(health, preview)
+}
+
+// refilter filters the pipelines again, keeping the selected pipeline
+// selected when it is still listed
+func (m *PipelinesViewModel) refilter() {
+	selected := m.GetSelectedPipeline()
+	m.filterPipelines()
+	for i, pipeline := range m.filteredPipelines {
+		if pipeline.Ref() == selected {
+			m.selected = i
+		}
+	}
+}
+
+// isFavorite returns true if the pipeline is starred for the current target
+func (m PipelinesViewModel) isFavorite(pipeline concourse.Pipeline) bool {
+	if m.stateStore == nil || m.client == nil {
+		return false
+	}
+	return m.stateStore.IsFavorite(m.client.GetTarget(), pipeline.Ref())
+}
+
+// toggleFavorite stars or unstars the selected pipeline, keeping it selected
+func (m *PipelinesViewModel) toggleFavorite() {
+	if m.stateStore == nil || m.client == nil {
+		return
+	}
+	
+	name := m.filteredPipelines[m.selected].Ref()
+	_, m.favoriteErr = m.stateStore.ToggleFavorite(m.client.GetTarget(), name)
+	m.filterPipelines()
+	
+	// Follow the pipeline to its new position in the sorted list
+	for i, pipeline := range m.filteredPipelines {
+		if pipeline.Ref() == name {
+			m.selected = i
+			break
+		}
+	}
+	if m.selected < m.scrollOffset {
+		m.scrollOffset = m.selected
+	} else if m.selected >= m.scrollOffset+m.maxVisible {
+		m.scrollOffset = m.selected - m.maxVisible + 1
+	}
+}
+
+// confirmDestroy asks the user before destroying the selected pipeline
+func (m PipelinesViewModel) confirmDestroy() tea.Cmd {
+	client := m.client
+	pipeline := m.filteredPipelines[m.selected].Ref()
+	return confirmAction("Destroy pipeline",
+		fmt.Sprintf("Destroy pipeline '%s' on %s? This deletes all of its build history and cannot be undone.", pipeline, client.GetTarget()),
+		func() tea.Msg {
+			return PipelineDestroyedMsg{Pipeline: pipeline, Error: client.DestroyPipeline(pipeline)}
+		})
+}
+
+// HandlePipelineDestroyed reports the result of a destroy and reloads the list
+func (m PipelinesViewModel) HandlePipelineDestroyed(msg PipelineDestroyedMsg) (PipelinesViewModel, tea.Cmd) {
+	if msg.Error != nil {
+		return m, showToast(ToastError, "%v", msg.Error)
+	}
+	if m.client == nil {
+		return m, showToast(ToastSuccess, "Destroyed pipeline %s", msg.Pipeline)
+	}
+	cmd := m.LoadPipelines(m.client)
+	return m, tea.Batch(showToast(ToastSuccess, "Destroyed pipeline %s", msg.Pipeline), cmd)
+}
+
 // togglePipeline pauses or unpauses the selected pipeline
 func (m PipelinesViewModel) togglePipeline() tea.Cmd {
 	if len(m.filteredPipelines) == 0 {
@@ -189,34 +487,71 @@ func (m PipelinesViewModel) togglePipeline() tea.Cmd {
 	}
 }
 
-// GetSelectedPipeline returns the currently selected pipeline name
+// groupInstances moves the instances of each instanced pipeline up to the
+// first one, keeping the pipelines' order otherwise
+func groupInstances(pipelines []concourse.Pipeline) []concourse.Pipeline {
+	type key struct{ team, name string }
+	var order []key
+	instances := make(map[key][]concourse.Pipeline)
+	for _, pipeline := range pipelines {
+		k := key{pipeline.TeamName, pipeline.Name}
+		if _, seen := instances[k]; !seen {
+			order = append(order, k)
+		}
+		instances[k] = append(instances[k], pipeline)
+	}
+	
+	grouped := make([]concourse.Pipeline, 0, len(pipelines))
+	for _, k := range order {
+		grouped = append(grouped, instances[k]...)
+	}
+	return grouped
+}
+
+// GetSelectedPipeline returns how fly identifies the currently selected
+// pipeline, its name plus instance vars for an instanced pipeline
 func (m PipelinesViewModel) GetSelectedPipeline() string {
 	if len(m.filteredPipelines) == 0 || m.selected >= len(m.filteredPipelines) {
 		return ""
 	}
-	return m.filteredPipelines[m.selected].Name
+	return m.filteredPipelines[m.selected].Ref()
 }
 
 // HandlePipelinesLoaded handles the pipelines loaded message
-func (m PipelinesViewModel) HandlePipelinesLoaded(msg PipelinesLoadedMsg) PipelinesViewModel {
+func (m PipelinesViewModel) HandlePipelinesLoaded(msg PipelinesLoadedMsg) (PipelinesViewModel, tea.Cmd) {
 	m.pipelines = msg.Pipelines
 	m.err = msg.Error
 	m.state = pipelinesStateList
 	
-	// Reset selection and scroll to top when loading new data
-	if msg.Error == nil {
+	// Reset selection and scroll to top when loading new data, but not when
+	// refreshing the pipelines already shown
+	if msg.Error == nil && m.refreshing {
+		m.refilter()
+	} else if msg.Error == nil {
 		m.selected = 0
 		m.scrollOffset = 0
 		m.filterPipelines() // Filter the loaded pipelines
+		for i, pipeline := range m.filteredPipelines {
+			if m.selectNext != "" && pipeline.Ref() == m.selectNext {
+				m.selected = i
+			}
+		}
+		if m.selected >= m.scrollOffset+m.maxVisible {
+			m.scrollOffset = m.selected - m.maxVisible + 1
+		}
+		m.selectNext = ""
 	}
+	m.refreshing = false
 	
-	return m
+	health := m.LoadHealth()
+	preview := m.LoadPreview()
+	return m, tea.Batch(health, preview)
 }
 
 // View renders the pipelines view
 func (m PipelinesViewModel) View(width, height int) string {
 	titleStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("205")).
+		Foreground(theme.Primary).
 		Bold(true).
 		MarginBottom(1)
 	
@@ -225,33 +560,43 @@ func (m PipelinesViewModel) View(width, height int) string {
 		MarginBottom(1)
 		
 	selectedStyle := itemStyle.Copy().
-		Foreground(lipgloss.Color("205")).
+		Foreground(theme.Primary).
 		Bold(true).
 		PaddingLeft(1).
 		Border(lipgloss.NormalBorder(), false, false, false, true).
-		BorderForeground(lipgloss.Color("205"))
+		BorderForeground(theme.Primary)
 	
 	searchStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("240")).
+		BorderForeground(theme.Muted).
 		Padding(0, 1).
 		MarginBottom(1)
 	
 	searchActiveStyle := searchStyle.Copy().
-		BorderForeground(lipgloss.Color("205"))
+		BorderForeground(theme.Primary)
 	
 	var content strings.Builder
-	content.WriteString(titleStyle.Render("Pipelines"))
+	title := "Pipelines"
+	if m.allTeams {
+		title += " (all teams)"
+	}
+	if m.showArchived {
+		title += " (with archived)"
+	}
+	if m.refreshing {
+		title += staleNote
+	}
+	content.WriteString(titleStyle.Render(title))
 	content.WriteString("\n\n")
 	
 	if m.state == pipelinesStateLoading {
-		content.WriteString("Loading pipelines...\n")
+		content.WriteString(m.loader.View("Loading pipelines...") + "\n")
 		return content.String()
 	}
 	
 	if m.err != nil {
-		errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
-		content.WriteString(errorStyle.Render(fmt.Sprintf("Error: %v", m.err)))
+		errorStyle := lipgloss.NewStyle().Foreground(theme.Error)
+		content.WriteString(errorStyle.Render("Error: " + errorSummary(m.err)))
 		content.WriteString("\n")
 		return content.String()
 	}
@@ -259,20 +604,38 @@ func (m PipelinesViewModel) View(width, height int) string {
 	// Add search box
 	searchPrompt := "Search: "
 	searchText := m.searchQuery
+	var searchBox string
 	if m.searchMode {
 		searchText += "█" // cursor
-		content.WriteString(searchActiveStyle.Render(searchPrompt + searchText))
+		searchBox = searchActiveStyle.Render(searchPrompt + searchText)
 	} else {
 		if m.searchQuery != "" {
-			content.WriteString(searchStyle.Render(searchPrompt + searchText))
+			searchBox = searchStyle.Render(searchPrompt + searchText)
 		} else {
-			content.WriteString(searchStyle.Render(searchPrompt + "(/,s to search)"))
+			searchBox = searchStyle.Render(searchPrompt + "(/,s to search)")
 		}
 	}
+	if m.filter != pipelineFilterNone {
+		filter := pipelineFilterLabels[m.filter]
+		if checking := m.checking(); m.filter == pipelineFilterFailing && checking > 0 {
+			filter += fmt.Sprintf(", still checking %d", checking)
+		}
+		filterLabel := lipgloss.NewStyle().Foreground(theme.Warning).
+			Render(fmt.Sprintf("  %s (%s to change)", filter, keys.Label(actionFilter)))
+		// Next to the search text, below the box's top border
+		searchBox = lipgloss.JoinHorizontal(lipgloss.Top, searchBox, "\n"+filterLabel)
+	}
+	content.WriteString(searchBox)
 	content.WriteString("\n\n")
 	
 	if len(m.filteredPipelines) == 0 {
-		if m.searchQuery != "" {
+		if m.filter == pipelineFilterPaused {
+			content.WriteString("No paused pipelines.\n")
+		} else if m.filter == pipelineFilterFailing && m.checking() == 0 {
+			content.WriteString("No pipelines with failing jobs.\n")
+		} else if m.filter == pipelineFilterFailing {
+			content.WriteString(m.loader.View("Checking the pipelines' jobs...") + "\n")
+		} else if m.searchQuery != "" {
 			content.WriteString("No pipelines match search query.\n")
 		} else {
 			content.WriteString("No pipelines found.\n")
@@ -281,6 +644,7 @@ func (m PipelinesViewModel) View(width, height int) string {
 	}
 	
 	// Show pipelines list
+	var list strings.Builder
 	for i, pipeline := range m.filteredPipelines {
 		status := ""
 		if pipeline.Paused {
@@ -291,40 +655,64 @@ func (m PipelinesViewModel) View(width, height int) string {
 		}
 		
 		line := fmt.Sprintf("%s%s", pipeline.Name, status)
+		if len(pipeline.InstanceVars) > 0 {
+			// Instances follow each other, only the first one names the pipeline
+			vars := lipgloss.NewStyle().Foreground(theme.Muted).Render(pipeline.InstanceVars.String())
+			if i > 0 && m.filteredPipelines[i-1].Name == pipeline.Name && m.filteredPipelines[i-1].TeamName == pipeline.TeamName {
+				line = fmt.Sprintf("  └ %s%s", vars, status)
+			} else {
+				line = fmt.Sprintf("%s %s%s", pipeline.Name, vars, status)
+			}
+		}
+		if m.allTeams {
+			line = lipgloss.NewStyle().Foreground(theme.Muted).Render(pipeline.TeamName+" › ") + line
+		}
+		if m.isFavorite(pipeline) {
+			line = "★ " + line
+		}
+		if m.stateStore != nil && m.client != nil &&
+			m.stateStore.IsWatched(state.WatchItem{Target: m.client.GetTarget(), Pipeline: pipeline.Ref()}) {
+			line += " [WATCHED]"
+		}
 		
 		if i == m.selected {
-			content.WriteString(selectedStyle.Render("> " + line))
+			list.WriteString(selectedStyle.Render("> " + line))
 		} else {
-			content.WriteString(itemStyle.Render("  " + line))
+			list.WriteString(itemStyle.Render("  " + line))
+		}
+		list.WriteString("\n")
+	}
+	
+	// Show selected pipeline info, beside the list when there is room
+	if split := paneWidth(m.pane, width); split > 0 {
+		info := m.renderInfo()
+		// Inside the pane's borders and padding
+		if preview := m.renderPreview(m.filteredPipelines[m.selected], split-4); preview != "" {
+			info += "\n\n" + preview
+		}
+		content.WriteString(renderSplit(list.String(), info, width, split))
+	} else {
+		content.WriteString(list.String())
+		if !m.pane.Collapsed {
+			content.WriteString("\n")
+			infoStyle := lipgloss.NewStyle().
+				Border(lipgloss.RoundedBorder()).
+				BorderForeground(theme.Muted).
+				Padding(1).
+				MarginTop(1)
+			content.WriteString(infoStyle.Render(m.renderInfo()))
 		}
-		content.WriteString("\n")
 	}
 	
-	// Show selected pipeline info
-	if len(m.filteredPipelines) > 0 {
+	if m.favoriteErr != nil {
+		errorStyle := lipgloss.NewStyle().Foreground(theme.Error).MarginTop(1)
 		content.WriteString("\n")
-		infoStyle := lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("240")).
-			Padding(1).
-			MarginTop(1)
-		
-		pipeline := m.filteredPipelines[m.selected]
-		info := fmt.Sprintf("Pipeline: %s\nTeam: %s\nStatus: %s\nPublic: %v", 
-			pipeline.Name, pipeline.TeamName,
-			func() string {
-				if pipeline.Paused {
-					return "Paused"
-				}
-				return "Running"
-			}(), pipeline.Public)
-		
-		content.WriteString(infoStyle.Render(info))
+		content.WriteString(errorStyle.Render(fmt.Sprintf("Failed to save state: %v", m.favoriteErr)))
 	}
 	
 	// Help text
 	helpStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("240")).
+		Foreground(theme.Muted).
 		Italic(true).
 		MarginTop(1)
 	
@@ -332,9 +720,29 @@ func (m PipelinesViewModel) View(width, height int) string {
 	if m.searchMode {
 		help = "Enter: finish search • Esc: cancel search • Ctrl+U: clear"
 	} else {
-		help = "↑/↓: navigate • Enter/j: jobs • r: resources • p: pause/unpause • /,s: search • F5: refresh • Esc: back"
+		help = keys.HelpLine(viewKeys[ViewPipelines]...)
 	}
 	content.WriteString(helpStyle.Render(help))
 	
 	return content.String()
+}
+
+// renderInfo renders the details of the selected pipeline
+func (m PipelinesViewModel) renderInfo() string {
+	pipeline := m.filteredPipelines[m.selected]
+	info := fmt.Sprintf("Pipeline: %s\nTeam: %s\nStatus: %s\nPublic: %v", 
+		pipeline.Name, pipeline.TeamName,
+		func() string {
+			if pipeline.Paused {
+				return "Paused"
+			}
+			return "Running"
+		}(), pipeline.Public)
+	if len(pipeline.InstanceVars) > 0 {
+		info += fmt.Sprintf("\nInstance vars: %s", pipeline.InstanceVars)
+	}
+	if health := m.renderHealth(pipeline); health != "" {
+		info += "\n" + health
+	}
+	return info
 }
\ No newline at end of file
@@ -0,0 +1,58 @@
+package tui
+
+import (
+	"testing"
+
+	"flyby/internal/concourse"
+)
+
+func TestLoadPipelines(t *testing.T) {
+	fake := concourse.NewFakeExecutor().On(concourse.FakeResponse{
+		Stdout: `[{"id": 1, "name": "main", "team_name": "dev"}, {"id": 2, "name": "release", "paused": true, "team_name": "dev"}]`,
+	}, "pipelines", "--json")
+	client := concourse.NewClientWithExecutor("flow-test", fake)
+
+	m := NewPipelinesViewModel(nil, false)
+	cmd := m.LoadPipelines(client)
+	if m.state != pipelinesStateLoading {
+		t.Errorf("state while loading = %v, want %v", m.state, pipelinesStateLoading)
+	}
+
+	msg, ok := cmd().(PipelinesLoadedMsg)
+	if !ok {
+		t.Fatalf("LoadPipelines() command returned %T, want PipelinesLoadedMsg", msg)
+	}
+	if msg.Error != nil {
+		t.Fatalf("PipelinesLoadedMsg.Error = %v", msg.Error)
+	}
+	if len(msg.Pipelines) != 2 || msg.Pipelines[0].Name != "main" || !msg.Pipelines[1].Paused {
+		t.Fatalf("PipelinesLoadedMsg.Pipelines = %+v", msg.Pipelines)
+	}
+
+	m, _ = m.HandlePipelinesLoaded(msg)
+	if m.state != pipelinesStateList {
+		t.Errorf("state once loaded = %v, want %v", m.state, pipelinesStateList)
+	}
+	if got := m.GetSelectedPipeline(); got != "main" {
+		t.Errorf("GetSelectedPipeline() = %q, want %q", got, "main")
+	}
+}
+
+func TestLoadPipelinesError(t *testing.T) {
+	fake := concourse.NewFakeExecutor().On(concourse.FakeResponse{
+		Stderr:   "error: not authorized. run the following to log in:\n",
+		ExitCode: 1,
+	}, "pipelines")
+	client := concourse.NewClientWithExecutor("flow-test-error", fake)
+
+	m := NewPipelinesViewModel(nil, false)
+	msg := m.LoadPipelines(client)().(PipelinesLoadedMsg)
+	if !concourse.IsAuthError(msg.Error) {
+		t.Fatalf("PipelinesLoadedMsg.Error = %v, want an auth error", msg.Error)
+	}
+
+	m, _ = m.HandlePipelinesLoaded(msg)
+	if m.err == nil || len(m.pipelines) != 0 {
+		t.Errorf("after a failed load: err = %v, pipelines = %v", m.err, m.pipelines)
+	}
+}
@@ -0,0 +1,166 @@
+package tui
+
+import (
+	"sync"
+	"time"
+
+	"flyby/internal/concourse"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// prefetchDelay is how long the cursor rests on a pipeline before its jobs
+// and resources are prefetched
+const prefetchDelay = 500 * time.Millisecond
+
+// prefetchTTL is how long prefetched jobs and resources are shown instead of
+// loading them again
+const prefetchTTL = 30 * time.Second
+
+// prefetchKey identifies a pipeline across targets
+type prefetchKey struct {
+	Target   string
+	Pipeline string
+}
+
+// prefetchedPipeline holds the jobs and resources of a pipeline loaded ahead
+// of time, each nil once shown or when it failed to load
+type prefetchedPipeline struct {
+	jobs      *JobsLoadedMsg
+	resources *ResourcesLoadedMsg
+	fetched   time.Time
+}
+
+// prefetcher loads the jobs and resources of the pipeline the cursor rests
+// on, so opening them needs no loading screen
+type prefetcher struct {
+	resting prefetchKey // pipeline the cursor was last seen on
+	loading map[prefetchKey]bool
+	entries map[prefetchKey]prefetchedPipeline
+}
+
+// PrefetchTickMsg fires once the cursor rested on a pipeline for prefetchDelay
+type PrefetchTickMsg struct {
+	Key prefetchKey
+}
+
+// PrefetchedMsg carries the jobs and resources of a pipeline loaded ahead
+type PrefetchedMsg struct {
+	Key       prefetchKey
+	Jobs      JobsLoadedMsg
+	Resources ResourcesLoadedMsg
+}
+
+// newPrefetcher creates an empty prefetcher
+func newPrefetcher() prefetcher {
+	return prefetcher{
+		loading: make(map[prefetchKey]bool),
+		entries: make(map[prefetchKey]prefetchedPipeline),
+	}
+}
+
+// prefetchSelected waits for the cursor to rest on the selected pipeline
+// whenever it moved to another one
+func (m *Model) prefetchSelected() tea.Cmd {
+	if m.currentView != ViewPipelines || m.client == nil || m.pipelinesView.state == pipelinesStateLoading {
+		m.prefetch.resting = prefetchKey{}
+		return nil
+	}
+	key := prefetchKey{Target: m.currentTarget, Pipeline: m.pipelinesView.GetSelectedPipeline()}
+	if key == m.prefetch.resting {
+		return nil
+	}
+	m.prefetch.resting = key
+	if key.Pipeline == "" {
+		return nil
+	}
+	return tea.Tick(prefetchDelay, func(time.Time) tea.Msg {
+		return PrefetchTickMsg{Key: key}
+	})
+}
+
+// HandlePrefetchTick loads the jobs and resources of the pipeline the cursor
+// still rests on, unless they are at hand already. Pipelines of other teams
+// are skipped, fly only lists the target's team's.
+func (m *Model) HandlePrefetchTick(msg PrefetchTickMsg) tea.Cmd {
+	if msg.Key.Pipeline == "" || msg.Key != m.prefetch.resting || m.prefetch.loading[msg.Key] || m.pipelinesView.otherTeam() != nil {
+		return nil
+	}
+	if entry, ok := m.prefetch.entries[msg.Key]; ok && time.Since(entry.fetched) < prefetchTTL {
+		return nil
+	}
+	m.prefetch.loading[msg.Key] = true
+
+	client, pipeline := m.client, msg.Key.Pipeline
+	return func() tea.Msg {
+		var jobs []concourse.Job
+		var resources []concourse.Resource
+		var config concourse.PipelineConfig
+		var jobsErr, resourcesErr error
+		var wg sync.WaitGroup
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			jobs, jobsErr = client.GetJobs(pipeline)
+		}()
+		go func() {
+			defer wg.Done()
+			resources, resourcesErr = client.GetResources(pipeline)
+		}()
+		go func() {
+			defer wg.Done()
+			// The config only organizes the jobs and resources, like when loading them
+			config, _ = client.GetPipelineConfig(pipeline)
+		}()
+		wg.Wait()
+		return PrefetchedMsg{
+			Key:       msg.Key,
+			Jobs:      JobsLoadedMsg{Jobs: jobs, Config: config, Error: jobsErr, Pipeline: pipeline},
+			Resources: ResourcesLoadedMsg{Resources: resources, Config: config, Error: resourcesErr, Pipeline: pipeline},
+		}
+	}
+}
+
+// store keeps what loaded fine, failures are left to the views to show, and
+// drops what went stale
+func (p *prefetcher) store(msg PrefetchedMsg) {
+	delete(p.loading, msg.Key)
+	for key, entry := range p.entries {
+		if time.Since(entry.fetched) >= prefetchTTL {
+			delete(p.entries, key)
+		}
+	}
+	entry := prefetchedPipeline{fetched: time.Now()}
+	if msg.Jobs.Error == nil {
+		entry.jobs = &msg.Jobs
+	}
+	if msg.Resources.Error == nil {
+		entry.resources = &msg.Resources
+	}
+	p.entries[msg.Key] = entry
+}
+
+// takeJobs returns the prefetched jobs of a pipeline if they are fresh, once
+func (p *prefetcher) takeJobs(key prefetchKey) *JobsLoadedMsg {
+	entry, ok := p.entries[key]
+	if !ok || time.Since(entry.fetched) >= prefetchTTL {
+		return nil
+	}
+	jobs := entry.jobs
+	entry.jobs = nil
+	p.entries[key] = entry
+	return jobs
+}
+
+// takeResources returns the prefetched resources of a pipeline if they are
+// fresh, once
+func (p *prefetcher) takeResources(key prefetchKey) *ResourcesLoadedMsg {
+	entry, ok := p.entries[key]
+	if !ok || time.Since(entry.fetched) >= prefetchTTL {
+		return nil
+	}
+	resources := entry.resources
+	entry.resources = nil
+	p.entries[key] = entry
+	return resources
+}
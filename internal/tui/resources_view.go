@@ -62,19 +62,28 @@ type ResourcesViewModel struct {
 	resources        []concourse.Resource
 	filteredResources []concourse.Resource
 	selected         int
+	selectNext       string // resource to select once the resources load, e.g. from global search
 	state            resourcesState
+	refreshing       bool // showing the resources of an earlier visit while they reload
 	err              error
 	pipeline         string
+	config           concourse.PipelineConfig // for which jobs use each resource
 	checkingResource string
 	checkResult      string
 	checkError       error
 	searchQuery      string
 	searchMode       bool
+	scrollOffset     int
+	maxVisible       int
+	clicks           clickTracker
+	loader           loader
 }
 
 // ResourceCheckMsg represents a resource check result
 type ResourceCheckMsg struct {
-	Resource string
+	Target   string
+	Pipeline string
+	Resource string // pipeline/resource
 	Output   string
 	Error    error
 	Success  bool
@@ -91,19 +100,26 @@ type ReloadResourcesMsg struct {
 	Pipeline string
 }
 
+// resourcesMinRows is how many resources stay listed however long the
+// selected resource's info and the check results are
+const resourcesMinRows = 3
+
 // NewResourcesViewModel creates a new resources view model
 func NewResourcesViewModel() ResourcesViewModel {
 	return ResourcesViewModel{
 		selected:     0,
+		maxVisible:   10,
 		state:        resourcesStateList,
 		searchQuery:  "",
 		searchMode:   false,
+		loader:       newLoader(),
 	}
 }
 
 // ResourcesLoadedMsg represents loaded resources
 type ResourcesLoadedMsg struct {
 	Resources []concourse.Resource
+	Config    concourse.PipelineConfig
 	Error     error
 	Pipeline  string
 	IsReload  bool // true when reloading after operations, false for initial load
@@ -116,8 +132,20 @@ func (m ResourcesViewModel) LoadResources(client *concourse.Client, pipeline str
 		if err != nil {
 			return ResourcesLoadedMsg{Error: err, Pipeline: pipeline}
 		}
-		return ResourcesLoadedMsg{Resources: resources, Pipeline: pipeline}
+		// Without the config the resources are still listed, just not who uses them
+		config, _ := client.GetPipelineConfig(pipeline)
+		return ResourcesLoadedMsg{Resources: resources, Config: config, Pipeline: pipeline}
+	}
+}
+
+// Revalidate reloads the resources while still showing the ones loaded on an
+// earlier visit, or returns nil when there are none to show
+func (m *ResourcesViewModel) Revalidate() tea.Cmd {
+	if m.client == nil || m.pipeline == "" || m.state == resourcesStateLoading || m.err != nil {
+		return nil
 	}
+	m.refreshing = true
+	return m.LoadResources(m.client, m.pipeline)
 }
 
 // filterResources filters resources based on the current search query
@@ -141,9 +169,11 @@ func (m *ResourcesViewModel) filterResources() {
 	// Reset selection and scroll if it's out of bounds
 	if m.selected >= len(m.filteredResources) {
 		m.selected = 0
+		m.scrollOffset = 0
 	}
 	if m.selected < 0 && len(m.filteredResources) > 0 {
 		m.selected = 0
+		m.scrollOffset = 0
 	}
 }
 
@@ -153,13 +183,14 @@ func (m ResourcesViewModel) ReloadResources(client *concourse.Client) tea.Cmd {
 		return nil
 	}
 	
+	pipeline := m.pipeline
 	return func() tea.Msg {
-		resources, err := client.GetResources(m.pipeline)
+		resources, err := client.GetResources(pipeline)
 		if err != nil {
 			// Don't show error for background reload, just keep existing data
 			return nil
 		}
-		return ResourcesLoadedMsg{Resources: resources, IsReload: true}
+		return ResourcesLoadedMsg{Resources: resources, Pipeline: pipeline, IsReload: true}
 	}
 }
 
@@ -192,49 +223,104 @@ func (m ResourcesViewModel) Update(msg tea.KeyMsg) (ResourcesViewModel, tea.Cmd)
 	}
 	
 	// Handle normal navigation mode
-	switch msg.String() {
-	case "f5":
+	switch {
+	case keys.Matches(msg, actionRefresh):
 		// Refresh resources
 		if m.client != nil && m.pipeline != "" {
 			m.state = resourcesStateLoading
 			return m, m.LoadResources(m.client, m.pipeline)
 		}
-	case "up", "k":
+	case keys.Matches(msg, actionUp):
 		if m.selected > 0 {
 			m.selected--
+			// Adjust scroll if needed
+			if m.selected < m.scrollOffset {
+				m.scrollOffset = m.selected
+			}
 			// Clear check results when navigating
 			m.checkResult = ""
 			m.checkError = nil
 		}
-	case "down", "j":
+	case keys.Matches(msg, actionDown):
 		if m.selected < len(m.filteredResources)-1 {
 			m.selected++
+			// Adjust scroll if needed
+			if m.selected >= m.scrollOffset+m.maxVisible {
+				m.scrollOffset = m.selected - m.maxVisible + 1
+			}
 			// Clear check results when navigating
 			m.checkResult = ""
 			m.checkError = nil
 		}
-	case "enter", "c":
+	case keys.Matches(msg, actionCheck):
 		if len(m.filteredResources) > 0 {
 			resource := m.filteredResources[m.selected]
 			return m, func() tea.Msg {
 				return CheckResourceRequestMsg{
-					Pipeline: resource.PipelineName,
+					Pipeline: resource.PipelineRef(),
 					Resource: resource.Name,
 				}
 			}
 		}
-	case "x", "clear":
+	case keys.Matches(msg, actionClear):
 		// Clear check results
 		m.checkResult = ""
 		m.checkError = nil
 		m.checkingResource = ""
-	case "/", "s":
+	case keys.Matches(msg, actionSearch):
 		m.searchMode = true
 	}
 	
 	return m, nil
 }
 
+// visibleRange returns the range of resources shown for the given height,
+// keeping the selected resource in view
+func (m ResourcesViewModel) visibleRange(height int) (int, int) {
+	maxVisible := m.maxVisible
+	if height > 0 {
+		// Account for title, search box, indicators, help and what renderDetails
+		// left for the list
+		info, check := m.renderDetails(height, "")
+		reserved := searchListTop + 4 + 2 + lipgloss.Height(info) + lipgloss.Height(check)
+		maxVisible = max((height-reserved)/2, 1)
+	}
+	
+	start := m.scrollOffset
+	if m.selected >= start+maxVisible {
+		start = m.selected - maxVisible + 1
+	}
+	if m.selected < start {
+		start = m.selected
+	}
+	return start, min(start+maxVisible, len(m.filteredResources))
+}
+
+// Mouse handles clicks and scrolling over the resources list
+func (m ResourcesViewModel) Mouse(msg tea.MouseMsg, height int) (ResourcesViewModel, tea.Cmd) {
+	if m.searchMode || m.state == resourcesStateLoading {
+		return m, nil
+	}
+	
+	start, end := m.visibleRange(height)
+	rows := listRows{top: searchListTop, height: 2, first: start, end: end}
+	if start > 0 {
+		rows.top += 2 // "more above" indicator
+	}
+	switch intent, index := m.clicks.interpret(msg, rows); intent {
+	case mouseScrollUp:
+		return m.Update(keyMsgFor(actionUp))
+	case mouseScrollDown:
+		return m.Update(keyMsgFor(actionDown))
+	case mouseClick:
+		m.selected = index
+	case mouseDoubleClick:
+		// Resources have nothing to open, and a check should stay deliberate
+		m.selected = index
+	}
+	return m, nil
+}
+
 // checkResource checks the selected resource
 func (m *ResourcesViewModel) checkResource(client *concourse.Client) tea.Cmd {
 	if len(m.filteredResources) == 0 || client == nil {
@@ -242,7 +328,7 @@ func (m *ResourcesViewModel) checkResource(client *concourse.Client) tea.Cmd {
 	}
 	
 	resource := m.filteredResources[m.selected]
-	resourceName := fmt.Sprintf("%s/%s", resource.PipelineName, resource.Name)
+	resourceName := fmt.Sprintf("%s/%s", resource.PipelineRef(), resource.Name)
 	
 	// Set checking state
 	m.checkingResource = resourceName
@@ -250,8 +336,10 @@ func (m *ResourcesViewModel) checkResource(client *concourse.Client) tea.Cmd {
 	m.checkError = nil
 	
 	return func() tea.Msg {
-		success, output, err := client.CheckResourceWithOutput(resource.PipelineName, resource.Name)
+		success, output, err := client.CheckResourceWithOutput(resource.PipelineRef(), resource.Name)
 		return ResourceCheckMsg{
+			Target:   client.GetTarget(),
+			Pipeline: resource.PipelineRef(),
 			Resource: resourceName,
 			Output:   output,
 			Error:    err,
@@ -267,20 +355,47 @@ func (m ResourcesViewModel) HandleResourcesLoaded(msg ResourcesLoadedMsg) Resour
 	m.pipeline = msg.Pipeline
 	m.state = resourcesStateList
 	
-	// For reloads, preserve the current selection; for initial loads, reset to 0
+	// For reloads, preserve the current config
 	if !msg.IsReload {
-		m.selected = 0
-	} else {
+		m.config = msg.Config
+	}
+	// For reloads and refreshes, preserve the current selection; for initial loads, reset to 0
+	if msg.IsReload || m.refreshing && msg.Error == nil {
 		// Ensure selection is still valid after reload
 		if m.selected >= len(m.resources) {
 			m.selected = 0
+			m.scrollOffset = 0
 		}
+	} else {
+		m.selected = 0
+		m.scrollOffset = 0
 	}
+	m.refreshing = false
 	
 	m.filterResources() // Filter the loaded resources
+	if m.selectNext != "" && !msg.IsReload {
+		m.selectResource(m.selectNext)
+		m.selectNext = ""
+	}
 	return m
 }
 
+// selectResource selects the listed resource with the name, clearing a
+// search that hides it
+func (m *ResourcesViewModel) selectResource(name string) {
+	if m.searchQuery != "" {
+		m.searchQuery = ""
+		m.filterResources()
+	}
+	for i, resource := range m.filteredResources {
+		if resource.Name == name {
+			m.selected = i
+			m.scrollOffset = 0
+			return
+		}
+	}
+}
+
 // HandleResourceCheck handles the resource check result message
 func (m ResourcesViewModel) HandleResourceCheck(msg ResourceCheckMsg) (ResourcesViewModel, tea.Cmd) {
 	m.checkingResource = ""
@@ -320,7 +435,7 @@ func (m ResourcesViewModel) StartResourceCheck(resourceName string) ResourcesVie
 // View renders the resources view
 func (m ResourcesViewModel) View(width, height int, target string) string {
 	titleStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("205")).
+		Foreground(theme.Primary).
 		Bold(true).
 		MarginBottom(1)
 	
@@ -329,37 +444,40 @@ func (m ResourcesViewModel) View(width, height int, target string) string {
 		MarginBottom(1)
 		
 	selectedStyle := itemStyle.Copy().
-		Foreground(lipgloss.Color("205")).
+		Foreground(theme.Primary).
 		Bold(true).
 		PaddingLeft(1).
 		Border(lipgloss.NormalBorder(), false, false, false, true).
-		BorderForeground(lipgloss.Color("205"))
+		BorderForeground(theme.Primary)
 	
 	searchStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("240")).
+		BorderForeground(theme.Muted).
 		Padding(0, 1).
 		MarginBottom(1)
 	
 	searchActiveStyle := searchStyle.Copy().
-		BorderForeground(lipgloss.Color("205"))
+		BorderForeground(theme.Primary)
 	
 	var content strings.Builder
 	title := "Resources"
 	if m.pipeline != "" {
 		title = fmt.Sprintf("Resources - %s", m.pipeline)
 	}
+	if m.refreshing && m.state != resourcesStateLoading {
+		title += staleNote
+	}
 	content.WriteString(titleStyle.Render(title))
 	content.WriteString("\n\n")
 	
 	if m.state == resourcesStateLoading {
-		content.WriteString("Loading resources...\n")
+		content.WriteString(m.loader.View("Loading resources...") + "\n")
 		return content.String()
 	}
 	
 	if m.err != nil {
-		errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
-		content.WriteString(errorStyle.Render(fmt.Sprintf("Error: %v", m.err)))
+		errorStyle := lipgloss.NewStyle().Foreground(theme.Error)
+		content.WriteString(errorStyle.Render("Error: " + errorSummary(m.err)))
 		content.WriteString("\n")
 		return content.String()
 	}
@@ -388,8 +506,17 @@ func (m ResourcesViewModel) View(width, height int, target string) string {
 		return content.String()
 	}
 	
-	// Show resources list
-	for i, resource := range m.filteredResources {
+	start, end := m.visibleRange(height)
+	
+	// Add scroll indicator at top
+	if start > 0 {
+		content.WriteString(itemStyle.Render(fmt.Sprintf("  ↑ (%d more above)", start)))
+		content.WriteString("\n")
+	}
+	
+	// Show visible resources only
+	for i := start; i < end; i++ {
+		resource := m.filteredResources[i]
 		line := fmt.Sprintf("%s (%s)", resource.Name, resource.Type)
 		
 		if i == m.selected {
@@ -400,86 +527,20 @@ func (m ResourcesViewModel) View(width, height int, target string) string {
 		content.WriteString("\n")
 	}
 	
-	// Show selected resource info
-	if len(m.filteredResources) > 0 {
+	// Add scroll indicator at bottom
+	if end < len(m.filteredResources) {
+		content.WriteString(itemStyle.Render(fmt.Sprintf("  ↓ (%d more below)", len(m.filteredResources)-end)))
 		content.WriteString("\n")
-		infoStyle := lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("240")).
-			Padding(1).
-			MarginTop(1)
-		
-		resource := m.filteredResources[m.selected]
-		info := fmt.Sprintf("Resource: %s\nType: %s\nPipeline: %s\nTeam: %s", 
-			resource.Name, resource.Type, resource.PipelineName, resource.TeamName)
-		
-		lastChecked := resource.GetLastChecked()
-		if !lastChecked.IsZero() {
-			info += fmt.Sprintf("\nLast Checked: %s", formatTimeAgo(lastChecked))
-		}
-		
-		// Show version information if available
-		if len(resource.Version) > 0 {
-			info += "\nVersion:"
-			for key, value := range resource.Version {
-				info += fmt.Sprintf("\n  %s: %v", key, value)
-			}
-		}
-		
-		// Show metadata if available
-		if len(resource.Metadata) > 0 {
-			info += "\nMetadata:"
-			for _, metadata := range resource.Metadata {
-				info += fmt.Sprintf("\n  %s: %s", metadata.Name, metadata.Value)
-			}
-		}
-		
-		content.WriteString(infoStyle.Render(info))
 	}
 	
-	// Show resource checking status and results
-	if m.checkingResource != "" {
-		content.WriteString("\n")
-		statusStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("226")).
-			Bold(true).
-			MarginTop(1)
-		content.WriteString(statusStyle.Render(fmt.Sprintf("🔄 Checking resource: %s", m.checkingResource)))
-		content.WriteString("\n")
-		content.WriteString(fmt.Sprintf("Command: fly -t %s check-resource -r %s", target, m.checkingResource))
-	} else if m.checkResult != "" || m.checkError != nil {
-		content.WriteString("\n")
-		
-		if m.checkError != nil {
-			errorStyle := lipgloss.NewStyle().
-				Foreground(lipgloss.Color("196")).
-				Bold(true).
-				MarginTop(1)
-			content.WriteString(errorStyle.Render("❌ Resource check failed:"))
-			content.WriteString("\n")
-			content.WriteString(errorStyle.Render(m.checkError.Error()))
-		} else {
-			successStyle := lipgloss.NewStyle().
-				Foreground(lipgloss.Color("46")).
-				Bold(true).
-				MarginTop(1)
-			content.WriteString(successStyle.Render("✅ Resource check completed successfully!"))
-			content.WriteString("\n")
-			
-			if m.checkResult != "" {
-				resultStyle := lipgloss.NewStyle().
-					Border(lipgloss.RoundedBorder()).
-					BorderForeground(lipgloss.Color("46")).
-					Padding(1).
-					MarginTop(1)
-				content.WriteString(resultStyle.Render("Output:\n" + m.checkResult))
-			}
-		}
-	}
+	// Show selected resource info and the check status and results
+	info, check := m.renderDetails(height, target)
+	content.WriteString(info)
+	content.WriteString(check)
 	
 	// Help text
 	helpStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("240")).
+		Foreground(theme.Muted).
 		Italic(true).
 		MarginTop(1)
 	
@@ -487,9 +548,129 @@ func (m ResourcesViewModel) View(width, height int, target string) string {
 	if m.searchMode {
 		help = "Enter: finish search • Esc: cancel search • Ctrl+U: clear"
 	} else {
-		help = "↑/↓: navigate • Enter/c: check • /,s: search • x: clear • F5: refresh • Esc: back"
+		help = keys.HelpLine(viewKeys[ViewResources]...)
 	}
 	content.WriteString(helpStyle.Render(help))
 	
 	return content.String()
-}
\ No newline at end of file
+}
+
+// renderDetails renders the selected resource's info and the status and
+// results of a resource check, cut down so that resourcesMinRows resources
+// still fit the height along with them. The check results get up to half
+// of the room left.
+func (m ResourcesViewModel) renderDetails(height int, target string) (string, string) {
+	if len(m.filteredResources) == 0 {
+		return "", ""
+	}
+	// Title, search box, indicators, help and the resources kept listed
+	room := height - searchListTop - 4 - 2 - 2*min(len(m.filteredResources), resourcesMinRows)
+	
+	var check string
+	if m.checkingResource != "" {
+		statusStyle := lipgloss.NewStyle().
+			Foreground(theme.Warning).
+			Bold(true)
+		check = "\n\n" + m.loader.View(statusStyle.Render(fmt.Sprintf("Checking resource: %s", m.checkingResource))) +
+			"\n" + fmt.Sprintf("Command: fly -t %s check-resource -r %s", target, m.checkingResource)
+	} else if m.checkError != nil {
+		errorStyle := lipgloss.NewStyle().
+			Foreground(theme.Error).
+			Bold(true).
+			MarginTop(1)
+		check = "\n" + errorStyle.Render("❌ Resource check failed:") + "\n" +
+			errorStyle.Render(errorSummary(m.checkError))
+	} else if m.checkResult != "" {
+		successStyle := lipgloss.NewStyle().
+			Foreground(theme.Success).
+			Bold(true).
+			MarginTop(1)
+		resultStyle := lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(theme.Success).
+			Padding(1).
+			MarginTop(1)
+		// The status line, the Output: line, borders, padding and margins take 8
+		check = "\n" + successStyle.Render("✅ Resource check completed successfully!") + "\n" +
+			resultStyle.Render("Output:\n"+clampLines(m.checkResult, room/2-8))
+	}
+	
+	infoStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Muted).
+		Padding(1).
+		MarginTop(1)
+	
+	resource := m.filteredResources[m.selected]
+	info := fmt.Sprintf("Resource: %s\nType: %s\nPipeline: %s\nTeam: %s", 
+		resource.Name, resource.Type, resource.PipelineName, resource.TeamName)
+	
+	lastChecked := resource.GetLastChecked()
+	if !lastChecked.IsZero() {
+		info += fmt.Sprintf("\nLast Checked: %s", formatTimeAgo(lastChecked))
+	}
+	
+	// Show version information if available
+	if len(resource.Version) > 0 {
+		info += "\nVersion:"
+		for key, value := range resource.Version {
+			info += fmt.Sprintf("\n  %s: %v", key, value)
+		}
+	}
+	
+	// Show metadata if available
+	if len(resource.Metadata) > 0 {
+		info += "\nMetadata:"
+		for _, metadata := range resource.Metadata {
+			info += fmt.Sprintf("\n  %s: %s", metadata.Name, metadata.Value)
+		}
+	}
+	
+	// Show which jobs a check or pin would affect
+	if len(m.config.Jobs) > 0 {
+		info += "\n" + renderResourceUsage(m.config.ResourceUsage(resource.Name))
+	}
+	
+	// The blank line, margin, borders and padding around the info take 6 lines,
+	// and the first 5 of the info are always shown
+	limit := max(room-lipgloss.Height(check)-6, 5)
+	return "\n" + infoStyle.Render(clampLines(info, limit)), check
+}
+
+// clampLines cuts text down to limit lines, the last one telling how many
+// were left out
+func clampLines(text string, limit int) string {
+	lines := strings.Split(text, "\n")
+	if len(lines) <= limit {
+		return text
+	}
+	kept := lines[:max(limit-1, 0)]
+	return strings.Join(append(kept, fmt.Sprintf("… %d more lines", len(lines)-len(kept))), "\n")
+}
+
+// renderResourceUsage lists the jobs getting and putting a resource
+func renderResourceUsage(usage concourse.ResourceUsage) string {
+	if len(usage.Gets) == 0 && len(usage.Puts) == 0 {
+		return "Used by: no jobs"
+	}
+	
+	lines := []string{"Used by:"}
+	for _, get := range usage.Gets {
+		var notes []string
+		if get.Trigger {
+			notes = append(notes, "triggers")
+		}
+		if len(get.Passed) > 0 {
+			notes = append(notes, "passed "+strings.Join(get.Passed, ", "))
+		}
+		line := "  get  " + get.Job
+		if len(notes) > 0 {
+			line += " (" + strings.Join(notes, "; ") + ")"
+		}
+		lines = append(lines, line)
+	}
+	for _, job := range usage.Puts {
+		lines = append(lines, "  put  "+job)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,577 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"flyby/internal/concourse"
+	"flyby/internal/config"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// searchConcurrency bounds the number of pipelines whose jobs and resources
+// load at once
+const searchConcurrency = 4
+
+// searchResultsTop is the line of the first result: title, margin, blank
+// line, the bordered query box, progress or summary and a blank line
+const searchResultsTop = 9
+
+// searchKind tells what a search result is
+type searchKind int
+
+const (
+	searchPipeline searchKind = iota
+	searchJob
+	searchResource
+)
+
+// searchKindLabels tags each kind of result in the list
+var searchKindLabels = map[searchKind]string{
+	searchPipeline: "pipeline",
+	searchJob:      "job",
+	searchResource: "resource",
+}
+
+// searchItem is a pipeline, job or resource global search can find
+type searchItem struct {
+	kind     searchKind
+	target   string
+	pipeline string // Ref
+	name     string // of the job or resource, "" for pipelines
+	detail   string // the resource type, or whether the pipeline is paused
+	status   string // of the job's latest finished build
+}
+
+// path returns how the item is listed, e.g. "web-app/deploy"
+func (i searchItem) path() string {
+	if i.name == "" {
+		return i.pipeline
+	}
+	return i.pipeline + "/" + i.name
+}
+
+// rank returns how well the item matches the lowercase query, lower being
+// better, or -1 when it doesn't match
+func (i searchItem) rank(query string) int {
+	name := strings.ToLower(i.name)
+	if i.kind == searchPipeline {
+		name = strings.ToLower(i.pipeline)
+	}
+	switch {
+	case query == "":
+		return 0
+	case name == query:
+		return 0
+	case strings.HasPrefix(name, query):
+		return 1
+	case strings.Contains(name, query):
+		return 2
+	case strings.Contains(strings.ToLower(i.path()), query), strings.Contains(strings.ToLower(i.detail), query),
+		strings.Contains(strings.ToLower(i.target), query):
+		return 3
+	}
+	return -1
+}
+
+// SearchViewModel searches every pipeline, job and resource of a target, or
+// of all logged in targets, at once, loading the jobs and resources of all
+// pipelines in the background
+type SearchViewModel struct {
+	configManager *config.ConfigManager
+	home          string // the target search was opened in, "" outside of one
+	all           bool   // searching every target rather than home
+	group         string // the target group all targets were searched in
+	searched      bool   // loaded at least once
+	load          int    // tells results of the current load from older ones
+	targets       int
+	listing       int              // targets whose pipelines are still being listed
+	skipped       []string         // targets not searched because they aren't logged in
+	targetErrors  map[string]error // targets whose pipelines failed to list, when searching all
+	pipelines     int
+	pending       int // pipelines whose jobs and resources are still loading
+	failed        int // pipelines whose jobs or resources failed to load
+	items         []searchItem
+	matches       []searchItem
+	query         string
+	semaphore     chan struct{}
+	selected      int
+	scrollOffset  int
+	maxVisible    int
+	err           error // listing the pipelines of home failed
+	canceled      bool  // loading was cut short by leaving the view
+	clicks        clickTracker
+	loader        loader
+}
+
+// SearchPipelinesMsg represents the listed pipelines of a target global
+// search looks in
+type SearchPipelinesMsg struct {
+	Load      int
+	Target    string
+	Pipelines []concourse.Pipeline
+	Error     error
+}
+
+// SearchItemsMsg represents the loaded jobs and resources of one pipeline
+type SearchItemsMsg struct {
+	Load      int
+	Target    string
+	Pipeline  string
+	Jobs      []concourse.Job
+	Resources []concourse.Resource
+	Error     error
+}
+
+// NewSearchViewModel creates a new global search model
+func NewSearchViewModel(configManager *config.ConfigManager) SearchViewModel {
+	return SearchViewModel{
+		configManager: configManager,
+		maxVisible:    10,
+		loader:        newLoader(),
+	}
+}
+
+// Open searches the target, or every target when it is "", unless it was
+// searched on an earlier visit and its pipelines, jobs and resources can be
+// reused
+func (m *SearchViewModel) Open(target string) tea.Cmd {
+	if m.searched && target == m.home && !m.stale() {
+		return nil
+	}
+	m.home = target
+	m.all = target == ""
+	m.query = ""
+	m.selected = 0
+	m.scrollOffset = 0
+	return m.Load()
+}
+
+// stale returns true when the last load failed or was cut short, or
+// searched all targets of another group
+func (m SearchViewModel) stale() bool {
+	return !m.searched || m.canceled || m.err != nil || (m.all && m.group != activeGroup)
+}
+
+// loading returns true while pipelines are being listed
+func (m SearchViewModel) loading() bool {
+	return m.listing > 0
+}
+
+// Load lists the pipelines of the searched targets, to then load their jobs
+// and resources. Searching all targets stays within the active group and
+// skips those that aren't logged in.
+func (m *SearchViewModel) Load() tea.Cmd {
+	targets := []string{m.home}
+	m.skipped = nil
+	m.group = activeGroup
+	if m.all {
+		targets = nil
+		for _, name := range m.configManager.GetTargetNames() {
+			if !inActiveGroup(name) {
+				continue
+			}
+			if target, _ := m.configManager.GetTarget(name); target.HasToken() && !target.TokenExpired() {
+				targets = append(targets, name)
+			} else {
+				m.skipped = append(m.skipped, name)
+			}
+		}
+	}
+
+	m.searched = true
+	m.load++
+	m.err = nil
+	m.canceled = false
+	m.targetErrors = make(map[string]error)
+	m.items = nil
+	m.targets = len(targets)
+	m.listing = len(targets)
+	m.pipelines = 0
+	m.pending = 0
+	m.failed = 0
+	m.filter()
+	m.semaphore = make(chan struct{}, searchConcurrency)
+
+	load := m.load
+	var cmds []tea.Cmd
+	for _, target := range targets {
+		target := target
+		cmds = append(cmds, func() tea.Msg {
+			pipelines, err := concourse.NewClient(target).GetPipelines(false)
+			return SearchPipelinesMsg{Load: load, Target: target, Pipelines: pipelines, Error: err}
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
+// HandleSearchPipelines lists the pipelines of a target and starts loading
+// their jobs and resources, a few pipelines at a time across targets
+func (m SearchViewModel) HandleSearchPipelines(msg SearchPipelinesMsg) (SearchViewModel, tea.Cmd) {
+	if msg.Load != m.load {
+		return m, nil
+	}
+	m.listing--
+	if msg.Error != nil {
+		m.canceled = m.canceled || concourse.IsCanceled(msg.Error)
+		if m.all {
+			m.targetErrors[msg.Target] = msg.Error
+		} else {
+			m.err = msg.Error
+		}
+		return m, nil
+	}
+
+	client, load, semaphore := concourse.NewClient(msg.Target), m.load, m.semaphore
+	var cmds []tea.Cmd
+	for _, pipeline := range msg.Pipelines {
+		detail := ""
+		if pipeline.Paused {
+			detail = "paused"
+		}
+		ref := pipeline.Ref()
+		m.items = append(m.items, searchItem{kind: searchPipeline, target: msg.Target, pipeline: ref, detail: detail})
+		cmds = append(cmds, func() tea.Msg {
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+			jobs, err := client.GetJobs(ref)
+			if err != nil {
+				return SearchItemsMsg{Load: load, Target: msg.Target, Pipeline: ref, Error: err}
+			}
+			resources, err := client.GetResources(ref)
+			return SearchItemsMsg{Load: load, Target: msg.Target, Pipeline: ref, Jobs: jobs, Resources: resources, Error: err}
+		})
+	}
+	m.pipelines += len(msg.Pipelines)
+	m.pending += len(msg.Pipelines)
+	m.filter()
+	return m, tea.Batch(cmds...)
+}
+
+// HandleSearchItems adds the jobs and resources of a pipeline that loaded
+func (m SearchViewModel) HandleSearchItems(msg SearchItemsMsg) SearchViewModel {
+	if msg.Load != m.load {
+		return m
+	}
+	m.pending--
+	if msg.Error != nil {
+		m.failed++
+		m.canceled = m.canceled || concourse.IsCanceled(msg.Error)
+	}
+	for _, job := range msg.Jobs {
+		m.items = append(m.items, searchItem{kind: searchJob, target: msg.Target, pipeline: msg.Pipeline, name: job.Name, status: job.FinishedBuild.Status})
+	}
+	for _, resource := range msg.Resources {
+		m.items = append(m.items, searchItem{kind: searchResource, target: msg.Target, pipeline: msg.Pipeline, name: resource.Name, detail: resource.Type})
+	}
+	m.filter()
+	return m
+}
+
+// filter picks the items matching the query, best matches first, keeping
+// the selected item selected while it still matches
+func (m *SearchViewModel) filter() {
+	var selected searchItem
+	if m.selected < len(m.matches) {
+		selected = m.matches[m.selected]
+	}
+
+	query := strings.ToLower(strings.TrimSpace(m.query))
+	ranks := make(map[searchItem]int)
+	m.matches = nil
+	for _, item := range m.items {
+		if rank := item.rank(query); rank >= 0 {
+			ranks[item] = rank
+			m.matches = append(m.matches, item)
+		}
+	}
+	sort.SliceStable(m.matches, func(i, j int) bool {
+		a, b := m.matches[i], m.matches[j]
+		if ranks[a] != ranks[b] {
+			return ranks[a] < ranks[b]
+		}
+		if a.kind != b.kind {
+			return a.kind < b.kind
+		}
+		if a.target != b.target {
+			return a.target < b.target
+		}
+		return a.path() < b.path()
+	})
+
+	m.selected = 0
+	for i, item := range m.matches {
+		if item == selected {
+			m.selected = i
+		}
+	}
+	m.scrollOffset = min(m.scrollOffset, m.selected)
+}
+
+// open jumps to the selected item: a pipeline's jobs, a job's builds or a
+// pipeline's resources with the resource selected
+func (m SearchViewModel) open() tea.Cmd {
+	if m.selected >= len(m.matches) {
+		return nil
+	}
+	item := m.matches[m.selected]
+	return func() tea.Msg {
+		switch item.kind {
+		case searchJob:
+			return SwitchViewMsg{View: ViewBuilds, Target: item.target, Pipeline: item.pipeline, Job: item.name}
+		case searchResource:
+			return SwitchViewMsg{View: ViewResources, Target: item.target, Pipeline: item.pipeline, Resource: item.name}
+		}
+		return SwitchViewMsg{View: ViewJobs, Target: item.target, Pipeline: item.pipeline}
+	}
+}
+
+// Update handles key presses for global search, where typing edits the query
+func (m SearchViewModel) Update(msg tea.KeyMsg) (SearchViewModel, tea.Cmd) {
+	switch msg.String() {
+	case "up", "ctrl+p":
+		if m.selected > 0 {
+			m.selected--
+			if m.selected < m.scrollOffset {
+				m.scrollOffset = m.selected
+			}
+		}
+	case "down", "ctrl+n":
+		if m.selected < len(m.matches)-1 {
+			m.selected++
+			if m.selected >= m.scrollOffset+m.maxVisible {
+				m.scrollOffset = m.selected - m.maxVisible + 1
+			}
+		}
+	case "enter":
+		return m, m.open()
+	case "esc":
+		if m.query == "" {
+			return m, navigateBack()
+		}
+		m.query = ""
+		m.filter()
+	case "backspace":
+		if len(m.query) > 0 {
+			m.query = m.query[:len(m.query)-1]
+			m.filter()
+		}
+	case "ctrl+u":
+		m.query = ""
+		m.filter()
+	case "tab":
+		// Widen the search to every target, or narrow it back down
+		if m.home != "" {
+			m.all = !m.all
+			cmd := m.Load()
+			return m, cmd
+		}
+	default:
+		if keys.Matches(msg, actionRefresh) && m.searched {
+			cmd := m.Load()
+			return m, cmd
+		}
+		if (msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace) && !msg.Alt {
+			m.query += msg.String()
+			m.filter()
+		}
+	}
+	return m, nil
+}
+
+// visibleRange returns the range of results shown for the given height
+func (m SearchViewModel) visibleRange(height int) (int, int) {
+	maxVisible := m.maxVisible
+	if height-searchResultsTop-4 > 0 { // Account for indicators and help
+		maxVisible = height - searchResultsTop - 4
+	}
+
+	start := m.scrollOffset
+	if m.selected >= start+maxVisible {
+		start = m.selected - maxVisible + 1
+	}
+	if m.selected < start {
+		start = m.selected
+	}
+	return start, min(start+maxVisible, len(m.matches))
+}
+
+// Mouse handles clicks and scrolling over the results
+func (m SearchViewModel) Mouse(msg tea.MouseMsg, height int) (SearchViewModel, tea.Cmd) {
+	start, end := m.visibleRange(height)
+	rows := listRows{top: searchResultsTop, height: 1, first: start, end: end}
+	if start > 0 {
+		rows.top++ // "more above" indicator
+	}
+
+	switch intent, index := m.clicks.interpret(msg, rows); intent {
+	case mouseScrollUp:
+		return m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	case mouseScrollDown:
+		return m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	case mouseClick:
+		m.selected = index
+	case mouseDoubleClick:
+		m.selected = index
+		return m, m.open()
+	}
+	return m, nil
+}
+
+// renderItem renders a result for its line of the list, after its target
+// when searching all of them
+func (m SearchViewModel) renderItem(item searchItem, targetWidth, width int) string {
+	mutedStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+	label := mutedStyle.Render(fmt.Sprintf("%-9s", searchKindLabels[item.kind]))
+	if m.all {
+		label = lipgloss.NewStyle().Foreground(theme.Info).Render(fmt.Sprintf("%-*s", targetWidth, item.target)) + " " + label
+	}
+
+	path := item.path()
+	if len(path) > width {
+		path = path[:max(width-1, 1)] + "…"
+	}
+	line := label + " " + path
+	if item.kind == searchJob {
+		line += " " + renderJobDots([]concourse.Job{{FinishedBuild: concourse.Build{Status: item.status}}}, 1)
+	}
+	if item.detail != "" {
+		line += mutedStyle.Render("  " + item.detail)
+	}
+	return line
+}
+
+// View renders global search
+func (m SearchViewModel) View(width, height int) string {
+	titleStyle := lipgloss.NewStyle().
+		Foreground(theme.Primary).
+		Bold(true).
+		MarginBottom(1)
+
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(theme.Muted)
+
+	searchStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Primary).
+		Padding(0, 1)
+
+	itemStyle := lipgloss.NewStyle().
+		PaddingLeft(2)
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(theme.Primary).
+		Bold(true).
+		PaddingLeft(1).
+		Border(lipgloss.NormalBorder(), false, false, false, true).
+		BorderForeground(theme.Primary)
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(theme.Muted).
+		Italic(true).
+		MarginTop(1)
+
+	var content strings.Builder
+	title := "Search - all targets"
+	if m.all && m.group != "" {
+		title = "Search - group " + m.group
+	}
+	if !m.all {
+		title = "Search - " + m.home
+	}
+	content.WriteString(titleStyle.Render(title))
+	content.WriteString("\n\n")
+	content.WriteString(searchStyle.Render("Search: " + m.query + "█"))
+	content.WriteString("\n")
+
+	if m.loading() && !m.all {
+		content.WriteString(m.loader.View("Loading pipelines...") + "\n")
+		return content.String()
+	}
+
+	if m.err != nil {
+		errorStyle := lipgloss.NewStyle().Foreground(theme.Error)
+		content.WriteString(errorStyle.Render("Error: " + errorSummary(m.err)))
+		content.WriteString("\n")
+		return content.String()
+	}
+
+	summary := fmt.Sprintf("%d matches in %d pipelines", len(m.matches), m.pipelines)
+	if m.all {
+		summary += fmt.Sprintf(" on %d targets", m.targets-len(m.targetErrors))
+	}
+	if m.failed > 0 {
+		summary += fmt.Sprintf(", %d failed to load", m.failed)
+	}
+	var notSearched []string
+	for _, name := range m.skipped {
+		notSearched = append(notSearched, name+" (not logged in)")
+	}
+	var failedTargets []string
+	for name := range m.targetErrors {
+		failedTargets = append(failedTargets, name)
+	}
+	sort.Strings(failedTargets)
+	for _, name := range failedTargets {
+		notSearched = append(notSearched, fmt.Sprintf("%s (%s)", name, errorSummary(m.targetErrors[name])))
+	}
+	if len(notSearched) > 0 {
+		summary += ", not searched: " + strings.Join(notSearched, ", ")
+	}
+	var progress string
+	switch {
+	case m.loading():
+		progress = m.loader.View(fmt.Sprintf("Listing pipelines, %d of %d targets done", m.targets-m.listing, m.targets)) + "  "
+	case m.pending > 0:
+		progress = m.loader.View(fmt.Sprintf("Loading jobs and resources, %d of %d pipelines done", m.pipelines-m.pending, m.pipelines)) + "  "
+	}
+	content.WriteString(ansi.Truncate(progress+mutedStyle.Render(summary), max(width-2, 10), "…"))
+	content.WriteString("\n\n")
+
+	switch {
+	case m.all && m.targets == 0:
+		content.WriteString("No logged in targets to search.\n")
+	case m.pipelines == 0 && !m.loading():
+		content.WriteString("No pipelines found.\n")
+	case len(m.matches) == 0 && m.pending == 0 && !m.loading():
+		content.WriteString("Nothing matches the search.\n")
+	default:
+		start, end := m.visibleRange(height)
+		if start > 0 {
+			content.WriteString(itemStyle.Render(fmt.Sprintf("  ↑ (%d more above)", start)))
+			content.WriteString("\n")
+		}
+		targetWidth := 0
+		for _, item := range m.matches[start:end] {
+			targetWidth = max(targetWidth, len(item.target))
+		}
+		for i := start; i < end; i++ {
+			line := m.renderItem(m.matches[i], targetWidth, max(width-30-targetWidth, 20))
+			if i == m.selected {
+				content.WriteString(selectedStyle.Render("> " + line))
+			} else {
+				content.WriteString(itemStyle.Render("  " + line))
+			}
+			content.WriteString("\n")
+		}
+		if end < len(m.matches) {
+			content.WriteString(itemStyle.Render(fmt.Sprintf("  ↓ (%d more below)", len(m.matches)-end)))
+			content.WriteString("\n")
+		}
+	}
+
+	help := "Type to search • ↑/↓: navigate • Enter: open • Ctrl+U: clear • Esc: back"
+	switch {
+	case m.home != "" && m.all:
+		help += " • Tab: only " + m.home
+	case m.home != "":
+		help += " • Tab: all targets"
+	}
+	content.WriteString(helpStyle.Render(help))
+
+	return content.String()
+}
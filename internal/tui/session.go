@@ -0,0 +1,150 @@
+package tui
+
+import (
+	"strings"
+
+	"flyby/internal/state"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// sessionViews are the views a session can be restored to, by the name the
+// state file uses
+var sessionViews = map[ViewType]string{
+	ViewPipelines: "pipelines",
+	ViewJobs:      "jobs",
+	ViewResources: "resources",
+	ViewBuilds:    "builds",
+}
+
+// restoreSessionMsg returns to where FlyBy was when it last quit
+type restoreSessionMsg struct {
+	Session state.Session
+}
+
+// currentSession returns where the user is, falling back to the deepest
+// breadcrumb level from views a session can't be restored to, or nil
+// outside of a target
+func (m *Model) currentSession() *state.Session {
+	levels := m.breadcrumbLevels()
+	if len(levels) == 0 {
+		return nil
+	}
+
+	session := &state.Session{Target: m.currentTarget}
+	view, ok := sessionViews[m.currentView]
+	if !ok {
+		level := levels[len(levels)-1]
+		for i := len(levels) - 1; i > 0; i-- {
+			if _, ok := sessionViews[levels[i].View]; ok {
+				break
+			}
+			level = levels[i-1]
+		}
+		session.View = sessionViews[level.View]
+		session.Pipeline = level.Pipeline
+		session.Job = level.Job
+		if m.currentView == ViewBuildLog {
+			session.Selected = m.currentBuild
+		}
+		return session
+	}
+
+	session.View = view
+	session.Pipeline = m.currentPipeline
+	switch m.currentView {
+	case ViewPipelines:
+		session.Pipeline = ""
+		session.Selected = m.pipelinesView.GetSelectedPipeline()
+	case ViewJobs:
+		if m.jobsView.selected < len(m.jobsView.filteredJobs) {
+			session.Selected = m.jobsView.filteredJobs[m.jobsView.selected].Name
+		}
+	case ViewResources:
+		if m.resourcesView.selected < len(m.resourcesView.filteredResources) {
+			session.Selected = m.resourcesView.filteredResources[m.resourcesView.selected].Name
+		}
+	case ViewBuilds:
+		session.Job = m.currentJob
+		if m.buildsView.cursor < len(m.buildsView.builds) {
+			session.Selected = m.buildsView.builds[m.buildsView.cursor].Name
+		}
+	}
+	return session
+}
+
+// saveSession remembers where the user is as FlyBy quits
+func (m *Model) saveSession() error {
+	return m.stateStore.SetSession(m.currentSession())
+}
+
+// describeSession returns the target › pipeline › job path of a session
+func describeSession(session state.Session) string {
+	path := []string{session.Target}
+	if session.Pipeline != "" {
+		path = append(path, session.Pipeline)
+	}
+	if session.Job != "" {
+		path = append(path, session.Job)
+	}
+	description := strings.Join(path, " › ")
+	if session.View == "resources" {
+		description += " resources"
+	}
+	return description
+}
+
+// offerSession asks whether to return to where FlyBy last quit, or returns
+// there straight away when the config file says to always do so
+func (m *Model) offerSession(session state.Session) tea.Cmd {
+	restore := func() tea.Msg {
+		return restoreSessionMsg{Session: session}
+	}
+	if m.settings.RestoreSession == "always" {
+		return restore
+	}
+	return confirmAction("Restore session", "Return to "+describeSession(session)+", where FlyBy was when it quit?", restore)
+}
+
+// restoreSession returns to a session, stacking the levels above it as if
+// the user had navigated down, with the cursor on the item of each level it
+// was last on
+func (m *Model) restoreSession(session state.Session) tea.Cmd {
+	var view ViewType
+	for viewType, name := range sessionViews {
+		if name == session.View {
+			view = viewType
+		}
+	}
+	if view == ViewMain {
+		return nil
+	}
+	if _, exists := m.configManager.GetTarget(session.Target); !exists {
+		return showToast(ToastError, "Can't restore the session, target %s is gone", session.Target)
+	}
+
+	m.navStack = []navEntry{{View: ViewMain}, {View: ViewTargets}}
+	m.pipelinesView.selectNext = session.Pipeline
+	if view != ViewPipelines {
+		m.navStack = append(m.navStack, navEntry{View: ViewPipelines, Target: session.Target})
+	}
+	if view == ViewBuilds {
+		m.navStack = append(m.navStack, navEntry{View: ViewJobs, Target: session.Target, Pipeline: session.Pipeline})
+		m.jobsView.selectNext = session.Job
+	}
+
+	restore := SwitchViewMsg{View: view, Target: session.Target, Pipeline: session.Pipeline, Job: session.Job, Replace: true}
+	switch view {
+	case ViewPipelines:
+		m.pipelinesView.selectNext = session.Selected
+	case ViewJobs:
+		m.jobsView.selectNext = session.Selected
+	case ViewResources:
+		restore.Resource = session.Selected
+	case ViewBuilds:
+		m.buildsView.selectNext = session.Selected
+	}
+	return func() tea.Msg {
+		return restore
+	}
+}
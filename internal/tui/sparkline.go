@@ -0,0 +1,36 @@
+package tui
+
+import "math"
+
+// sparklineLevels are the bars of a sparkline, lowest first
+var sparklineLevels = []rune("▁▂▃▄▅▆▇█")
+
+// asciiSparklineLevels stand in for the bars in ASCII-only mode
+var asciiSparklineLevels = []rune("_.,-=+*#")
+
+// sparkline returns a bar per value, scaled between the smallest and largest
+// value. Equal values all get a middle bar.
+func sparkline(values []float64) []rune {
+	levels := sparklineLevels
+	if asciiOnly {
+		levels = asciiSparklineLevels
+	}
+	if len(values) == 0 {
+		return nil
+	}
+
+	low, high := values[0], values[0]
+	for _, value := range values {
+		low, high = math.Min(low, value), math.Max(high, value)
+	}
+
+	bars := make([]rune, len(values))
+	for i, value := range values {
+		level := len(levels) / 2
+		if high > low {
+			level = int((value - low) / (high - low) * float64(len(levels)-1))
+		}
+		bars[i] = levels[level]
+	}
+	return bars
+}
@@ -0,0 +1,193 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"flyby/internal/concourse"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// SyncViewModel represents the prompt shown when fly is out of sync with a target
+type SyncViewModel struct {
+	target  string
+	client  *concourse.Client
+	cause   error
+	syncing bool
+	error   error
+	resume  SwitchViewMsg
+	loader  loader
+}
+
+// FlySyncedMsg represents the result of running fly sync and retrying the
+// command that failed because of the mismatch
+type FlySyncedMsg struct {
+	Target       string
+	Error        error
+	Retried      string // command line of the retried command, if any
+	RetryOutput  string
+	RetrySuccess bool
+	RetryError   error
+}
+
+// NewSyncViewModel creates a new sync view model
+func NewSyncViewModel() SyncViewModel {
+	return SyncViewModel{
+		loader: newLoader(),
+	}
+}
+
+// SetTarget sets the target to sync with, the error that revealed the
+// mismatch, and the view to resume after syncing
+func (m *SyncViewModel) SetTarget(target string, client *concourse.Client, cause error, resume SwitchViewMsg) {
+	m.target = target
+	m.client = client
+	m.cause = cause
+	m.resume = resume
+	m.syncing = false
+	m.error = nil
+}
+
+// StartSync runs fly sync for the target
+func (m *SyncViewModel) StartSync() tea.Cmd {
+	m.syncing = true
+	m.error = nil
+
+	client := m.client
+	target := m.target
+	return func() tea.Msg {
+		if err := client.Sync(); err != nil {
+			return FlySyncedMsg{Target: target, Error: err}
+		}
+
+		// Replay whatever fly refused to do, so the user doesn't have to
+		result := FlySyncedMsg{Target: target, Retried: client.PendingCommand()}
+		result.RetryOutput, result.RetrySuccess, result.RetryError = client.RetryPending()
+		return result
+	}
+}
+
+// Update handles messages for the sync view
+func (m SyncViewModel) Update(msg tea.KeyMsg) (SyncViewModel, tea.Cmd) {
+	if m.syncing {
+		// Don't handle keys while fly is being replaced
+		return m, nil
+	}
+
+	switch {
+	case keys.Matches(msg, actionSync):
+		cmd := m.StartSync()
+		return m, cmd
+	case keys.Matches(msg, actionCancel), keys.Matches(msg, actionBack):
+		// Go back to wherever we came from
+		return m, navigateBack()
+	}
+
+	return m, nil
+}
+
+// HandleSyncResult handles the fly sync result, resuming the interrupted view
+// when it succeeded
+func (m SyncViewModel) HandleSyncResult(msg FlySyncedMsg) (SyncViewModel, tea.Cmd) {
+	m.syncing = false
+	m.error = msg.Error
+	if msg.Error != nil {
+		return m, nil
+	}
+
+	cmds := []tea.Cmd{showToast(ToastSuccess, "fly synced with %s", msg.Target)}
+	switch {
+	case msg.Retried == "":
+	case msg.RetryError != nil:
+		cmds = append(cmds, showToast(ToastError, "Retrying %s failed: %v", msg.Retried, msg.RetryError))
+	case !msg.RetrySuccess:
+		cmds = append(cmds, showToast(ToastError, "Retried %s\n%s", msg.Retried, msg.RetryOutput))
+	default:
+		cmds = append(cmds, showToast(ToastSuccess, "Retried %s\n%s", msg.Retried, msg.RetryOutput))
+	}
+
+	resume := m.resume
+	cmds = append(cmds, func() tea.Msg {
+		return resume
+	})
+	return m, tea.Batch(cmds...)
+}
+
+// actionError returns the error of a fly action's result message, so actions
+// refused because fly is out of sync can offer a sync too
+func actionError(msg tea.Msg) error {
+	switch msg := msg.(type) {
+	case TriggerJobMsg:
+		return msg.Error
+	case BuildRerunResultMsg:
+		return msg.Error
+	case BuildAbortedMsg:
+		return msg.Error
+	case ResourceCheckMsg:
+		return msg.Error
+	case PipelineDestroyedMsg:
+		return msg.Error
+	}
+	return nil
+}
+
+// View renders the sync view
+func (m SyncViewModel) View(width, height int) string {
+	titleStyle := lipgloss.NewStyle().
+		Foreground(theme.Primary).
+		Bold(true).
+		MarginBottom(2)
+
+	contentStyle := lipgloss.NewStyle().
+		Padding(1).
+		MarginBottom(1).
+		Width(min(width-2, 100))
+
+	errorStyle := lipgloss.NewStyle().
+		Foreground(theme.Error).
+		Bold(true)
+
+	promptStyle := lipgloss.NewStyle().
+		Foreground(theme.Muted).
+		Italic(true)
+
+	var content strings.Builder
+
+	if m.syncing {
+		content.WriteString(m.loader.View(titleStyle.Render("Syncing fly...")))
+		content.WriteString("\n\n")
+		content.WriteString(contentStyle.Render(fmt.Sprintf("Downloading the fly version that matches %s.", m.target)))
+		return content.String()
+	}
+
+	if m.error != nil {
+		content.WriteString(titleStyle.Render("Sync Failed"))
+		content.WriteString("\n\n")
+		content.WriteString(errorStyle.Render("✗ " + errorSummary(m.error)))
+		content.WriteString("\n\n")
+		content.WriteString(promptStyle.Render(fmt.Sprintf("Press %s to retry, %s to go back, or %s to cancel", keys.Label(actionSync), keys.Label(actionCancel), keys.Label(actionBack))))
+		return content.String()
+	}
+
+	content.WriteString(titleStyle.Render("fly Is Out of Sync"))
+	content.WriteString("\n\n")
+	content.WriteString(contentStyle.Render(fmt.Sprintf("Target: %s", m.target)))
+	content.WriteString("\n")
+	if m.cause != nil {
+		detail := strings.TrimPrefix(m.cause.Error(), concourse.ErrOutOfSync.Error()+": ")
+		content.WriteString(contentStyle.Render(strings.TrimSpace(detail)))
+		content.WriteString("\n")
+	}
+	content.WriteString(contentStyle.Render("fly sync downloads the fly version matching this target and replaces the installed one."))
+	content.WriteString("\n")
+	if pending := m.client.PendingCommand(); pending != "" {
+		content.WriteString(contentStyle.Render("Afterwards FlyBy runs this again: " + pending))
+		content.WriteString("\n")
+	}
+	content.WriteString("\n")
+	content.WriteString(promptStyle.Render(fmt.Sprintf("Press %s to run fly sync and retry, %s to go back, or %s to cancel", keys.Label(actionSync), keys.Label(actionCancel), keys.Label(actionBack))))
+
+	return content.String()
+}
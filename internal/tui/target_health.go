@@ -0,0 +1,173 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	"flyby/internal/concourse"
+	"flyby/internal/config"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// healthTTL is how long a target's health is reused before checking again
+	healthTTL = time.Minute
+	// healthConcurrency bounds the number of targets checked at once
+	healthConcurrency = 4
+)
+
+// healthLevel summarizes how usable a target is
+type healthLevel int
+
+const (
+	healthUnknown  healthLevel = iota // not checked yet
+	healthOK                          // reachable and logged in
+	healthDegraded                    // reachable, but fly can't use it as is
+	healthDown                        // unreachable or not a Concourse
+)
+
+// targetHealth is the result of checking a target
+type targetHealth struct {
+	level    healthLevel
+	info     concourse.Info     // what the API reports about itself, when reachable
+	user     concourse.UserInfo // who fly is logged in as, when logged in
+	detail   string             // why the target isn't healthy
+	checking bool
+	checked  time.Time
+}
+
+// TargetHealthMsg carries the health of one target
+type TargetHealthMsg struct {
+	Target string
+	Health targetHealth
+}
+
+// CheckHealth checks every target without a fresh result, a few at a time.
+// force checks them all again, as on refresh.
+func (m TargetsViewModel) CheckHealth(force bool) tea.Cmd {
+	semaphore := make(chan struct{}, healthConcurrency)
+	var cmds []tea.Cmd
+	for _, target := range m.targets {
+		health := m.health[target.Name]
+		if health.checking || (!force && time.Since(health.checked) < healthTTL) {
+			continue
+		}
+		// Keep showing the previous result until the new one arrives
+		health.checking = true
+		m.health[target.Name] = health
+
+		target := target
+		cmds = append(cmds, func() tea.Msg {
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+			return TargetHealthMsg{Target: target.Name, Health: checkTargetHealth(target)}
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
+// HandleTargetHealth stores a target's health check result
+func (m TargetsViewModel) HandleTargetHealth(msg TargetHealthMsg) TargetsViewModel {
+	m.health[msg.Target] = msg.Health
+	return m
+}
+
+// checkTargetHealth asks the target's API for its version, then fly whether
+// the saved token still works
+func checkTargetHealth(target config.Target) targetHealth {
+	health := targetHealth{checked: time.Now()}
+	info, err := concourse.FetchInfo(target.GetURL(), concourse.TLSOptions{
+		Insecure:   target.Insecure,
+		CACertPEM:  target.CACert,
+		ClientCert: target.ClientCert,
+		ClientKey:  target.ClientKey,
+	})
+	if err != nil {
+		health.level = healthDown
+		health.detail = err.Error()
+		return health
+	}
+	health.info = info
+
+	switch {
+	case !target.HasToken():
+		health.level, health.detail = healthDegraded, "not logged in"
+	case target.TokenExpired():
+		health.level, health.detail = healthDegraded, "token expired"
+	default:
+		loggedIn, err := concourse.NewClient(target.Name).Status()
+		switch {
+		case concourse.IsCanceled(err):
+			// Unknown, checked again once the targets show
+			return targetHealth{}
+		case err != nil:
+			health.level, health.detail = healthDegraded, err.Error()
+		case !loggedIn:
+			health.level, health.detail = healthDegraded, "not logged in"
+		default:
+			health.level = healthOK
+			// The user is extra detail, the target is healthy without it
+			health.user, _ = concourse.NewClient(target.Name).Userinfo()
+		}
+	}
+	return health
+}
+
+// dot renders a target's health as a colored dot
+func (h targetHealth) dot() string {
+	switch h.level {
+	case healthOK:
+		return lipgloss.NewStyle().Foreground(theme.Success).Render("●")
+	case healthDegraded:
+		return lipgloss.NewStyle().Foreground(theme.Warning).Render("●")
+	case healthDown:
+		return lipgloss.NewStyle().Foreground(theme.Error).Render("●")
+	}
+	return lipgloss.NewStyle().Foreground(theme.Muted).Render("○")
+}
+
+// describeCluster lists the versions and URL the target's API reported, one
+// per line, or returns "" when it hasn't been reached
+func (h targetHealth) describeCluster() string {
+	if h.info.Version == "" {
+		return ""
+	}
+	text := "Concourse: " + h.info.Version
+	if h.info.WorkerVersion != "" {
+		text += fmt.Sprintf("\nWorker version: %s", h.info.WorkerVersion)
+	}
+	if h.info.ExternalURL != "" {
+		text += fmt.Sprintf("\nExternal URL: %s", h.info.ExternalURL)
+	}
+	return text
+}
+
+// describeUser names the logged-in user and their teams, or returns "" when
+// that isn't known
+func (h targetHealth) describeUser() string {
+	if h.user.Teams == nil {
+		return ""
+	}
+	return fmt.Sprintf("User: %s\nTeams: %s", h.user.DisplayName(), formatTeamRoles(h.user))
+}
+
+// describe explains a target's health for the details box
+func (h targetHealth) describe() string {
+	var text string
+	switch h.level {
+	case healthOK:
+		text = "Reachable, logged in"
+	case healthDegraded:
+		text = "Reachable, " + h.detail
+	case healthDown:
+		text = h.detail
+	default:
+		text = "Not checked yet"
+	}
+	if h.checking {
+		text += ", checking..."
+	}
+	return text
+}
@@ -2,14 +2,73 @@ package tui
 
 import (
 	"fmt"
+	"os"
 	"strings"
+	"time"
 
+	"flyby/internal/concourse"
 	"flyby/internal/config"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
+// formatTokenExpiry describes when the target's token expires, e.g. "token
+// expires in 2h", or returns "" when that isn't known
+func formatTokenExpiry(target config.Target) string {
+	expiry, ok := target.TokenExpiry()
+	if !ok {
+		return ""
+	}
+	
+	remaining := time.Until(expiry)
+	switch {
+	case remaining <= 0:
+		return "token expired"
+	case remaining < time.Hour:
+		return fmt.Sprintf("token expires in %dm", int(remaining.Minutes())+1)
+	case remaining < 48*time.Hour:
+		return fmt.Sprintf("token expires in %dh", int(remaining.Hours()))
+	default:
+		return fmt.Sprintf("token expires in %dd", int(remaining.Hours()/24))
+	}
+}
+
+// describeTokenExpiry returns the details box line saying when the target's
+// token expires or expired, in local time
+func describeTokenExpiry(target config.Target) string {
+	expiry, ok := target.TokenExpiry()
+	if !ok {
+		return "Expires: unknown, the token carries no expiry"
+	}
+	line := fmt.Sprintf("Expires: %s (%s)", expiry.Local().Format("2006-01-02 15:04 MST"), strings.TrimPrefix(formatTokenExpiry(target), "token expires "))
+	if target.TokenExpired() {
+		line = fmt.Sprintf("Expired: %s, log in again", expiry.Local().Format("2006-01-02 15:04 MST"))
+	}
+	return tokenExpiryStyle(target).Render(line)
+}
+
+// maskToken shortens a token to its first and last few characters, enough to
+// tell tokens apart without showing one
+func maskToken(value string) string {
+	if len(value) <= 16 {
+		return "…"
+	}
+	return value[:6] + "…" + value[len(value)-4:]
+}
+
+// tokenExpiryStyle colors a token expiry by how soon it needs attention
+func tokenExpiryStyle(target config.Target) lipgloss.Style {
+	expiry, _ := target.TokenExpiry()
+	switch remaining := time.Until(expiry); {
+	case remaining <= 0:
+		return lipgloss.NewStyle().Foreground(theme.Error)
+	case remaining < time.Hour:
+		return lipgloss.NewStyle().Foreground(theme.Warning)
+	}
+	return lipgloss.NewStyle().Foreground(theme.Muted)
+}
+
 // min returns the smaller of two integers
 func min(a, b int) int {
 	if a < b {
@@ -29,27 +88,41 @@ type TargetsViewModel struct {
 	maxVisible    int
 	searchQuery   string
 	searchMode    bool
+	marked        map[string]bool // targets to list the pipelines of together
+	clicks        clickTracker
+	health        map[string]targetHealth
+	teamPicker    *teamPicker
+	backupPicker  *backupPicker
+	clipboardCommand string
 }
 
 // NewTargetsViewModel creates a new targets view model
-func NewTargetsViewModel(configManager *config.ConfigManager) TargetsViewModel {
+func NewTargetsViewModel(configManager *config.ConfigManager, clipboardCommand string) TargetsViewModel {
 	vm := TargetsViewModel{
 		configManager: configManager,
+		clipboardCommand: clipboardCommand,
 		selected:      0,
 		showingDetail: false,
 		scrollOffset:  0,
 		maxVisible:    10, // Show max 10 items at once
 		searchQuery:   "",
 		searchMode:    false,
+		marked:        make(map[string]bool),
+		health:        make(map[string]targetHealth),
 	}
 	vm.loadTargets()
 	return vm
 }
 
-// loadTargets loads targets from configuration
+// loadTargets loads the targets of the active group from configuration,
+// sorted by name
 func (m *TargetsViewModel) loadTargets() {
 	m.targets = nil
-	for name, target := range m.configManager.GetTargets() {
+	for _, name := range m.configManager.GetTargetNames() {
+		if !inActiveGroup(name) {
+			continue
+		}
+		target, _ := m.configManager.GetTarget(name)
 		// Ensure the target has its name set correctly
 		target.Name = name
 		m.targets = append(m.targets, target)
@@ -57,6 +130,25 @@ func (m *TargetsViewModel) loadTargets() {
 	m.filterTargets()
 }
 
+// reloadTargets loads the targets again, staying on the selected one
+func (m *TargetsViewModel) reloadTargets() {
+	selected := ""
+	if m.selected < len(m.filteredTargets) {
+		selected = m.filteredTargets[m.selected].Name
+	}
+	m.loadTargets()
+	for i, target := range m.filteredTargets {
+		if target.Name == selected {
+			m.selected = i
+		}
+	}
+	for name := range m.marked {
+		if _, exists := m.configManager.GetTarget(name); !exists {
+			delete(m.marked, name)
+		}
+	}
+}
+
 // filterTargets filters targets based on the current search query
 func (m *TargetsViewModel) filterTargets() {
 	if m.searchQuery == "" {
@@ -113,9 +205,16 @@ func (m TargetsViewModel) Update(msg tea.KeyMsg) (TargetsViewModel, tea.Cmd) {
 		return m, nil
 	}
 	
+	if m.teamPicker != nil {
+		return m.updateTeamPicker(msg)
+	}
+	if m.backupPicker != nil {
+		return m.updateBackupPicker(msg)
+	}
+	
 	// Handle normal navigation mode
-	switch msg.String() {
-	case "up", "k":
+	switch {
+	case keys.Matches(msg, actionUp):
 		if m.selected > 0 {
 			m.selected--
 			// Adjust scroll if needed
@@ -123,7 +222,7 @@ func (m TargetsViewModel) Update(msg tea.KeyMsg) (TargetsViewModel, tea.Cmd) {
 				m.scrollOffset = m.selected
 			}
 		}
-	case "down", "j":
+	case keys.Matches(msg, actionDown):
 		if m.selected < len(m.filteredTargets)-1 {
 			m.selected++
 			// Adjust scroll if needed
@@ -131,26 +230,122 @@ func (m TargetsViewModel) Update(msg tea.KeyMsg) (TargetsViewModel, tea.Cmd) {
 				m.scrollOffset = m.selected - m.maxVisible + 1
 			}
 		}
-	case "enter":
+	case keys.Matches(msg, actionSelect):
 		if len(m.filteredTargets) > 0 {
 			return m, m.selectTarget()
 		}
-	case "a":
+	case keys.Matches(msg, actionMark):
+		if len(m.filteredTargets) > 0 {
+			name := m.filteredTargets[m.selected].Name
+			if m.marked[name] {
+				delete(m.marked, name)
+			} else {
+				m.marked[name] = true
+			}
+		}
+	case keys.Matches(msg, actionAggregate):
+		return m, m.aggregateTargets()
+	case keys.Matches(msg, actionAdd):
 		return m, func() tea.Msg {
 			return SwitchViewMsg{View: ViewAddTarget}
 		}
-	case "d":
+	case keys.Matches(msg, actionEdit):
 		if len(m.filteredTargets) > 0 {
-			return m, m.deleteTarget()
+			target := m.filteredTargets[m.selected]
+			return m, func() tea.Msg {
+				return EditTargetMsg{Target: target}
+			}
 		}
-	case "i":
+	case keys.Matches(msg, actionDuplicate):
+		if len(m.filteredTargets) > 0 {
+			target := m.filteredTargets[m.selected]
+			return m, func() tea.Msg {
+				return DuplicateTargetMsg{Target: target}
+			}
+		}
+	case keys.Matches(msg, actionSwitchTeam):
+		if len(m.filteredTargets) > 0 {
+			return m.openTeamPicker()
+		}
+	case keys.Matches(msg, actionTeams):
+		if len(m.filteredTargets) > 0 {
+			target := m.filteredTargets[m.selected].Name
+			return m, func() tea.Msg {
+				return SwitchViewMsg{View: ViewTeams, Target: target}
+			}
+		}
+	case keys.Matches(msg, actionActiveUsers):
+		if len(m.filteredTargets) > 0 {
+			target := m.filteredTargets[m.selected].Name
+			return m, func() tea.Msg {
+				return SwitchViewMsg{View: ViewActiveUsers, Target: target}
+			}
+		}
+	case keys.Matches(msg, actionDelete):
+		if len(m.filteredTargets) > 0 {
+			return m, m.confirmDelete()
+		}
+	case keys.Matches(msg, actionLogout):
+		if len(m.filteredTargets) > 0 {
+			return m, m.logout()
+		}
+	case keys.Matches(msg, actionRestoreFlyrc):
+		return m.openBackupPicker()
+	case keys.Matches(msg, actionCopy):
+		if len(m.filteredTargets) > 0 {
+			return m, m.copyToken()
+		}
+	case keys.Matches(msg, actionDetails):
 		m.showingDetail = !m.showingDetail
-	case "/", "s":
+	case keys.Matches(msg, actionSearch):
 		m.searchMode = true
-	case "F5":
+	case keys.Matches(msg, actionRefresh):
 		m.loadTargets()
+		return m, m.CheckHealth(true)
+	}
+	
+	return m, nil
+}
+
+// visibleRange returns the range of targets shown for the given height
+func (m TargetsViewModel) visibleRange(height int) (int, int) {
+	maxVisible := m.maxVisible
+	if height-10 > 0 { // Account for title, search box, header, footer, details
+		maxVisible = min(height-10, len(m.filteredTargets))
+	}
+	
+	// Adjust maxVisible if showing details
+	if m.showingDetail {
+		maxVisible = min(maxVisible-6, len(m.filteredTargets)) // Leave space for details
+	}
+	
+	start := m.scrollOffset
+	return start, min(start+maxVisible, len(m.filteredTargets))
+}
+
+// Mouse handles clicks and scrolling over the targets list
+func (m TargetsViewModel) Mouse(msg tea.MouseMsg, height int) (TargetsViewModel, tea.Cmd) {
+	if m.searchMode || m.teamPicker != nil || m.backupPicker != nil {
+		return m, nil
 	}
 	
+	start, end := m.visibleRange(height)
+	rows := listRows{top: searchListTop, height: 2, first: start, end: end}
+	if start > 0 {
+		rows.top += 2 // "more above" indicator
+	}
+	
+	switch intent, index := m.clicks.interpret(msg, rows); intent {
+	case mouseScrollUp:
+		return m.Update(keyMsgFor(actionUp))
+	case mouseScrollDown:
+		return m.Update(keyMsgFor(actionDown))
+	case mouseClick:
+		m.selected = index
+	case mouseDoubleClick:
+		m.selected = index
+		return m.Update(keyMsgFor(actionSelect))
+	}
 	return m, nil
 }
 
@@ -166,27 +361,226 @@ func (m TargetsViewModel) selectTarget() tea.Cmd {
 	}
 }
 
-// deleteTarget deletes the selected target
-func (m TargetsViewModel) deleteTarget() tea.Cmd {
+// DeleteTargetMsg asks the targets view to remove a confirmed target
+type DeleteTargetMsg struct {
+	Name string
+}
+
+// TargetLoggedOutMsg represents the result of fly logout for a target
+type TargetLoggedOutMsg struct {
+	Target string
+	Delete bool // remove the target once logged out
+	Error  error
+}
+
+// aggregateTargets lists the pipelines of the marked targets together, or
+// of every target of the active group logged in to when none are marked
+func (m TargetsViewModel) aggregateTargets() tea.Cmd {
+	var names []string
+	for _, name := range m.configManager.GetTargetNames() {
+		target, _ := m.configManager.GetTarget(name)
+		if m.marked[name] || (len(m.marked) == 0 && inActiveGroup(name) && target.HasToken() && !target.TokenExpired()) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return showToast(ToastError, "Mark targets with space to list their pipelines together")
+	}
+	return func() tea.Msg {
+		return SwitchViewMsg{View: ViewAggregate, Targets: names}
+	}
+}
+
+// confirmDelete asks the user before deleting the selected target
+func (m TargetsViewModel) confirmDelete() tea.Cmd {
 	if len(m.filteredTargets) == 0 {
 		return nil
 	}
 	
+	name := m.filteredTargets[m.selected].Name
+	message := fmt.Sprintf("Remove target '%s'?", name)
+	if path := m.configManager.GetConfigPath(); path != "" {
+		message = fmt.Sprintf("Remove target '%s' from %s?", name, path)
+	}
+	message += fmt.Sprintf(" %s removes it only, %s runs fly logout for it first.", keys.Label(actionConfirm), keys.Label(actionLogout))
+	return confirmActionOr("Delete target", message,
+		func() tea.Msg {
+			return DeleteTargetMsg{Name: name}
+		},
+		actionLogout,
+		func() tea.Msg {
+			return TargetLoggedOutMsg{Target: name, Delete: true, Error: concourse.NewClient(name).Logout()}
+		})
+}
+
+// copyToken copies the selected target's token to the clipboard, e.g. to
+// call the Concourse API with curl
+func (m TargetsViewModel) copyToken() tea.Cmd {
 	target := m.filteredTargets[m.selected]
-	err := m.configManager.RemoveTarget(target.Name)
-	if err == nil {
-		m.loadTargets()
-		// Adjust selected and scroll position
-		if m.selected >= len(m.filteredTargets) && len(m.filteredTargets) > 0 {
-			m.selected = len(m.filteredTargets) - 1
+	if !target.HasToken() {
+		return showToast(ToastInfo, "%s isn't logged in", target.Name)
+	}
+	if err := copyToClipboard(m.clipboardCommand, target.GetTokenValue()); err != nil {
+		return showToast(ToastError, "Failed to copy token: %v", err)
+	}
+	return showToast(ToastSuccess, "Copied the %s token of %s", target.Token.Type, target.Name)
+}
+
+// logout runs fly logout for the selected target, keeping the target
+func (m TargetsViewModel) logout() tea.Cmd {
+	target := m.filteredTargets[m.selected]
+	if !target.HasToken() {
+		return showToast(ToastInfo, "%s isn't logged in", target.Name)
+	}
+	return func() tea.Msg {
+		return TargetLoggedOutMsg{Target: target.Name, Error: concourse.NewClient(target.Name).Logout()}
+	}
+}
+
+// HandleDeleteTarget deletes a target once the user has confirmed it
+func (m TargetsViewModel) HandleDeleteTarget(msg DeleteTargetMsg) (TargetsViewModel, tea.Cmd) {
+	if err := m.configManager.RemoveTarget(msg.Name); err != nil {
+		return m, showToast(ToastError, "Failed to delete target: %v", err)
+	}
+	m.forgetTarget(msg.Name)
+	return m, showToast(ToastSuccess, "Deleted target %s", msg.Name)
+}
+
+// HandleTargetLoggedOut picks up the cleared token of a target fly logged out
+// of, or deletes the target when asked to
+func (m TargetsViewModel) HandleTargetLoggedOut(msg TargetLoggedOutMsg) (TargetsViewModel, tea.Cmd) {
+	if msg.Error != nil {
+		if msg.Delete {
+			return m, showToast(ToastError, "Kept target %s, fly logout failed: %s", msg.Target, errorSummary(msg.Error))
+		}
+		return m, showToast(ToastError, "Failed to log out of %s: %s", msg.Target, errorSummary(msg.Error))
+	}
+
+	m.configManager.LoadConfig()
+	if !msg.Delete {
+		// fly cleared the token, make sure it shows even if the flyrc couldn't be reread
+		if target, exists := m.configManager.GetTarget(msg.Target); exists && target.HasToken() {
+			err := m.configManager.EditTarget(msg.Target, func(target *config.Target) {
+				target.Token = nil
+			})
+			if err != nil {
+				return m, showToast(ToastError, "Failed to clear the token of %s: %v", msg.Target, err)
+			}
+		}
+		m.reloadTargets()
+		delete(m.health, msg.Target)
+		return m, tea.Batch(
+			showToast(ToastSuccess, "Logged out of %s", msg.Target),
+			m.CheckHealth(false),
+		)
+	}
+	if _, exists := m.configManager.GetTarget(msg.Target); exists {
+		if err := m.configManager.RemoveTarget(msg.Target); err != nil {
+			return m, showToast(ToastError, "Logged out of %s but failed to delete it: %v", msg.Target, err)
+		}
+	}
+	m.forgetTarget(msg.Target)
+	return m, showToast(ToastSuccess, "Logged out of %s and deleted it", msg.Target)
+}
+
+// forgetTarget drops a deleted target from the list, keeping the selection
+// within it
+func (m *TargetsViewModel) forgetTarget(name string) {
+	delete(m.marked, name)
+	m.loadTargets()
+	// Adjust selected and scroll position
+	if m.selected >= len(m.filteredTargets) && len(m.filteredTargets) > 0 {
+		m.selected = len(m.filteredTargets) - 1
+	}
+	// Adjust scroll offset if needed
+	if m.scrollOffset > 0 && m.selected < m.scrollOffset {
+		m.scrollOffset = max(0, m.scrollOffset-1)
+	}
+}
+
+// EditTargetMsg asks to open the add target form filled with a target to edit
+type EditTargetMsg struct {
+	Target config.Target
+}
+
+// DuplicateTargetMsg asks to open the add target form with a target's URL and
+// TLS settings, to add another team of the same Concourse
+type DuplicateTargetMsg struct {
+	Target config.Target
+}
+
+// HandleUpdateTarget saves an edited target and offers to log in to it again,
+// since a changed URL, team or certificate makes the saved token useless
+func (m TargetsViewModel) HandleUpdateTarget(msg UpdateTargetMsg) (TargetsViewModel, tea.Cmd) {
+	// The flyrc holds the CA cert itself, fly only reads the file at login
+	var caCert []byte
+	if msg.TLS.CACert != "" {
+		var err error
+		if caCert, err = os.ReadFile(msg.TLS.CACert); err != nil {
+			return m, showToast(ToastError, "Failed to update target %s: %v", msg.Original, err)
 		}
-		// Adjust scroll offset if needed
-		if m.scrollOffset > 0 && m.selected < m.scrollOffset {
-			m.scrollOffset = max(0, m.scrollOffset-1)
+	}
+	
+	err := m.configManager.RenameAndEditTarget(msg.Original, msg.Name, func(target *config.Target) {
+		target.API = msg.URL
+		target.Team = msg.Team
+		target.Insecure = msg.TLS.Insecure
+		if caCert != nil {
+			target.CACert = string(caCert)
 		}
+		if msg.TLS.ClientCert != "" {
+			target.ClientCert = msg.TLS.ClientCert
+			target.ClientKey = msg.TLS.ClientKey
+		}
+	})
+	if err != nil {
+		return m, showToast(ToastError, "Failed to update target %s, it is unchanged: %v", msg.Original, err)
 	}
+	m.loadTargets()
+	// The old health says nothing about a changed URL
+	delete(m.health, msg.Original)
+	delete(m.health, msg.Name)
 	
-	return nil
+	command := concourse.CommandRecord{Args: append([]string{"fly", "-t", msg.Name}, concourse.LoginArgs(msg.URL, msg.Team, msg.TLS)...)}.Command()
+	login := tea.Exec(concourse.NewClient(msg.Name).LoginInteractiveTLS(msg.URL, msg.Team, msg.TLS), func(err error) tea.Msg {
+		return TargetLoginMsg{Target: msg.Name, Error: err}
+	})
+	var renamed tea.Cmd
+	if msg.Name != msg.Original {
+		renamed = func() tea.Msg {
+			return TargetRenamedMsg{From: msg.Original, To: msg.Name}
+		}
+	}
+	return m, tea.Batch(
+		showToast(ToastSuccess, "Updated target %s", msg.Name),
+		renamed,
+		m.CheckHealth(false),
+		confirmAction("Log in again", fmt.Sprintf("Log in to '%s' now with %s?", msg.Name, command), login),
+	)
+}
+
+// TargetRenamedMsg tells that a target was saved under a new name, so what
+// FlyBy remembers about it moves along
+type TargetRenamedMsg struct {
+	From string
+	To   string
+}
+
+// TargetLoginMsg represents the result of logging in again after an edit
+type TargetLoginMsg struct {
+	Target string
+	Error  error
+}
+
+// HandleTargetLogin reports the new login and picks up the token fly saved
+func (m TargetsViewModel) HandleTargetLogin(msg TargetLoginMsg) (TargetsViewModel, tea.Cmd) {
+	if msg.Error != nil {
+		return m, showToast(ToastError, "Failed to log in to %s: %s", msg.Target, errorSummary(msg.Error))
+	}
+	
+	m.configManager.LoadConfig()
+	m.loadTargets()
+	return m, showToast(ToastSuccess, "Logged in to %s", msg.Target)
 }
 
 // max returns the larger of two integers
@@ -200,7 +594,7 @@ func max(a, b int) int {
 // View renders the targets view
 func (m TargetsViewModel) View(width, height int) string {
 	titleStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("205")).
+		Foreground(theme.Primary).
 		Bold(true).
 		MarginBottom(1)
 	
@@ -209,23 +603,27 @@ func (m TargetsViewModel) View(width, height int) string {
 		MarginBottom(1)
 		
 	selectedStyle := itemStyle.Copy().
-		Foreground(lipgloss.Color("205")).
+		Foreground(theme.Primary).
 		Bold(true).
 		PaddingLeft(1).
 		Border(lipgloss.NormalBorder(), false, false, false, true).
-		BorderForeground(lipgloss.Color("205"))
+		BorderForeground(theme.Primary)
 	
 	searchStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("240")).
+		BorderForeground(theme.Muted).
 		Padding(0, 1).
 		MarginBottom(1)
 	
 	searchActiveStyle := searchStyle.Copy().
-		BorderForeground(lipgloss.Color("205"))
+		BorderForeground(theme.Primary)
 	
 	var content strings.Builder
-	content.WriteString(titleStyle.Render("Manage Targets"))
+	title := "Manage Targets"
+	if activeGroup != "" {
+		title += " - group " + activeGroup
+	}
+	content.WriteString(titleStyle.Render(title))
 	content.WriteString("\n\n")
 	
 	// Add search box
@@ -246,25 +644,15 @@ func (m TargetsViewModel) View(width, height int) string {
 	if len(m.filteredTargets) == 0 {
 		if m.searchQuery != "" {
 			content.WriteString("No targets match search query.\n")
+		} else if activeGroup != "" {
+			content.WriteString(fmt.Sprintf("None of group %s's targets are configured. Press %s for another group.\n", activeGroup, keys.Label(actionTargetGroup)))
 		} else {
 			content.WriteString("No targets configured. Press 'a' to add a new target.\n")
 		}
 		return content.String()
 	}
 
-	// Calculate visible range
-	maxVisible := m.maxVisible
-	if height-10 > 0 { // Account for title, search box, header, footer, details
-		maxVisible = min(height-10, len(m.filteredTargets))
-	}
-	
-	// Adjust maxVisible if showing details
-	if m.showingDetail {
-		maxVisible = min(maxVisible-6, len(m.filteredTargets)) // Leave space for details
-	}
-	
-	start := m.scrollOffset
-	end := min(start+maxVisible, len(m.filteredTargets))
+	start, end := m.visibleRange(height)
 	
 	// Add scroll indicator at top
 	if start > 0 {
@@ -281,6 +669,21 @@ func (m TargetsViewModel) View(width, height int) string {
 		} else {
 			line = fmt.Sprintf("%s (%s)", target.Name, target.Team)
 		}
+		health := m.health[target.Name]
+		line = health.dot() + " " + line
+		if health.info.Version != "" {
+			line += " " + lipgloss.NewStyle().Foreground(theme.Muted).Render("• v"+health.info.Version)
+		}
+		if expiry := formatTokenExpiry(target); expiry != "" {
+			line += " " + tokenExpiryStyle(target).Render("• "+expiry)
+		}
+		if len(m.marked) > 0 {
+			if m.marked[target.Name] {
+				line = "[x] " + line
+			} else {
+				line = "[ ] " + line
+			}
+		}
 		
 		if i == m.selected {
 			content.WriteString(selectedStyle.Render("> " + line))
@@ -301,34 +704,55 @@ func (m TargetsViewModel) View(width, height int) string {
 		content.WriteString("\n")
 		detailStyle := lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("240")).
+			BorderForeground(theme.Muted).
 			Padding(1).
 			MarginTop(1)
 		
 		target := m.filteredTargets[m.selected]
-		details := fmt.Sprintf("Target: %s\nTeam: %s\nAPI: %s\nToken: %s", 
-			target.Name, target.Team, target.GetURL(), 
+		details := fmt.Sprintf("Target: %s\nTeam: %s\nAPI: %s\nHealth: %s\nToken: %s", 
+			target.Name, target.Team, target.GetURL(), m.health[target.Name].describe(), 
 			func() string {
-				if target.HasToken() {
-					return "Present"
+				if !target.HasToken() {
+					return "Not set"
 				}
-				return "Not set"
+				token := strings.TrimSpace(target.Token.Type + " " + maskToken(target.GetTokenValue()))
+				return token + fmt.Sprintf(" (%s: copy)", keys.Label(actionCopy))
 			}())
+		if target.HasToken() {
+			details += "\n" + describeTokenExpiry(target)
+		}
+		if user := m.health[target.Name].describeUser(); user != "" {
+			details += "\n" + user
+		}
+		if cluster := m.health[target.Name].describeCluster(); cluster != "" {
+			details += "\n" + cluster
+		}
 		
 		content.WriteString(detailStyle.Render(details))
 	}
 	
 	// Help text
 	helpStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("240")).
+		Foreground(theme.Muted).
 		Italic(true).
 		MarginTop(1)
 	
+	if m.teamPicker != nil {
+		content.WriteString(m.viewTeamPicker())
+		content.WriteString("\n")
+	}
+	if m.backupPicker != nil {
+		content.WriteString(m.viewBackupPicker())
+		content.WriteString("\n")
+	}
+	
 	var help string
 	if m.searchMode {
 		help = "Enter: finish search • Esc: cancel search • Ctrl+U: clear"
+	} else if m.teamPicker != nil || m.backupPicker != nil {
+		help = keys.HelpLine(actionUp, actionDown, actionSelect, actionBack)
 	} else {
-		help = "↑/↓: navigate • Enter: select • a: add • d: delete • i: toggle details • /,s: search • F5: refresh • Esc: back"
+		help = keys.HelpLine(viewKeys[ViewTargets]...)
 	}
 	content.WriteString(helpStyle.Render(help))
 	
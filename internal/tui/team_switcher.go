@@ -0,0 +1,180 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"flyby/internal/concourse"
+	"flyby/internal/config"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// teamPicker lists the teams of the user logged in to a target, to log in to
+// another one of them
+type teamPicker struct {
+	target   string
+	current  string // the team the target is logged in to
+	user     concourse.UserInfo
+	teams    []string
+	selected int
+	loading  bool
+}
+
+// TargetTeamsMsg carries the teams the user of a target belongs to
+type TargetTeamsMsg struct {
+	Target string
+	User   concourse.UserInfo
+	Error  error
+}
+
+// TeamSwitchedMsg represents the result of logging a target in to another team
+type TeamSwitchedMsg struct {
+	Target string
+	Team   string
+	Error  error
+}
+
+// openTeamPicker starts loading the teams of the selected target's user
+func (m TargetsViewModel) openTeamPicker() (TargetsViewModel, tea.Cmd) {
+	target := m.filteredTargets[m.selected]
+	m.teamPicker = &teamPicker{target: target.Name, current: target.Team, loading: true}
+	return m, func() tea.Msg {
+		user, err := concourse.NewClient(target.Name).Userinfo()
+		return TargetTeamsMsg{Target: target.Name, User: user, Error: err}
+	}
+}
+
+// HandleTargetTeams fills the team picker with the user's teams
+func (m TargetsViewModel) HandleTargetTeams(msg TargetTeamsMsg) (TargetsViewModel, tea.Cmd) {
+	if m.teamPicker == nil || m.teamPicker.target != msg.Target {
+		return m, nil
+	}
+	if msg.Error != nil {
+		m.teamPicker = nil
+		return m, showToast(ToastError, "Failed to list teams for %s: %s", msg.Target, errorSummary(msg.Error))
+	}
+
+	picker := *m.teamPicker
+	picker.loading = false
+	picker.user = msg.User
+	picker.teams = nil
+	for team := range msg.User.Teams {
+		picker.teams = append(picker.teams, team)
+	}
+	sort.Strings(picker.teams)
+	for i, team := range picker.teams {
+		if team == picker.current {
+			picker.selected = i
+		}
+	}
+	m.teamPicker = &picker
+	return m, nil
+}
+
+// updateTeamPicker handles keys while the team picker is open
+func (m TargetsViewModel) updateTeamPicker(msg tea.KeyMsg) (TargetsViewModel, tea.Cmd) {
+	picker := *m.teamPicker
+	switch {
+	case keys.Matches(msg, actionBack):
+		m.teamPicker = nil
+		return m, nil
+	case picker.loading:
+		return m, nil
+	case keys.Matches(msg, actionUp):
+		if picker.selected > 0 {
+			picker.selected--
+		}
+	case keys.Matches(msg, actionDown):
+		if picker.selected < len(picker.teams)-1 {
+			picker.selected++
+		}
+	case keys.Matches(msg, actionSelect):
+		m.teamPicker = nil
+		if len(picker.teams) == 0 {
+			return m, nil
+		}
+		team := picker.teams[picker.selected]
+		if team == picker.current {
+			return m, showToast(ToastInfo, "%s is already logged in to team %s", picker.target, team)
+		}
+		return m, switchTeam(picker.target, team)
+	}
+	m.teamPicker = &picker
+	return m, nil
+}
+
+// switchTeam logs a target in to another team, keeping its URL
+func switchTeam(target, team string) tea.Cmd {
+	return tea.Exec(concourse.NewClient(target).SwitchTeam(team), func(err error) tea.Msg {
+		return TeamSwitchedMsg{Target: target, Team: team, Error: err}
+	})
+}
+
+// HandleTeamSwitched picks up the target's new team and token
+func (m TargetsViewModel) HandleTeamSwitched(msg TeamSwitchedMsg) (TargetsViewModel, tea.Cmd) {
+	if msg.Error != nil {
+		return m, showToast(ToastError, "Failed to switch %s to team %s: %s", msg.Target, msg.Team, errorSummary(msg.Error))
+	}
+
+	// fly saved the new team, make sure it shows even if the flyrc couldn't be reread
+	m.configManager.LoadConfig()
+	if target, exists := m.configManager.GetTarget(msg.Target); exists && target.Team != msg.Team {
+		err := m.configManager.EditTarget(msg.Target, func(target *config.Target) {
+			target.Team = msg.Team
+		})
+		if err != nil {
+			return m, showToast(ToastError, "Failed to save team of %s: %v", msg.Target, err)
+		}
+	}
+	m.loadTargets()
+	delete(m.health, msg.Target)
+	return m, tea.Batch(
+		showToast(ToastSuccess, "Switched %s to team %s", msg.Target, msg.Team),
+		m.CheckHealth(false),
+	)
+}
+
+// viewTeamPicker renders the team picker below the targets list
+func (m TargetsViewModel) viewTeamPicker() string {
+	picker := m.teamPicker
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Primary).
+		Padding(0, 1).
+		MarginTop(1)
+	titleStyle := lipgloss.NewStyle().
+		Foreground(theme.Primary).
+		Bold(true)
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(theme.Primary).
+		Bold(true)
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(theme.Muted)
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render("Switch team for " + picker.target))
+	content.WriteString("\n\n")
+	switch {
+	case picker.loading:
+		content.WriteString(mutedStyle.Render("Loading teams..."))
+	case len(picker.teams) == 0:
+		content.WriteString(mutedStyle.Render(picker.user.DisplayName() + " doesn't belong to any team"))
+	default:
+		for i, team := range picker.teams {
+			line := fmt.Sprintf("%s (%s)", team, strings.Join(picker.user.Teams[team], ", "))
+			if team == picker.current {
+				line += mutedStyle.Render(" • current")
+			}
+			if i == picker.selected {
+				content.WriteString(selectedStyle.Render("> " + line))
+			} else {
+				content.WriteString("  " + line)
+			}
+			content.WriteString("\n")
+		}
+	}
+	return boxStyle.Render(strings.TrimRight(content.String(), "\n"))
+}
@@ -0,0 +1,422 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"flyby/internal/concourse"
+	"flyby/internal/config"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// TeamsViewModel represents the team administration view of a target
+type TeamsViewModel struct {
+	client   *concourse.Client
+	teams    []concourse.Team
+	selected int
+	loading  bool
+	busy     string // what is being done to a team, while fly runs
+	err      error
+	loader   loader
+
+	// form creates a team or updates the selected one's auth
+	form *teamForm
+	// destroying asks for the selected team's name before destroying it
+	destroying   bool
+	confirmInput textinput.Model
+}
+
+// teamForm collects the team name and auth config file for fly set-team
+type teamForm struct {
+	inputs  []textinput.Model // name, config file
+	focused int
+	update  bool // the name is that of an existing team
+	err     error
+}
+
+// TeamsLoadedMsg represents loaded teams data
+type TeamsLoadedMsg struct {
+	Teams []concourse.Team
+	Error error
+}
+
+// TeamSetMsg represents the result of creating or updating a team
+type TeamSetMsg struct {
+	Team    string
+	Created bool
+	Error   error
+}
+
+// TeamDestroyedMsg represents the result of destroying a team
+type TeamDestroyedMsg struct {
+	Team  string
+	Error error
+}
+
+// NewTeamsViewModel creates a new teams view model
+func NewTeamsViewModel() TeamsViewModel {
+	return TeamsViewModel{
+		loader: newLoader(),
+	}
+}
+
+// LoadTeams loads the teams of the client's target
+func (m *TeamsViewModel) LoadTeams(client *concourse.Client) tea.Cmd {
+	m.client = client
+	m.loading = true
+	m.err = nil
+	return func() tea.Msg {
+		teams, err := client.GetTeams()
+		return TeamsLoadedMsg{Teams: teams, Error: err}
+	}
+}
+
+// HandleTeamsLoaded handles loaded teams
+func (m TeamsViewModel) HandleTeamsLoaded(msg TeamsLoadedMsg) TeamsViewModel {
+	m.loading = false
+	m.err = msg.Error
+	if msg.Error == nil {
+		m.teams = msg.Teams
+	}
+	if m.selected >= len(m.teams) {
+		m.selected = max(0, len(m.teams)-1)
+	}
+	return m
+}
+
+// Update handles messages for the teams view
+func (m TeamsViewModel) Update(msg tea.KeyMsg) (TeamsViewModel, tea.Cmd) {
+	if m.form != nil {
+		return m.updateForm(msg)
+	}
+	if m.destroying {
+		return m.updateDestroy(msg)
+	}
+	if m.loading || m.busy != "" {
+		return m, nil
+	}
+
+	switch {
+	case keys.Matches(msg, actionUp):
+		if m.selected > 0 {
+			m.selected--
+		}
+	case keys.Matches(msg, actionDown):
+		if m.selected < len(m.teams)-1 {
+			m.selected++
+		}
+	case keys.Matches(msg, actionNewTeam):
+		return m.openForm("")
+	case keys.Matches(msg, actionSetTeam):
+		if len(m.teams) > 0 {
+			return m.openForm(m.teams[m.selected].Name)
+		}
+	case keys.Matches(msg, actionDestroy):
+		if len(m.teams) > 0 {
+			m.destroying = true
+			m.confirmInput = textinput.New()
+			m.confirmInput.Prompt = ""
+			m.confirmInput.Placeholder = m.teams[m.selected].Name
+			m.confirmInput.Width = 36
+			return m, tea.Batch(m.confirmInput.Focus(), textinput.Blink)
+		}
+	case keys.Matches(msg, actionRefresh):
+		cmd := m.LoadTeams(m.client)
+		return m, cmd
+	}
+	return m, nil
+}
+
+// openForm opens the set-team form, for a new team when team is ""
+func (m TeamsViewModel) openForm(team string) (TeamsViewModel, tea.Cmd) {
+	name := textinput.New()
+	name.Prompt = ""
+	name.Placeholder = "team name"
+	name.Width = 36
+	name.SetValue(team)
+
+	configFile := textinput.New()
+	configFile.Prompt = ""
+	configFile.Placeholder = "path/to/team-auth.yml"
+	configFile.Width = 36
+
+	form := &teamForm{inputs: []textinput.Model{name, configFile}, update: team != ""}
+	if form.update {
+		// The name of an existing team isn't editable, fly would create a new one
+		form.focused = 1
+	}
+	m.form = form
+	return m, tea.Batch(form.inputs[form.focused].Focus(), textinput.Blink)
+}
+
+// updateForm handles keys while the set-team form is open
+func (m TeamsViewModel) updateForm(msg tea.KeyMsg) (TeamsViewModel, tea.Cmd) {
+	form := *m.form
+	switch msg.String() {
+	case "tab", "shift+tab", "down", "up":
+		if !form.update {
+			form.inputs[form.focused].Blur()
+			form.focused = 1 - form.focused
+			m.form = &form
+			return m, form.inputs[form.focused].Focus()
+		}
+		return m, nil
+	case "enter":
+		team := strings.TrimSpace(form.inputs[0].Value())
+		path := config.ExpandHome(strings.TrimSpace(form.inputs[1].Value()))
+		switch {
+		case team == "":
+			form.err = fmt.Errorf("a team name is required")
+		case path == "":
+			form.err = fmt.Errorf("an auth config file is required")
+		default:
+			form.err = nil
+			if _, err := os.Stat(path); err != nil {
+				form.err = fmt.Errorf("can't read the auth config file: %w", err)
+			}
+		}
+		if form.err != nil {
+			m.form = &form
+			return m, nil
+		}
+		m.form = nil
+		return m, m.confirmSetTeam(team, path)
+	case "esc":
+		m.form = nil
+		return m, nil
+	}
+	var cmd tea.Cmd
+	form.inputs[form.focused], cmd = form.inputs[form.focused].Update(msg)
+	m.form = &form
+	return m, cmd
+}
+
+// confirmSetTeam asks the user before fly set-team applies the auth config,
+// as fly itself would without --non-interactive
+func (m *TeamsViewModel) confirmSetTeam(team, path string) tea.Cmd {
+	client := m.client
+	created := true
+	for _, existing := range m.teams {
+		if existing.Name == team {
+			created = false
+		}
+	}
+
+	title := "Update team"
+	message := fmt.Sprintf("Replace the auth of team '%s' on %s with %s? Users and groups not in it lose access to the team.", team, client.GetTarget(), path)
+	if created {
+		title = "Create team"
+		message = fmt.Sprintf("Create team '%s' on %s with the auth in %s?", team, client.GetTarget(), path)
+	}
+	return confirmAction(title, message, func() tea.Msg {
+		return TeamSetMsg{Team: team, Created: created, Error: client.SetTeam(team, path)}
+	})
+}
+
+// updateDestroy handles keys while the team name is asked for before
+// destroying it, like fly destroy-team does
+func (m TeamsViewModel) updateDestroy(msg tea.KeyMsg) (TeamsViewModel, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		team := m.teams[m.selected].Name
+		if strings.TrimSpace(m.confirmInput.Value()) != team {
+			return m, showToast(ToastError, "Type %s to destroy it", team)
+		}
+		m.destroying = false
+		m.busy = "Destroying " + team
+		client := m.client
+		return m, func() tea.Msg {
+			return TeamDestroyedMsg{Team: team, Error: client.DestroyTeam(team)}
+		}
+	case "esc":
+		m.destroying = false
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.confirmInput, cmd = m.confirmInput.Update(msg)
+	return m, cmd
+}
+
+// UpdateInput passes a message, like a cursor blink, to the focused text input
+func (m TeamsViewModel) UpdateInput(msg tea.Msg) (TeamsViewModel, tea.Cmd) {
+	var cmd tea.Cmd
+	switch {
+	case m.form != nil:
+		form := *m.form
+		form.inputs[form.focused], cmd = form.inputs[form.focused].Update(msg)
+		m.form = &form
+	case m.destroying:
+		m.confirmInput, cmd = m.confirmInput.Update(msg)
+	}
+	return m, cmd
+}
+
+// HandleTeamSet reports the result of fly set-team and reloads the teams
+func (m TeamsViewModel) HandleTeamSet(msg TeamSetMsg) (TeamsViewModel, tea.Cmd) {
+	if msg.Error != nil {
+		return m, showToast(ToastError, "%s", errorSummary(msg.Error))
+	}
+	action := "Updated"
+	if msg.Created {
+		action = "Created"
+	}
+	cmd := m.LoadTeams(m.client)
+	return m, tea.Batch(showToast(ToastSuccess, "%s team %s", action, msg.Team), cmd)
+}
+
+// HandleTeamDestroyed reports the result of fly destroy-team and reloads the teams
+func (m TeamsViewModel) HandleTeamDestroyed(msg TeamDestroyedMsg) (TeamsViewModel, tea.Cmd) {
+	m.busy = ""
+	if msg.Error != nil {
+		return m, showToast(ToastError, "%s", errorSummary(msg.Error))
+	}
+	cmd := m.LoadTeams(m.client)
+	return m, tea.Batch(showToast(ToastSuccess, "Destroyed team %s", msg.Team), cmd)
+}
+
+// View renders the teams view
+func (m TeamsViewModel) View(width, height int) string {
+	titleStyle := lipgloss.NewStyle().
+		Foreground(theme.Primary).
+		Bold(true).
+		MarginBottom(1)
+
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(theme.Muted)
+
+	errorStyle := lipgloss.NewStyle().
+		Foreground(theme.Error).
+		Bold(true)
+
+	itemStyle := lipgloss.NewStyle().
+		PaddingLeft(2)
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(theme.Primary).
+		Bold(true).
+		PaddingLeft(1).
+		Border(lipgloss.NormalBorder(), false, false, false, true).
+		BorderForeground(theme.Primary)
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(theme.Muted).
+		Italic(true).
+		MarginTop(1)
+
+	var content strings.Builder
+	target := ""
+	if m.client != nil {
+		target = m.client.GetTarget()
+	}
+	content.WriteString(titleStyle.Render("Teams on " + target))
+	content.WriteString("\n\n")
+
+	switch {
+	case m.loading:
+		content.WriteString(m.loader.View("Loading teams..."))
+		return content.String()
+	case m.busy != "":
+		content.WriteString(m.loader.View(m.busy + "..."))
+		return content.String()
+	case m.err != nil:
+		content.WriteString(errorStyle.Render("✗ " + errorSummary(m.err)))
+		content.WriteString("\n")
+	case len(m.teams) == 0:
+		content.WriteString(mutedStyle.Render("No teams visible to this user."))
+		content.WriteString("\n")
+	}
+
+	for i, team := range m.teams {
+		if i == m.selected {
+			content.WriteString(selectedStyle.Render("> " + team.Name))
+		} else {
+			content.WriteString(itemStyle.Render("  " + team.Name))
+		}
+		content.WriteString("\n")
+	}
+
+	switch {
+	case m.form != nil:
+		content.WriteString(m.formView(width))
+	case m.destroying:
+		content.WriteString(m.destroyView(width))
+	default:
+		content.WriteString(helpStyle.Render(keys.HelpLine(viewKeys[ViewTeams]...)))
+	}
+	return content.String()
+}
+
+// formView renders the set-team form below the teams list
+func (m TeamsViewModel) formView(width int) string {
+	form := m.form
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Primary).
+		Padding(0, 1).
+		MarginTop(1).
+		Width(min(width-2, 70))
+	titleStyle := lipgloss.NewStyle().
+		Foreground(theme.Primary).
+		Bold(true)
+	labelStyle := lipgloss.NewStyle().
+		Bold(true).
+		Width(14)
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(theme.Muted)
+	errorStyle := lipgloss.NewStyle().
+		Foreground(theme.Error)
+
+	var content strings.Builder
+	if form.update {
+		content.WriteString(titleStyle.Render("Set auth of team " + form.inputs[0].Value()))
+	} else {
+		content.WriteString(titleStyle.Render("New team"))
+	}
+	content.WriteString("\n\n")
+	for i, label := range []string{"Name", "Auth config"} {
+		line := form.inputs[i].View()
+		if i == 0 && form.update {
+			line = form.inputs[0].Value()
+		}
+		if i == form.focused {
+			label = "> " + label
+		} else {
+			label = "  " + label
+		}
+		content.WriteString(labelStyle.Render(label+":") + " " + line)
+		content.WriteString("\n")
+	}
+	content.WriteString("\n")
+	content.WriteString(mutedStyle.Render("The auth config is a fly set-team config file with the team's roles and their users and groups."))
+	if form.err != nil {
+		content.WriteString("\n")
+		content.WriteString(errorStyle.Render("✗ " + form.err.Error()))
+	}
+	return boxStyle.Render(content.String())
+}
+
+// destroyView renders the destroy handshake below the teams list
+func (m TeamsViewModel) destroyView(width int) string {
+	team := m.teams[m.selected].Name
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Error).
+		Padding(0, 1).
+		MarginTop(1).
+		Width(min(width-2, 70))
+	titleStyle := lipgloss.NewStyle().
+		Foreground(theme.Error).
+		Bold(true)
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render("Destroy team " + team))
+	content.WriteString("\n\n")
+	content.WriteString(fmt.Sprintf("This deletes every pipeline and build of team '%s' on %s and cannot be undone.\n", team, m.client.GetTarget()))
+	content.WriteString("Type the team name to continue:\n\n")
+	content.WriteString(m.confirmInput.View())
+	return boxStyle.Render(content.String())
+}
@@ -0,0 +1,55 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// terminalCommands are tried in order when no terminal command is configured
+// and FlyBy doesn't run in tmux, each followed by sh and the command to run
+var terminalCommands = [][]string{
+	{"x-terminal-emulator", "-e"},
+	{"gnome-terminal", "--"},
+	{"konsole", "-e"},
+	{"alacritty", "-e"},
+	{"kitty"},
+	{"xterm", "-e"},
+}
+
+// runInTerminal starts a shell command in a new tmux window or terminal
+// emulator window, using the configured terminal command when there is one.
+// The window stays open when the command fails, so its error can be read.
+func runInTerminal(terminal, command string) error {
+	script := command + ` || { echo; echo "Press Enter to close"; read _; }`
+	shell := []string{"sh", "-c", script}
+
+	var candidates [][]string
+	switch {
+	case strings.TrimSpace(terminal) != "":
+		candidates = [][]string{append(strings.Fields(terminal), shell...)}
+	case os.Getenv("TMUX") != "":
+		candidates = [][]string{append([]string{"tmux", "new-window"}, shell...)}
+	case runtime.GOOS == "darwin":
+		quoted := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(command)
+		candidates = [][]string{{"osascript", "-e", `tell application "Terminal" to do script "` + quoted + `"`}}
+	case runtime.GOOS == "windows":
+		// The empty title keeps start from taking the command for one
+		candidates = [][]string{{"cmd", "/c", "start", "", "cmd", "/k", command}}
+	default:
+		for _, candidate := range terminalCommands {
+			candidates = append(candidates, append(append([]string(nil), candidate...), shell...))
+		}
+	}
+
+	for _, candidate := range candidates {
+		if _, err := exec.LookPath(candidate[0]); err != nil {
+			continue
+		}
+		return exec.Command(candidate[0], candidate[1:]...).Start()
+	}
+
+	return fmt.Errorf("no terminal found to run the login in, set terminal_command in the FlyBy config")
+}
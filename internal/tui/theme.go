@@ -0,0 +1,127 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Theme holds the colors used to render every view
+type Theme struct {
+	Name     string
+	Primary  lipgloss.TerminalColor // titles, selection and highlights
+	Muted    lipgloss.TerminalColor // secondary text and help lines
+	Error    lipgloss.TerminalColor
+	Success  lipgloss.TerminalColor
+	Warning  lipgloss.TerminalColor
+	Info     lipgloss.TerminalColor
+	HeaderBg lipgloss.TerminalColor
+	HeaderFg lipgloss.TerminalColor
+	FooterBg lipgloss.TerminalColor
+	Text     lipgloss.TerminalColor // footer and breadcrumb text
+}
+
+// themes lists the built-in presets in the order they are cycled through
+var themes = []Theme{
+	{
+		// auto picks the light or dark palette from the terminal background
+		Name:     "auto",
+		Primary:  lipgloss.AdaptiveColor{Light: "125", Dark: "205"},
+		Muted:    lipgloss.AdaptiveColor{Light: "243", Dark: "240"},
+		Error:    lipgloss.AdaptiveColor{Light: "160", Dark: "196"},
+		Success:  lipgloss.AdaptiveColor{Light: "28", Dark: "46"},
+		Warning:  lipgloss.AdaptiveColor{Light: "130", Dark: "226"},
+		Info:     lipgloss.AdaptiveColor{Light: "25", Dark: "33"},
+		HeaderBg: lipgloss.AdaptiveColor{Light: "62", Dark: "62"},
+		HeaderFg: lipgloss.AdaptiveColor{Light: "231", Dark: "230"},
+		FooterBg: lipgloss.AdaptiveColor{Light: "253", Dark: "236"},
+		Text:     lipgloss.AdaptiveColor{Light: "235", Dark: "252"},
+	},
+	{
+		Name:     "dark",
+		Primary:  lipgloss.Color("205"),
+		Muted:    lipgloss.Color("240"),
+		Error:    lipgloss.Color("196"),
+		Success:  lipgloss.Color("46"),
+		Warning:  lipgloss.Color("226"),
+		Info:     lipgloss.Color("33"),
+		HeaderBg: lipgloss.Color("62"),
+		HeaderFg: lipgloss.Color("230"),
+		FooterBg: lipgloss.Color("236"),
+		Text:     lipgloss.Color("252"),
+	},
+	{
+		Name:     "light",
+		Primary:  lipgloss.Color("125"),
+		Muted:    lipgloss.Color("243"),
+		Error:    lipgloss.Color("160"),
+		Success:  lipgloss.Color("28"),
+		Warning:  lipgloss.Color("130"),
+		Info:     lipgloss.Color("25"),
+		HeaderBg: lipgloss.Color("62"),
+		HeaderFg: lipgloss.Color("231"),
+		FooterBg: lipgloss.Color("253"),
+		Text:     lipgloss.Color("235"),
+	},
+	{
+		Name:     "solarized",
+		Primary:  lipgloss.Color("#d33682"),
+		Muted:    lipgloss.Color("#657b83"),
+		Error:    lipgloss.Color("#dc322f"),
+		Success:  lipgloss.Color("#859900"),
+		Warning:  lipgloss.Color("#b58900"),
+		Info:     lipgloss.Color("#268bd2"),
+		HeaderBg: lipgloss.Color("#073642"),
+		HeaderFg: lipgloss.Color("#eee8d5"),
+		FooterBg: lipgloss.Color("#073642"),
+		Text:     lipgloss.Color("#93a1a1"),
+	},
+	{
+		Name:     "monochrome",
+		Primary:  lipgloss.Color("15"),
+		Muted:    lipgloss.Color("245"),
+		Error:    lipgloss.Color("15"),
+		Success:  lipgloss.Color("250"),
+		Warning:  lipgloss.Color("15"),
+		Info:     lipgloss.Color("250"),
+		HeaderBg: lipgloss.Color("240"),
+		HeaderFg: lipgloss.Color("15"),
+		FooterBg: lipgloss.Color("236"),
+		Text:     lipgloss.Color("252"),
+	},
+}
+
+// theme is the active theme used by all views
+var theme = themes[0]
+
+// SetTheme activates the built-in theme with the given name
+func SetTheme(name string) error {
+	for _, t := range themes {
+		if strings.EqualFold(t.Name, name) {
+			theme = t
+			return nil
+		}
+	}
+
+	var names []string
+	for _, t := range themes {
+		names = append(names, t.Name)
+	}
+	return fmt.Errorf("unknown theme %q (available: %s)", name, strings.Join(names, ", "))
+}
+
+// cycleTheme switches to the next built-in theme and returns its name
+func cycleTheme() string {
+	if theme.Name == plainTheme.Name {
+		return theme.Name
+	}
+	for i, t := range themes {
+		if t.Name == theme.Name {
+			theme = themes[(i+1)%len(themes)]
+			return theme.Name
+		}
+	}
+	theme = themes[0]
+	return theme.Name
+}
@@ -0,0 +1,129 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	toastDuration = 5 * time.Second
+	toastWidth    = 44
+	toastMaxLines = 4
+	maxToasts     = 3
+)
+
+// ToastLevel represents how a toast notification is styled
+type ToastLevel int
+
+const (
+	ToastInfo ToastLevel = iota
+	ToastSuccess
+	ToastError
+)
+
+// ToastMsg asks the app to show a toast notification
+type ToastMsg struct {
+	Level ToastLevel
+	Text  string
+}
+
+// dismissToastMsg removes a toast once its time is up
+type dismissToastMsg struct {
+	id int
+}
+
+// toast is a notification currently on screen
+type toast struct {
+	id    int
+	level ToastLevel
+	text  string
+}
+
+// showToast returns a command that pushes a toast notification
+func showToast(level ToastLevel, format string, args ...interface{}) tea.Cmd {
+	text := fmt.Sprintf(format, args...)
+	return func() tea.Msg {
+		return ToastMsg{Level: level, Text: text}
+	}
+}
+
+// pushToast shows a toast and schedules its dismissal
+func (m *Model) pushToast(msg ToastMsg) tea.Cmd {
+	m.nextToastID++
+	id := m.nextToastID
+	m.toasts = append(m.toasts, toast{id: id, level: msg.Level, text: strings.TrimSpace(msg.Text)})
+	if len(m.toasts) > maxToasts {
+		m.toasts = m.toasts[len(m.toasts)-maxToasts:]
+	}
+	return tea.Tick(toastDuration, func(time.Time) tea.Msg {
+		return dismissToastMsg{id: id}
+	})
+}
+
+// dismissToast removes the toast with the given id
+func (m *Model) dismissToast(id int) {
+	for i, t := range m.toasts {
+		if t.id == id {
+			m.toasts = append(m.toasts[:i], m.toasts[i+1:]...)
+			return
+		}
+	}
+}
+
+// renderToasts renders the visible toasts stacked on top of each other
+func (m *Model) renderToasts() string {
+	if len(m.toasts) == 0 {
+		return ""
+	}
+
+	width := min(toastWidth, m.width-2)
+	var rendered []string
+	for _, t := range m.toasts {
+		color, icon := theme.Info, "ℹ"
+		switch t.level {
+		case ToastSuccess:
+			color, icon = theme.Success, "✅"
+		case ToastError:
+			color, icon = theme.Error, "❌"
+		}
+
+		style := lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(color).
+			Foreground(theme.Text).
+			Padding(0, 1).
+			Width(width - 2)
+
+		lines := strings.Split(lipgloss.NewStyle().Width(width-4).Render(icon+" "+t.text), "\n")
+		if len(lines) > toastMaxLines {
+			more := "…"
+			if t.level == ToastError {
+				more += fmt.Sprintf(" (%s: details)", keys.Label(actionErrorDetails))
+			}
+			lines = append(lines[:toastMaxLines-1], more)
+		}
+		rendered = append(rendered, style.Render(strings.Join(lines, "\n")))
+	}
+	return lipgloss.JoinVertical(lipgloss.Right, rendered...)
+}
+
+// overlayToasts draws the toasts in the bottom-right corner of the view,
+// just above the footer
+func (m *Model) overlayToasts(view string) string {
+	toasts := m.renderToasts()
+	if toasts == "" {
+		return view
+	}
+
+	// Keep the footer visible below the toasts
+	top := strings.Count(view, "\n") - lipgloss.Height(toasts)
+	left := m.width - lipgloss.Width(toasts) - 1
+	if top < 0 || left < 0 {
+		return view
+	}
+	return placeOverlay(view, toasts, left, top)
+}
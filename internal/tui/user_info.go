@@ -0,0 +1,72 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"flyby/internal/concourse"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// UserInfoMsg carries the user fly is logged in as on a target
+type UserInfoMsg struct {
+	Target string
+	User   concourse.UserInfo
+	Error  error
+}
+
+// loadUserInfo fetches who the current target's actions run as, unless it
+// was fetched before
+func (m *Model) loadUserInfo() tea.Cmd {
+	if m.currentTarget == "" || m.client == nil {
+		return nil
+	}
+	if _, fetched := m.userInfo[m.currentTarget]; fetched {
+		return nil
+	}
+
+	client := m.client
+	return func() tea.Msg {
+		user, err := client.Userinfo()
+		return UserInfoMsg{Target: client.GetTarget(), User: user, Error: err}
+	}
+}
+
+// handleUserInfo remembers a target's user. Like cluster info, a failure is
+// kept as an empty user so it isn't retried on every view switch.
+func (m *Model) handleUserInfo(msg UserInfoMsg) {
+	if concourse.IsCanceled(msg.Error) {
+		// Fetched again on the next view switch
+		return
+	}
+	m.userInfo[msg.Target] = msg.User
+}
+
+// formatUser describes a user and their roles in team, e.g. "admin (owner)"
+func formatUser(user concourse.UserInfo, team string) string {
+	name := user.DisplayName()
+	if roles := user.Teams[team]; len(roles) > 0 {
+		name += fmt.Sprintf(" (%s)", strings.Join(roles, ", "))
+	}
+	if user.IsAdmin {
+		name += " [admin]"
+	}
+	return name
+}
+
+// formatTeamRoles lists a user's teams with their roles, e.g. "main (owner),
+// ops (viewer)"
+func formatTeamRoles(user concourse.UserInfo) string {
+	teams := make([]string, 0, len(user.Teams))
+	for team := range user.Teams {
+		teams = append(teams, team)
+	}
+	sort.Strings(teams)
+
+	for i, team := range teams {
+		teams[i] = fmt.Sprintf("%s (%s)", team, strings.Join(user.Teams[team], ", "))
+	}
+	return strings.Join(teams, ", ")
+}
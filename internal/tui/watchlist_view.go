@@ -0,0 +1,420 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"flyby/internal/concourse"
+	"flyby/internal/state"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// watchStatus represents the latest known status of a watched item
+type watchStatus struct {
+	Status  string // latest finished build status, or the worst one for pipelines
+	Build   string
+	Running bool
+	Summary string
+	Err     error
+}
+
+// WatchlistViewModel represents the watchlist view
+type WatchlistViewModel struct {
+	stateStore      *state.Store
+	refreshInterval time.Duration
+	items           []state.WatchItem
+	statuses        map[string]watchStatus
+	selected        int
+	scrollOffset    int
+	maxVisible      int
+	loading         bool
+	lastRefresh     time.Time
+	generation      int
+	err             error
+	clicks          clickTracker
+	loader          loader
+}
+
+// WatchlistLoadedMsg represents freshly loaded watchlist statuses
+type WatchlistLoadedMsg struct {
+	Statuses   map[string]watchStatus
+	Generation int
+}
+
+// WatchlistTickMsg triggers a periodic watchlist refresh
+type WatchlistTickMsg struct {
+	Generation int
+}
+
+// NewWatchlistViewModel creates a new watchlist view model
+func NewWatchlistViewModel(stateStore *state.Store, refreshInterval time.Duration) WatchlistViewModel {
+	return WatchlistViewModel{
+		stateStore:      stateStore,
+		refreshInterval: refreshInterval,
+		statuses:        make(map[string]watchStatus),
+		maxVisible:      10,
+		loader:          newLoader(),
+	}
+}
+
+// Activate reloads the watchlist and starts its auto-refresh loop
+func (m *WatchlistViewModel) Activate() tea.Cmd {
+	m.generation++
+	m.items = m.watched()
+	if m.selected >= len(m.items) {
+		m.selected = 0
+		m.scrollOffset = 0
+	}
+	return tea.Batch(m.loadStatuses(), m.scheduleRefresh())
+}
+
+// watched returns the watched jobs and pipelines of the active group's
+// targets
+func (m WatchlistViewModel) watched() []state.WatchItem {
+	var items []state.WatchItem
+	for _, item := range m.stateStore.GetWatchlist() {
+		if inActiveGroup(item.Target) {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// scheduleRefresh schedules the next automatic refresh
+func (m WatchlistViewModel) scheduleRefresh() tea.Cmd {
+	generation := m.generation
+	return tea.Tick(m.refreshInterval, func(time.Time) tea.Msg {
+		return WatchlistTickMsg{Generation: generation}
+	})
+}
+
+// HandleTick refreshes the watchlist if the tick belongs to the active refresh loop
+func (m WatchlistViewModel) HandleTick(msg WatchlistTickMsg, active bool) (WatchlistViewModel, tea.Cmd) {
+	if !active || msg.Generation != m.generation {
+		// Stale loop from a previous visit, let it die
+		return m, nil
+	}
+	cmd := m.loadStatuses()
+	return m, tea.Batch(cmd, m.scheduleRefresh())
+}
+
+// loadStatuses fetches the latest job statuses for every watched item
+func (m *WatchlistViewModel) loadStatuses() tea.Cmd {
+	if len(m.items) == 0 {
+		return nil
+	}
+
+	m.loading = true
+	items := append([]state.WatchItem(nil), m.items...)
+	generation := m.generation
+
+	return func() tea.Msg {
+		// Fetch each pipeline only once, even if several of its jobs are watched
+		type pipelineKey struct{ target, pipeline string }
+		jobsByPipeline := make(map[pipelineKey][]concourse.Job)
+		errsByPipeline := make(map[pipelineKey]error)
+		for _, item := range items {
+			jobsByPipeline[pipelineKey{item.Target, item.Pipeline}] = nil
+		}
+
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		for key := range jobsByPipeline {
+			wg.Add(1)
+			go func(key pipelineKey) {
+				defer wg.Done()
+				// The refresh interval may be shorter than fly's output is cached
+				jobs, err := concourse.NewClient(key.target).Uncached().GetJobs(key.pipeline)
+				mu.Lock()
+				defer mu.Unlock()
+				jobsByPipeline[key] = jobs
+				errsByPipeline[key] = err
+			}(key)
+		}
+		wg.Wait()
+
+		statuses := make(map[string]watchStatus)
+		for _, item := range items {
+			key := pipelineKey{item.Target, item.Pipeline}
+			if err := errsByPipeline[key]; err != nil {
+				statuses[item.Key()] = watchStatus{Err: err}
+				continue
+			}
+			statuses[item.Key()] = summarizeWatchItem(item, jobsByPipeline[key])
+		}
+		return WatchlistLoadedMsg{Statuses: statuses, Generation: generation}
+	}
+}
+
+// summarizeWatchItem derives the status of a watched job or pipeline from its jobs
+func summarizeWatchItem(item state.WatchItem, jobs []concourse.Job) watchStatus {
+	if item.Job != "" {
+		for _, job := range jobs {
+			if job.Name == item.Job {
+				return watchStatus{
+					Status:  job.FinishedBuild.Status,
+					Build:   job.FinishedBuild.Name,
+					Running: job.NextBuild.ID != 0,
+				}
+			}
+		}
+		return watchStatus{Err: fmt.Errorf("job not found")}
+	}
+
+	// For whole pipelines, report the worst latest status across its jobs
+	severity := map[string]int{"succeeded": 1, "aborted": 2, "errored": 3, "failed": 4}
+	var result watchStatus
+	var succeeded, failed, running int
+	for _, job := range jobs {
+		status := job.FinishedBuild.Status
+		if severity[status] > severity[result.Status] {
+			result.Status = status
+		}
+		if job.NextBuild.ID != 0 {
+			running++
+			result.Running = true
+		}
+		switch status {
+		case "succeeded":
+			succeeded++
+		case "failed", "errored", "aborted":
+			failed++
+		}
+	}
+	result.Summary = fmt.Sprintf("%d ok, %d failing, %d running", succeeded, failed, running)
+	return result
+}
+
+// HandleWatchlistLoaded handles the watchlist loaded message
+func (m WatchlistViewModel) HandleWatchlistLoaded(msg WatchlistLoadedMsg) WatchlistViewModel {
+	if msg.Generation != m.generation {
+		return m
+	}
+	m.loading = false
+	m.statuses = msg.Statuses
+	m.lastRefresh = time.Now()
+	return m
+}
+
+// Update handles messages for the watchlist view
+func (m WatchlistViewModel) Update(msg tea.KeyMsg) (WatchlistViewModel, tea.Cmd) {
+	switch {
+	case keys.Matches(msg, actionUp):
+		if m.selected > 0 {
+			m.selected--
+			if m.selected < m.scrollOffset {
+				m.scrollOffset = m.selected
+			}
+		}
+	case keys.Matches(msg, actionDown):
+		if m.selected < len(m.items)-1 {
+			m.selected++
+			if m.selected >= m.scrollOffset+m.maxVisible {
+				m.scrollOffset = m.selected - m.maxVisible + 1
+			}
+		}
+	case keys.Matches(msg, actionRefresh):
+		cmd := m.loadStatuses()
+		return m, cmd
+	case keys.Matches(msg, actionDelete):
+		if len(m.items) > 0 {
+			m.err = m.stateStore.RemoveWatch(m.items[m.selected])
+			m.items = m.watched()
+			if m.selected >= len(m.items) && m.selected > 0 {
+				m.selected--
+			}
+			if m.scrollOffset > m.selected {
+				m.scrollOffset = m.selected
+			}
+		}
+	case keys.Matches(msg, actionSelect):
+		if len(m.items) > 0 {
+			item := m.items[m.selected]
+			if item.Job != "" {
+				return m, func() tea.Msg {
+					return SwitchViewMsg{View: ViewBuilds, Target: item.Target, Pipeline: item.Pipeline, Job: item.Job}
+				}
+			}
+			return m, func() tea.Msg {
+				return SwitchViewMsg{View: ViewJobs, Target: item.Target, Pipeline: item.Pipeline}
+			}
+		}
+	}
+
+	return m, nil
+}
+
+// visibleRange returns the range of watched items shown for the given height,
+// keeping the selected item in view
+func (m WatchlistViewModel) visibleRange(height int) (int, int) {
+	maxVisible := m.maxVisible
+	if height-8 > 0 {
+		maxVisible = max((height-8)/2, 1)
+	}
+	start := m.scrollOffset
+	if m.selected >= start+maxVisible {
+		start = m.selected - maxVisible + 1
+	}
+	if m.selected < start {
+		start = m.selected
+	}
+	return start, min(start+maxVisible, len(m.items))
+}
+
+// Mouse handles clicks and scrolling over the watchlist
+func (m WatchlistViewModel) Mouse(msg tea.MouseMsg, height int) (WatchlistViewModel, tea.Cmd) {
+	// Rows start below the title and the refresh summary line
+	start, end := m.visibleRange(height)
+	rows := listRows{top: 5, height: 2, first: start, end: end}
+	if start > 0 {
+		rows.top += 2 // "more above" indicator
+	}
+
+	switch intent, index := m.clicks.interpret(msg, rows); intent {
+	case mouseScrollUp:
+		return m.Update(keyMsgFor(actionUp))
+	case mouseScrollDown:
+		return m.Update(keyMsgFor(actionDown))
+	case mouseClick:
+		m.selected = index
+	case mouseDoubleClick:
+		m.selected = index
+		return m.Update(keyMsgFor(actionSelect))
+	}
+	return m, nil
+}
+
+// View renders the watchlist view
+func (m WatchlistViewModel) View(width, height int) string {
+	titleStyle := lipgloss.NewStyle().
+		Foreground(theme.Primary).
+		Bold(true).
+		MarginBottom(1)
+
+	itemStyle := lipgloss.NewStyle().
+		PaddingLeft(2).
+		MarginBottom(1)
+
+	selectedStyle := itemStyle.Copy().
+		Foreground(theme.Primary).
+		Bold(true).
+		PaddingLeft(1).
+		Border(lipgloss.NormalBorder(), false, false, false, true).
+		BorderForeground(theme.Primary)
+
+	mutedStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+
+	var content strings.Builder
+	title := "Watchlist"
+	if activeGroup != "" {
+		title += " - group " + activeGroup
+	}
+	content.WriteString(titleStyle.Render(title))
+	content.WriteString("\n\n")
+
+	if len(m.items) == 0 && activeGroup != "" && len(m.stateStore.GetWatchlist()) > 0 {
+		content.WriteString(fmt.Sprintf("Nothing of group %s is watched. Press %s for another group.\n", activeGroup, keys.Label(actionTargetGroup)))
+		return content.String()
+	}
+	if len(m.items) == 0 {
+		content.WriteString("Nothing watched yet. Press 'w' on a pipeline or job to add it here.\n")
+		return content.String()
+	}
+
+	refreshed := "loading..."
+	if !m.lastRefresh.IsZero() {
+		refreshed = "updated " + m.lastRefresh.Format("15:04:05")
+		if m.loading {
+			refreshed += " (refreshing...)"
+		}
+	}
+	summary := mutedStyle.Render(fmt.Sprintf("%d watched • %s • auto-refresh every %s", len(m.items), refreshed, m.refreshInterval))
+	if m.loading {
+		summary = m.loader.View(summary)
+	}
+	content.WriteString(summary)
+	content.WriteString("\n\n")
+
+	start, end := m.visibleRange(height)
+
+	if start > 0 {
+		content.WriteString(itemStyle.Render("  ↑ (more above)"))
+		content.WriteString("\n")
+	}
+
+	for i := start; i < end; i++ {
+		item := m.items[i]
+		name := fmt.Sprintf("%s › %s", item.Target, item.Pipeline)
+		if item.Job != "" {
+			name += "/" + item.Job
+		}
+
+		line := name + " " + m.renderStatus(m.statuses[item.Key()], item)
+		if i == m.selected {
+			content.WriteString(selectedStyle.Render("> " + line))
+		} else {
+			content.WriteString(itemStyle.Render("  " + line))
+		}
+		content.WriteString("\n")
+	}
+
+	if end < len(m.items) {
+		content.WriteString(itemStyle.Render("  ↓ (more below)"))
+		content.WriteString("\n")
+	}
+
+	if m.err != nil {
+		errorStyle := lipgloss.NewStyle().Foreground(theme.Error)
+		content.WriteString(errorStyle.Render("Error: " + errorSummary(m.err)))
+		content.WriteString("\n")
+	}
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(theme.Muted).
+		Italic(true).
+		MarginTop(1)
+	content.WriteString(helpStyle.Render(keys.HelpLine(viewKeys[ViewWatchlist]...)))
+
+	return content.String()
+}
+
+// renderStatus renders the colored status badge for a watched item
+func (m WatchlistViewModel) renderStatus(status watchStatus, item state.WatchItem) string {
+	if status.Err != nil {
+		errorStyle := lipgloss.NewStyle().Foreground(theme.Error)
+		return errorStyle.Render(fmt.Sprintf("[ERROR: %v]", status.Err))
+	}
+	if status.Status == "" && !status.Running {
+		return lipgloss.NewStyle().Foreground(theme.Muted).Render("[UNKNOWN]")
+	}
+
+	statusColor := theme.Muted
+	switch status.Status {
+	case "succeeded":
+		statusColor = theme.Success
+	case "failed", "errored":
+		statusColor = theme.Error
+	case "aborted":
+		statusColor = theme.Warning
+	}
+
+	badge := "[NO BUILDS]"
+	if status.Status != "" {
+		badge = fmt.Sprintf("[%s]", strings.ToUpper(status.Status))
+	}
+	line := lipgloss.NewStyle().Foreground(statusColor).Bold(true).Render(badge)
+	if status.Build != "" {
+		line += " #" + status.Build
+	}
+	if status.Running {
+		line += lipgloss.NewStyle().Foreground(theme.Warning).Render(" (running)")
+	}
+	if item.Job == "" && status.Summary != "" {
+		line += " " + lipgloss.NewStyle().Foreground(theme.Muted).Render(status.Summary)
+	}
+	return line
+}
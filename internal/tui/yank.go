@@ -0,0 +1,68 @@
+package tui
+
+import (
+	"flyby/internal/concourse"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// selectedCommand returns the fly command doing what the current view does
+// with its selected item, if it has one
+func (m *Model) selectedCommand() (string, bool) {
+	var args []string
+	switch m.currentView {
+	case ViewTargets:
+		if m.targetsView.selected < len(m.targetsView.filteredTargets) {
+			target := m.targetsView.filteredTargets[m.targetsView.selected]
+			// The flyrc only keeps the CA certificate's contents, fly login
+			// needs a path to it
+			tls := concourse.TLSOptions{
+				Insecure:   target.Insecure,
+				ClientCert: target.ClientCert,
+				ClientKey:  target.ClientKey,
+			}
+			args = append([]string{"-t", target.Name}, concourse.LoginArgs(target.GetURL(), target.Team, tls)...)
+		}
+	case ViewPipelines:
+		if m.pipelinesView.selected < len(m.pipelinesView.filteredPipelines) {
+			pipeline := m.pipelinesView.filteredPipelines[m.pipelinesView.selected]
+			args = []string{"-t", m.currentTarget, "jobs", "-p", pipeline.Ref()}
+		}
+	case ViewJobs:
+		if m.jobsView.selected < len(m.jobsView.filteredJobs) {
+			job := m.jobsView.filteredJobs[m.jobsView.selected]
+			args = []string{"-t", m.currentTarget, "trigger-job", "-j", job.PipelineRef() + "/" + job.Name}
+		}
+	case ViewBuilds:
+		if m.buildsView.state != buildsStateLoading && m.buildsView.cursor < len(m.buildsView.builds) {
+			build := m.buildsView.builds[m.buildsView.cursor]
+			args = []string{"-t", m.currentTarget, "rerun-build", "-j", m.buildsView.pipeline + "/" + m.buildsView.job, "-b", build.Name}
+		}
+	case ViewResources:
+		if m.resourcesView.selected < len(m.resourcesView.filteredResources) {
+			resource := m.resourcesView.filteredResources[m.resourcesView.selected]
+			args = []string{"-t", m.currentTarget, "check-resource", "-r", resource.PipelineRef() + "/" + resource.Name}
+		}
+	case ViewBuildLog:
+		args = []string{"-t", m.currentTarget, "watch", "-j", m.buildLogView.pipeline + "/" + m.buildLogView.job, "-b", m.buildLogView.build}
+	case ViewConfig:
+		args = []string{"-t", m.currentTarget, "get-pipeline", "-p", m.configView.pipeline}
+	}
+	if args == nil {
+		return "", false
+	}
+	return concourse.CommandRecord{Args: append([]string{"fly"}, args...)}.Command(), true
+}
+
+// yankSelected copies the fly command for the selected item to the clipboard,
+// or returns nil when the current view has nothing to copy
+func (m *Model) yankSelected() tea.Cmd {
+	command, ok := m.selectedCommand()
+	if !ok {
+		return nil
+	}
+	if err := copyToClipboard(m.settings.ClipboardCommand, command); err != nil {
+		return showToast(ToastError, "Failed to copy command: %v", err)
+	}
+	return showToast(ToastSuccess, "Copied: %s", command)
+}